@@ -0,0 +1,277 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/untillpro/qs/utils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+// BackupBranch saves the tip of the branch and a snapshot of the notes under refs/qs before the branch is deleted or reset.
+// The ID of the backup is returned, empty if the branch does not exist.
+func BackupBranch(rc *RepoContext, branchName string) (string, error) {
+	sha := RefSHA(rc, refsHeads+branchName)
+	if len(sha) == 0 {
+		return "", nil
+	}
+
+	timestamp := time.Now().UTC().Format(backupTimeLayout)
+	if notesSHA := RefSHA(rc, notesRef); len(notesSHA) > 0 {
+		if _, stderr, err := rc.run(git, "update-ref", backupNotesRefsPrefix+timestamp, notesSHA); err != nil {
+			logger.Verbose(stderr)
+			return "", fmt.Errorf("failed to back up notes: %w", err)
+		}
+	}
+	id := timestamp + slash + branchName
+	if _, stderr, err := rc.run(git, "update-ref", backupRefsPrefix+id, sha); err != nil {
+		logger.Verbose(stderr)
+		return "", fmt.Errorf("failed to back up branch %s: %w", branchName, err)
+	}
+	logger.Verbose(fmt.Sprintf("branch %s is backed up as %s", branchName, id))
+
+	return id, nil
+}
+
+// ListBackups returns the backups, the latest first
+func ListBackups(rc *RepoContext) ([]Backup, error) {
+	stdout, stderr, err := rc.run(git, "for-each-ref", "--format=%(refname)%09%(objectname)%09%(subject)", backupRefsPrefix, backupNotesRefsPrefix)
+	if err != nil {
+		logger.Verbose(stderr)
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	var backups []Backup
+	notesSnapshots := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(stdout), caret) {
+		fields := strings.SplitN(line, "\t", 3) //nolint:revive
+		if len(fields) < 2 {
+			continue
+		}
+		if timestamp, ok := strings.CutPrefix(fields[0], backupNotesRefsPrefix); ok {
+			notesSnapshots[timestamp] = fields[1]
+			continue
+		}
+		id := strings.TrimPrefix(fields[0], backupRefsPrefix)
+		timestamp, branchName, ok := strings.Cut(id, slash)
+		if !ok {
+			continue
+		}
+		createdAt, err := time.Parse(backupTimeLayout, timestamp)
+		if err != nil {
+			logger.Verbose(fmt.Sprintf("unexpected backup ref %s: %v", fields[0], err))
+			continue
+		}
+		b := Backup{ID: id, Branch: branchName, CreatedAt: createdAt, SHA: fields[1]}
+		if len(fields) > 2 { //nolint:revive
+			b.Subject = fields[2]
+		}
+		backups = append(backups, b)
+	}
+	for i := range backups {
+		backups[i].NotesSHA = notesSnapshots[backups[i].CreatedAt.Format(backupTimeLayout)]
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		if !backups[i].CreatedAt.Equal(backups[j].CreatedAt) {
+			return backups[i].CreatedAt.After(backups[j].CreatedAt)
+		}
+		return backups[i].Branch < backups[j].Branch
+	})
+
+	return backups, nil
+}
+
+// RestoreBackup brings the branch back and restores the notes of its commits missing in refs/notes/commits.
+// The existing branch is moved to the backup only if force is true, the branch is backed up before then.
+func RestoreBackup(rc *RepoContext, b Backup, force bool) error {
+	if len(RefSHA(rc, refsHeads+b.Branch)) > 0 {
+		if !force {
+			return fmt.Errorf("%w: %s, use --force to reset it to the backup", ErrBranchExists, b.Branch)
+		}
+		if err := resetBranchToBackup(rc, b); err != nil {
+			return err
+		}
+	} else if _, stderr, err := rc.run(git, branch, b.Branch, b.SHA); err != nil {
+		logger.Verbose(stderr)
+		return fmt.Errorf("failed to restore branch %s: %w", b.Branch, err)
+	}
+
+	if len(b.NotesSHA) == 0 {
+		return nil
+	}
+
+	return restoreBackupNotes(rc, b)
+}
+
+// resetBranchToBackup backs up the branch and points it to the backup, the working tree is updated if it is the current branch
+func resetBranchToBackup(rc *RepoContext, b Backup) error {
+	if _, err := BackupBranch(rc, b.Branch); err != nil {
+		return err
+	}
+
+	currentBranch, err := GetCurrentBranchName(rc)
+	if err != nil {
+		return err
+	}
+	args := []string{branch, "--force", b.Branch, b.SHA}
+	if currentBranch == b.Branch {
+		// --keep refuses to discard uncommitted changes
+		args = []string{"reset", "--keep", b.SHA}
+	}
+	if _, stderr, err := rc.run(git, args...); err != nil {
+		logger.Verbose(stderr)
+		return fmt.Errorf("failed to reset branch %s: %w", b.Branch, err)
+	}
+
+	return nil
+}
+
+// restoreBackupNotes adds the notes of the backup commits missing in refs/notes/commits and pushes the notes
+func restoreBackupNotes(rc *RepoContext, b Backup) error {
+	// notes are read from the snapshot tree since git notes refuses to read refs outside of refs/notes/
+	stdout, stderr, err := rc.run(git, "ls-tree", "-r", b.NotesSHA)
+	if err != nil {
+		logger.Verbose(stderr)
+		return fmt.Errorf("failed to read notes backup: %w", err)
+	}
+	noteBlobs := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(stdout), caret) {
+		// <mode> blob <sha>\t<path>, path is the annotated commit SHA split by fanout directories
+		meta, path, ok := strings.Cut(line, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 { //nolint:revive
+			continue
+		}
+		noteBlobs[strings.ReplaceAll(path, slash, "")] = fields[2]
+	}
+
+	// the notes are updated from origin first to be pushed back afterwards
+	if _, stderr, err := rc.run(git, fetch, origin, "--force", utils.RefsNotes); err != nil {
+		logger.Verbose(stderr)
+	}
+
+	// <blob> <annotated commit> lines
+	stdout, stderr, err = rc.run(git, "notes", "list")
+	if err != nil {
+		logger.Verbose(stderr)
+		return fmt.Errorf("failed to list notes: %w", err)
+	}
+	annotated := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSpace(stdout), caret) {
+		if fields := strings.Fields(line); len(fields) == 2 { //nolint:revive
+			annotated[fields[1]] = true
+		}
+	}
+
+	revs, err := backupRevisions(rc, b)
+	if err != nil {
+		return err
+	}
+	restored := 0
+	for _, rev := range revs {
+		blob, ok := noteBlobs[rev]
+		if !ok || annotated[rev] {
+			continue
+		}
+		if _, stderr, err := rc.run(git, "notes", "add", "-C", blob, rev); err != nil {
+			logger.Verbose(stderr)
+			return fmt.Errorf("failed to restore notes of %s: %w", rev, err)
+		}
+		restored++
+	}
+	if restored == 0 {
+		return nil
+	}
+
+	err = utils.Retry(rc.Context(), func() error {
+		_, stderr, err := rc.run(git, push, origin, utils.RefsNotes)
+		if err != nil {
+			logger.Verbose(stderr)
+			return fmt.Errorf("failed to push notes to origin: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		fmt.Println("Notes are restored locally only:", err)
+	}
+
+	return nil
+}
+
+// backupRevisions returns the commits of the backup which are not in the main branch
+func backupRevisions(rc *RepoContext, b Backup) ([]string, error) {
+	rangeSpec := b.SHA
+	if mainBranch, err := GetMainBranch(rc); err == nil && mainBranch != b.Branch {
+		rangeSpec = mainBranch + ".." + b.SHA
+	}
+	stdout, stderr, err := rc.run(git, "rev-list", rangeSpec)
+	if err != nil {
+		logger.Verbose(stderr)
+		return nil, fmt.Errorf("failed to get commits of backup %s: %w", b.ID, err)
+	}
+
+	return strings.Fields(stdout), nil
+}
+
+// PruneBackups deletes the backups created before the given time and the notes snapshots not used by other backups
+func PruneBackups(rc *RepoContext, before time.Time) ([]Backup, error) {
+	backups, err := ListBackups(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []Backup
+	keptSnapshots := make(map[string]bool)
+	for _, b := range backups {
+		timestamp := b.CreatedAt.Format(backupTimeLayout)
+		if !b.CreatedAt.Before(before) {
+			keptSnapshots[timestamp] = true
+			continue
+		}
+		if _, stderr, err := rc.run(git, "update-ref", "-d", backupRefsPrefix+b.ID); err != nil {
+			logger.Verbose(stderr)
+			return pruned, fmt.Errorf("failed to delete backup %s: %w", b.ID, err)
+		}
+		pruned = append(pruned, b)
+	}
+	deletedSnapshots := make(map[string]bool)
+	for _, b := range pruned {
+		timestamp := b.CreatedAt.Format(backupTimeLayout)
+		if len(b.NotesSHA) == 0 || keptSnapshots[timestamp] || deletedSnapshots[timestamp] {
+			continue
+		}
+		deletedSnapshots[timestamp] = true
+		if _, stderr, err := rc.run(git, "update-ref", "-d", backupNotesRefsPrefix+timestamp); err != nil {
+			logger.Verbose(stderr)
+			return pruned, fmt.Errorf("failed to delete notes backup %s: %w", timestamp, err)
+		}
+	}
+
+	return pruned, nil
+}
+
+// FindBackups returns the backups matching the ID, the timestamp or the branch name, the latest backup for each branch
+func FindBackups(backups []Backup, query string) []Backup {
+	var found []Backup
+	seen := make(map[string]bool)
+	for _, b := range backups {
+		if b.ID != query && b.Branch != query && b.CreatedAt.Format(backupTimeLayout) != query {
+			continue
+		}
+		if seen[b.Branch] {
+			continue
+		}
+		seen[b.Branch] = true
+		found = append(found, b)
+	}
+
+	return found
+}
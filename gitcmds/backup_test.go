@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const backupRefsOutput = "refs/qs/backup-notes/20260101-100000\tn1\tNotes added by 'git notes append'\n" +
+	"refs/qs/backup/20260101-100000/feature-dev\ta1\twork\n" +
+	"refs/qs/backup/20260101-100000/bugfix-dev\tb1\tfix\n" +
+	"refs/qs/backup/20260301-120000/feature-dev\ta2\tmore work\n"
+
+func TestListBackups(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git for-each-ref").Return(backupRefsOutput, "", nil)
+
+	backups, err := ListBackups(rc)
+	require.NoError(t, err)
+	require.Equal(t, []Backup{
+		{
+			ID:        "20260301-120000/feature-dev",
+			Branch:    "feature-dev",
+			CreatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+			SHA:       "a2",
+			Subject:   "more work",
+		},
+		{
+			ID:        "20260101-100000/bugfix-dev",
+			Branch:    "bugfix-dev",
+			CreatedAt: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			SHA:       "b1",
+			Subject:   "fix",
+			NotesSHA:  "n1",
+		},
+		{
+			ID:        "20260101-100000/feature-dev",
+			Branch:    "feature-dev",
+			CreatedAt: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			SHA:       "a1",
+			Subject:   "work",
+			NotesSHA:  "n1",
+		},
+	}, backups)
+
+	t.Run("find", func(t *testing.T) {
+		require.Equal(t, []string{"a2"}, backupSHAs(FindBackups(backups, "feature-dev")))
+		require.Equal(t, []string{"b1", "a1"}, backupSHAs(FindBackups(backups, "20260101-100000")))
+		require.Equal(t, []string{"a1"}, backupSHAs(FindBackups(backups, "20260101-100000/feature-dev")))
+		require.Empty(t, FindBackups(backups, "main"))
+	})
+}
+
+func TestPruneBackups(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git for-each-ref").Return(backupRefsOutput, "", nil)
+
+	pruned, err := PruneBackups(rc, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, []string{"b1", "a1"}, backupSHAs(pruned))
+	require.True(t, fake.Ran("git update-ref -d refs/qs/backup/20260101-100000/bugfix-dev"))
+	require.True(t, fake.Ran("git update-ref -d refs/qs/backup/20260101-100000/feature-dev"))
+	require.True(t, fake.Ran("git update-ref -d refs/qs/backup-notes/20260101-100000"))
+	require.False(t, fake.Ran("git update-ref -d refs/qs/backup/20260301-120000/feature-dev"))
+}
+
+func TestRestoreBackup(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	b := Backup{ID: "20260101-100000/feature-dev", Branch: "feature-dev", SHA: "a1", NotesSHA: "n1"}
+	fake.On("git rev-parse --verify --quiet refs/heads/feature-dev").Return("", "", errors.New("exit status 1"))
+	fake.On("git ls-tree -r n1").Return("100644 blob nb1\tc1\n100644 blob nb2\tc2/c2\n", "", nil)
+	fake.On("git notes list").Return("nb2 c2c2\n", "", nil)
+	fake.On("git branch -r").Return("  origin/main\n", "", nil)
+	fake.On("git rev-list main..a1").Return("a1\nc2c2\nc1\n", "", nil)
+
+	require.NoError(t, RestoreBackup(rc, b, false))
+	require.True(t, fake.Ran("git branch feature-dev a1"))
+	require.True(t, fake.Ran("git notes add -C nb1 c1"))
+	require.False(t, fake.Ran("git notes add -C nb2"), "existing notes are kept")
+	require.True(t, fake.Ran("git push origin refs/notes/*:refs/notes/*"))
+
+	t.Run("existing branch is not reset without force", func(t *testing.T) {
+		rc, fake := newFakeRepoContext(t)
+		fake.On("git rev-parse --verify --quiet refs/heads/feature-dev").Return("a0\n", "", nil)
+		require.Error(t, RestoreBackup(rc, b, false))
+		require.False(t, fake.Ran("git branch"))
+	})
+}
+
+func backupSHAs(backups []Backup) []string {
+	res := make([]string, 0, len(backups))
+	for _, b := range backups {
+		res = append(res, b.SHA)
+	}
+
+	return res
+}
+
+func TestDownload_DivergedUpstreamBackedUpOnce(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	t.Setenv("QS_MAX_RETRIES", "2")
+	t.Setenv("QS_RETRY_DELAY_MS", "1")
+	fake.On("git branch --show-current").Return("main\n", "", nil)
+	fake.On("git branch -r").Return("  origin/main\n  upstream/main\n", "", nil)
+	fake.On("git remote").Return("origin\nupstream\n", "", nil)
+	fake.On("git pull --ff-only upstream main").Return("", "fatal: Not possible to fast-forward, aborting.", errors.New("exit status 128"))
+	fake.On("git rev-parse --verify --quiet refs/heads/main").Return("a1\n", "", nil)
+
+	err := Download(rc)
+	require.ErrorIs(t, err, ErrDiverged)
+	calls := fake.Calls()
+	require.Equal(t, 1, countCalls(calls, "git pull --ff-only upstream main"), "diverged branches are not retried")
+	backups := 0
+	for _, call := range calls {
+		if strings.HasPrefix(call, "git update-ref "+backupRefsPrefix) {
+			backups++
+		}
+	}
+	require.Equal(t, 1, backups)
+}
@@ -1,14 +1,27 @@
 package gitcmds
 
+import "github.com/untillpro/qs/internal/hosting"
+
 const (
 	msgOkSeeYou                 = "Ok, see you"
-	errMsgModFiles              = "you have modified files. Please first commit & push them"
 	errMsgFailedToGetMainBranch = "failed to get main branch: %w"
 	countOfZerosIn1000          = 3
 	decimalBase                 = 10
 
 	bitSizeOfInt64        = 64
 	LargeFileHookFilename = "large-file-hook.sh"
+	// maxReportedFiles limits the list of new files reported by PreCommitCheck
+	maxReportedFiles = 20
+
+	// numbers of fields after the kind of porcelain v2 status records, the path is the last one
+	ordinaryStatusFields = 8
+	renamedStatusFields  = 9
+	unmergedStatusFields = 10
+	// keys of the facts kept in the local git config if the cache is enabled
+	parentRepoCacheKey = "qs.parent-repo"
+	userLoginCacheKey  = "qs.user-login"
+	// missingFileMode is the mode of the porcelain v2 status for a file missing in HEAD, the index or the working tree
+	missingFileMode = "000000"
 
 	// Error message fragments for main branch sync issues (exported for test use)
 	MsgCannotFastForward          = "Error: Cannot fast-forward merge upstream/%s into %s."
@@ -20,44 +33,118 @@ const (
 	MsgGitResetHardUpstream       = "git reset --hard upstream/main"
 	MsgGitPushOriginMainForce     = "git push origin main --force"
 	MsgWarningOverwriteMainBranch = "Warning: This will overwrite your main branch on origin with the state of upstream/main, discarding any local or remote changes that diverge from upstream. Make sure you have backed up any important work before proceeding."
+	MsgMainBranchBackedUp         = "Current %s is backed up as %s, run 'qs backups restore --force %s' to bring it back."
 )
 
-const largeFileHookContent = `
-#!/bin/bash
-totalsize=0
-totalcnt=0
-
-# Use -z to get NUL-separated records: "XY<space>path\0"
-while IFS= read -r -d '' entry; do
-  status="${entry:0:2}"         # e.g., "??", "A ", "AM", etc.
-  path="${entry:3}"             # skip "XY " to get the path
-
-  # consider untracked (??) and added (A*) files
-  if [[ "$status" == "??" || "$status" == A* ]]; then
-    extension="${path##*.}"
-    if [[ "$extension" != "wasm" ]]; then
-      # wc -c < file gives just the count; quote the path
-      fs=$(wc -c < "$path")
-      totalsize=$((totalsize + fs))
-      totalcnt=$((totalcnt + 1))
-    fi
-  fi
-done < <(git status --porcelain -z)
-
-if (( $totalsize > 100000 )); then
-  echo " Attempt to commit too large files: Files size = $totalsize"
-	 exit 1
+// largeFileHookContent delegates the check of new files size to qs, see PreCommitCheck
+const largeFileHookContent = `#!/bin/bash
+if ! command -v qs >/dev/null 2>&1; then
+  echo " [qs] qs is not found in PATH, large files check is skipped"
+  exit 0
 fi
+exec qs hook pre-commit
+`
 
-if (( $totalcnt > 200 )); then
-  echo " Attempt to commit too much files: Files number = $totalcnt"
-	 exit 1
+const (
+	preCommitHookFilename = "pre-commit"
+	hooksPathKey          = "core.hooksPath"
+	// hooksPathSetByQsKey is set in the global config if qs has set the global core.hooksPath
+	hooksPathSetByQsKey = "qs.hooksPathSet"
+	shebang             = "#!/bin/bash"
+	// defaultGlobalHooksDir is relative to the home directory, used if global core.hooksPath is not set
+	defaultGlobalHooksDir = ".git/hooks"
+	// hookChecksumPrefix starts the last line of large-file-hook.sh, used to detect edits made by the user
+	hookChecksumPrefix = "# qs checksum: "
+	// qsHookBlockBegin and qsHookBlockEnd enclose the lines qs adds to the pre-commit hook
+	qsHookBlockBegin = "# >>> qs >>>"
+	qsHookBlockEnd   = "# <<< qs <<<"
+	qsHookLines      = `bash "${0%/*}/` + LargeFileHookFilename + `" || exit $?` + "\n"
+	// repoHookChainLines run the repository hook which is not run by git if the global core.hooksPath is set
+	repoHookChainLines = `repo_hook="$(git rev-parse --git-dir)/hooks/pre-commit"
+if [ -x "$repo_hook" ]; then
+  "$repo_hook" "$@" || exit $?
 fi
 `
+	// legacyHookComment precedes the call of large-file-hook.sh appended by older qs versions
+	legacyHookComment = "#Here is large files commit prevent is added by [qs]"
+
+	huskyDirName             = ".husky"
+	preCommitFrameworkMarker = "File generated by pre-commit"
+	hookManagerHusky         = "husky"
+	hookManagerPreCommit     = "pre-commit"
+)
+
+// legacyLargeFileHookChecksums are sha256 of large-file-hook.sh written by qs versions without the checksum line
+var legacyLargeFileHookChecksums = map[string]bool{
+	"7b8148d4dc5b1f404dddf00464d1440b376ddecc13c0686f71f726e3bf24dd1b": true,
+}
+
+const (
+	PRStateOpen   = hosting.PRStateOpen
+	PRStateMerged = hosting.PRStateMerged
+)
+
+const (
+	hostingGitHub  = "github"
+	hostingGitLab  = "gitlab"
+	hostingGitea   = "gitea"
+	hostingForgejo = "forgejo"
+	defaultHost    = "github.com"
+	gitlabHost     = "gitlab.com"
+	codebergHost   = "codeberg.org"
+)
+
+const (
+	gitSuffix     = ".git"
+	protocolHTTPS = "https"
+	protocolHTTP  = "http"
+	protocolSSH   = "ssh"
+	protocolGit   = "git"
+	protocolFile  = "file"
+)
+
+const (
+	refsHeads         = "refs/heads/"
+	refsRemotes       = "refs/remotes/"
+	refsRemotesOrigin = "refs/remotes/origin/"
+)
+
+const (
+	// backupRefsPrefix starts the refs keeping branches deleted or reset by qs, refs/qs/backup/<timestamp>/<branch>
+	backupRefsPrefix = "refs/qs/backup/"
+	// backupNotesRefsPrefix starts the refs keeping snapshots of the notes, refs/qs/backup-notes/<timestamp>
+	backupNotesRefsPrefix = "refs/qs/backup-notes/"
+	backupTimeLayout      = "20060102-150405"
+	notesRef              = "refs/notes/commits"
+)
 
-type PRState string
+// conflictDescriptions describes the XY codes of unmerged files
+var conflictDescriptions = map[string]string{
+	"DD": "both deleted",
+	"AU": "added by us",
+	"UD": "deleted by them",
+	"UA": "added by them",
+	"DU": "deleted by us",
+	"AA": "both added",
+	"UU": "both modified",
+}
 
 const (
-	PRStateOpen   PRState = "open"
-	PRStateMerged PRState = "merged"
+	// detachedHead is the branch name reported by the status if HEAD is detached
+	detachedHead = "HEAD"
+
+	modeFork         = "fork"
+	modeSingleRemote = "single remote"
 )
+
+// inProgressMarkers are the files in the git directory left by the operations waiting to be continued or aborted
+var inProgressMarkers = []struct {
+	path      string
+	operation string
+}{
+	{"rebase-merge", "rebase"},
+	{"rebase-apply", "rebase"},
+	{"MERGE_HEAD", "merge"},
+	{"CHERRY_PICK_HEAD", "cherry-pick"},
+	{"REVERT_HEAD", "revert"},
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	notesPkg "github.com/untillpro/qs/internal/notes"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+// Dashboard shows the state of the current branch in the qs workflow followed by the git repo status
+func Dashboard(rc *RepoContext) error {
+	branchName, err := GetCurrentBranchName(rc)
+	if err != nil {
+		return err
+	}
+	w, err := GetWorkflowInfo(rc, branchName)
+	if err != nil {
+		return err
+	}
+	displayWorkflow(branchName, w)
+
+	return Status(rc)
+}
+
+// GetWorkflowInfo returns the workflow mode, the branch type and issue from notes, ahead/behind counters,
+// the open pull request, the stash entries and the operation in progress.
+// The pull request is looked up only if the branch is pushed to origin and the status.pr_timeout_ms setting is not 0,
+// hosting errors and timeouts are ignored.
+func GetWorkflowInfo(rc *RepoContext, branchName string) (*WorkflowInfo, error) {
+	w := &WorkflowInfo{Mode: modeSingleRemote, Comparisons: []RefComparison{}, Stashes: []string{}}
+	upstreamURL := GetRemoteUpstreamURL(rc)
+	if len(upstreamURL) > 0 {
+		w.Mode = modeFork
+	}
+
+	mainBranch, err := GetMainBranch(rc)
+	if err != nil {
+		logger.Verbose(err)
+	}
+	isMain := branchName == mainBranch
+	if !isMain && len(branchName) > 0 {
+		w.BranchType, w.IssueURL = branchTypeAndIssue(rc, branchName, mainBranch)
+	}
+
+	refs := make([]string, 0, 3) //nolint:revive
+	if len(branchName) > 0 && !isMain {
+		refs = append(refs, originSlash+branchName)
+	}
+	if len(mainBranch) > 0 {
+		refs = append(refs, originSlash+mainBranch)
+		if w.Mode == modeFork {
+			refs = append(refs, "upstream/"+mainBranch)
+		}
+	}
+	for _, ref := range refs {
+		if len(RefSHA(rc, refsRemotes+ref)) == 0 {
+			continue
+		}
+		c, err := compareWithRef(rc, ref)
+		if err != nil {
+			return nil, err
+		}
+		w.Comparisons = append(w.Comparisons, c)
+	}
+
+	prTimeout := time.Duration(rc.Settings().Status.PRTimeoutMs) * time.Millisecond
+	if prTimeout > 0 && !isMain && len(branchName) > 0 && len(RefSHA(rc, refsRemotesOrigin+branchName)) > 0 {
+		w.PR = findOpenPR(rc, upstreamURL, branchName, prTimeout)
+	}
+
+	if w.Stashes, err = stashEntries(rc); err != nil {
+		return nil, err
+	}
+	if w.InProgress, err = operationInProgress(rc); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// branchTypeAndIssue returns the branch type and the issue URL from the notes, the type is taken from the name if there are no notes
+func branchTypeAndIssue(rc *RepoContext, branchName, mainBranch string) (string, string) {
+	branchType := GetBranchTypeByName(rc, branchName)
+	if len(mainBranch) == 0 {
+		return branchType.String(), ""
+	}
+	notes, _, err := getNotesWithMainBranch(rc, branchName, mainBranch)
+	if err != nil {
+		logger.Verbose(err)
+		return branchType.String(), ""
+	}
+	n, err := notesPkg.ReadNotes(notes)
+	if err != nil {
+		logger.Verbose(err)
+		return branchType.String(), ""
+	}
+	if n.BranchType != notesPkg.BranchTypeUnknown {
+		branchType = n.BranchType
+	}
+	_, issueURL := GetNoteAndURL(notes)
+
+	return branchType.String(), issueURL
+}
+
+// compareWithRef returns the number of commits HEAD is ahead and behind of the ref
+func compareWithRef(rc *RepoContext, ref string) (RefComparison, error) {
+	stdout, stderr, err := rc.run(git, "rev-list", "--left-right", "--count", "HEAD..."+ref)
+	if err != nil {
+		logger.Verbose(stderr)
+		return RefComparison{}, fmt.Errorf("failed to compare HEAD with %s: %w", ref, err)
+	}
+	c := RefComparison{Ref: ref}
+	if counters := strings.Fields(stdout); len(counters) == 2 { //nolint:revive
+		c.Ahead, _ = strconv.Atoi(counters[0])
+		c.Behind, _ = strconv.Atoi(counters[1])
+	}
+
+	return c, nil
+}
+
+// findOpenPR returns the open pull request of the branch to upstream in fork mode or to origin otherwise, nil if it is not found.
+// The hosting is given the timeout for both the lookup and the status, so a slow or unreachable hosting does not hold the dashboard.
+func findOpenPR(rc *RepoContext, upstreamURL, branchName string, timeout time.Duration) *OpenPR {
+	repo, org, err := GetRepoAndOrgName(rc)
+	if err != nil {
+		logger.Verbose(err)
+		return nil
+	}
+	targetRepo := org + slash + repo
+	if len(upstreamURL) > 0 {
+		upstream, err := ParseGitRemoteURL(upstreamURL)
+		if err != nil {
+			logger.Verbose(err)
+			return nil
+		}
+		targetRepo = upstream.Account + slash + upstream.Repo
+	}
+
+	ctx, cancel := context.WithTimeout(rc.Context(), timeout)
+	defer cancel()
+
+	prInfo, err := rc.Hosting.FindPR(ctx, targetRepo, org, branchName, PRStateOpen)
+	if err != nil || prInfo == nil {
+		logger.Verbose(fmt.Sprintf("open PR of %s is not found: %v", branchName, err))
+		return nil
+	}
+	pr := &OpenPR{Number: prInfo.Number, URL: prInfo.URL}
+	status, err := rc.Hosting.GetPRStatus(ctx, targetRepo, prInfo.Number)
+	if err != nil {
+		logger.Verbose(err)
+		return pr
+	}
+	pr.Review, pr.Checks = status.Review, status.Checks
+
+	return pr
+}
+
+// stashEntries returns the stash entries, the latest first
+func stashEntries(rc *RepoContext) ([]string, error) {
+	stdout, stderr, err := rc.run(git, "stash", "list")
+	if err != nil {
+		logger.Verbose(stderr)
+		return nil, fmt.Errorf("failed to list stash entries: %w", err)
+	}
+	entries := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(stdout), caret) {
+		if len(line) > 0 {
+			entries = append(entries, line)
+		}
+	}
+
+	return entries, nil
+}
+
+// operationInProgress returns the rebase, merge, cherry-pick or revert waiting to be continued or aborted, empty if there is none
+func operationInProgress(rc *RepoContext) (string, error) {
+	stdout, stderr, err := rc.run(git, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		logger.Verbose(stderr)
+		return "", fmt.Errorf("failed to get git directory: %w", err)
+	}
+	gitDir := strings.TrimSpace(stdout)
+	for _, marker := range inProgressMarkers {
+		if _, err := os.Stat(filepath.Join(gitDir, marker.path)); err == nil {
+			return marker.operation, nil
+		}
+	}
+
+	return "", nil
+}
+
+// displayWorkflow prints the workflow info of the branch
+func displayWorkflow(branchName string, w *WorkflowInfo) {
+	fmt.Printf("%-13s %s\n", "Mode:", w.Mode)
+	if len(w.BranchType) > 0 {
+		fmt.Printf("%-13s %s (%s)\n", "Branch:", branchName, w.BranchType)
+	}
+	if len(w.IssueURL) > 0 {
+		fmt.Printf("%-13s %s\n", "Issue:", w.IssueURL)
+	}
+	for _, c := range w.Comparisons {
+		fmt.Printf("%-13s ahead %d, behind %d\n", c.Ref+":", c.Ahead, c.Behind)
+	}
+	if w.PR != nil {
+		pr := w.PR.URL
+		if len(w.PR.Review) > 0 {
+			pr += ", " + string(w.PR.Review)
+		}
+		if len(w.PR.Checks) > 0 {
+			pr += ", checks " + string(w.PR.Checks)
+		}
+		fmt.Printf("%-13s %s\n", "PR:", pr)
+	}
+	for _, entry := range w.Stashes {
+		fmt.Printf("%-13s %s\n", "Stash:", entry)
+	}
+	if len(w.InProgress) > 0 {
+		fmt.Printf("%-13s %s, run git %s --continue or git %s --abort\n", "In progress:", w.InProgress, w.InProgress, w.InProgress)
+	}
+	fmt.Println()
+}
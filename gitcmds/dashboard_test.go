@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/config"
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// fakePRHosting serves the open pull request of fork-account:feature-dev to untillpro/qs
+type fakePRHosting struct {
+	hosting.HostingProvider
+}
+
+func (fakePRHosting) FindPR(_ context.Context, repoFullName, headOwner, headBranch string, _ hosting.PRState) (*hosting.PRInfo, error) {
+	if repoFullName != "untillpro/qs" || headOwner != "fork-account" || headBranch != "feature-dev" {
+		return nil, nil
+	}
+
+	return &hosting.PRInfo{Number: 7, URL: "https://github.com/untillpro/qs/pull/7"}, nil
+}
+
+func (fakePRHosting) GetPRStatus(_ context.Context, _ string, _ int) (*hosting.PRStatus, error) {
+	return &hosting.PRStatus{Review: hosting.ReviewRequired, Checks: hosting.ChecksSuccess}, nil
+}
+
+func TestGetWorkflowInfo(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	rc.Hosting = fakePRHosting{}
+	gitDir := filepath.Join(rc.Wd, ".git")
+	require.NoError(t, os.MkdirAll(filepath.Join(gitDir, "rebase-merge"), 0755))
+	fake.On("git config --local remote.upstream.url").Return("https://github.com/untillpro/qs.git\n", "", nil)
+	fake.On("git config --local remote.origin.url").Return("https://github.com/fork-account/qs.git\n", "", nil)
+	fake.On("git branch -r").Return("  origin/main\n  origin/feature-dev\n  upstream/main\n", "", nil)
+	fake.On("git log -z --format=%H%x00%N main..feature-dev").Return(
+		"aaa\x00"+`{"version":"1.0","issue_url":"https://github.com/untillpro/qs/issues/42","branch_type":1,"description":"Fix"}`+"\n\x00", "", nil)
+	fake.On("git rev-parse --verify --quiet refs/remotes/origin/feature-dev").Return("bbb\n", "", nil)
+	fake.On("git rev-parse --verify --quiet refs/remotes/origin/main").Return("ccc\n", "", nil)
+	fake.On("git rev-list --left-right --count HEAD...origin/feature-dev").Return("1\t0\n", "", nil)
+	fake.On("git rev-list --left-right --count HEAD...origin/main").Return("3\t2\n", "", nil)
+	fake.On("git stash list").Return("stash@{0}: WIP on main: 1234567 message\n", "", nil)
+	fake.On("git rev-parse --absolute-git-dir").Return(gitDir+"\n", "", nil)
+
+	w, err := GetWorkflowInfo(rc, "feature-dev")
+	require.NoError(t, err)
+	require.Equal(t, &WorkflowInfo{
+		Mode:       modeFork,
+		BranchType: "dev",
+		IssueURL:   "https://github.com/untillpro/qs/issues/42",
+		Comparisons: []RefComparison{
+			{Ref: "origin/feature-dev", Ahead: 1},
+			{Ref: "origin/main", Ahead: 3, Behind: 2},
+		},
+		PR:         &OpenPR{Number: 7, URL: "https://github.com/untillpro/qs/pull/7", Review: hosting.ReviewRequired, Checks: hosting.ChecksSuccess},
+		Stashes:    []string{"stash@{0}: WIP on main: 1234567 message"},
+		InProgress: "rebase",
+	}, w)
+	require.False(t, fake.Ran("git rev-list --left-right --count HEAD...upstream/main"), "missing refs are skipped")
+}
+
+// slowPRHosting does not answer until the request is cancelled and counts the requests
+type slowPRHosting struct {
+	hosting.HostingProvider
+	requests int
+}
+
+func (h *slowPRHosting) FindPR(ctx context.Context, _, _, _ string, _ hosting.PRState) (*hosting.PRInfo, error) {
+	h.requests++
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func TestGetWorkflowInfo_PRTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeoutMs    int
+		wantRequests int
+	}{
+		{name: "timed out", timeoutMs: 10, wantRequests: 1},
+		{name: "disabled", timeoutMs: 0, wantRequests: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, fake := newFakeRepoContext(t)
+			h := &slowPRHosting{}
+			rc.Hosting = h
+			rc.Config = config.Default()
+			rc.Config.Status.PRTimeoutMs = tt.timeoutMs
+			fake.On("git config --local remote.origin.url").Return("https://github.com/untillpro/qs.git\n", "", nil)
+			fake.On("git branch -r").Return("  origin/main\n  origin/feature-dev\n", "", nil)
+			fake.On("git rev-parse --verify --quiet refs/remotes/origin/feature-dev").Return("bbb\n", "", nil)
+			fake.On("git rev-parse --absolute-git-dir").Return(filepath.Join(rc.Wd, ".git")+"\n", "", nil)
+
+			w, err := GetWorkflowInfo(rc, "feature-dev")
+			require.NoError(t, err)
+			require.Nil(t, w.PR)
+			require.Equal(t, tt.wantRequests, h.requests)
+		})
+	}
+}
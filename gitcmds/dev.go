@@ -8,53 +8,45 @@ package gitcmds
 import (
 	"errors"
 	"fmt"
-	"os"
 	"regexp"
 	"strings"
 
-	"github.com/untillpro/goutils/exec"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
 // CreateDevBranch creates dev branch and pushes it to origin
-func CreateDevBranch(wd, branchName, mainBranch string, notes []string) error {
+func CreateDevBranch(rc *RepoContext, branchName, mainBranch string, notes []string) error {
 	branchName = normalizeBranchName(branchName)
 	if branchName == "" {
 		return errors.New("branch name is empty after normalization")
 	}
 
-	stdout, stderr, err := new(exec.PipedExec).
-		Command(git, "checkout", mainBranch).
-		WorkingDir(wd).
-		RunToStrings()
+	stdout, stderr, err := rc.run(git, "checkout", mainBranch)
 
 	if err != nil {
-		if strings.Contains(err.Error(), err128) && strings.Contains(stderr, "matched multiple") {
-			err = new(exec.PipedExec).
-				Command(git, "checkout", "--track", originSlash+mainBranch).
-				WorkingDir(wd).
-				Run(os.Stdout, os.Stdout)
+		if errors.Is(err, ErrAmbiguousRef) {
+			stdout, stderr, err = rc.run(git, "checkout", "--track", originSlash+mainBranch)
 		}
 	}
 	if err != nil {
+		logger.Verbose(stderr)
+
 		return err
 	}
+	printLn(stdout)
 
 	// Create new branch from main
-	err = new(exec.PipedExec).
-		Command(git, "checkout", "-B", branchName).
-		WorkingDir(wd).
-		Run(os.Stdout, os.Stdout)
+	stdout, stderr, err = rc.run(git, "checkout", "-B", branchName)
 	if err != nil {
+		logger.Verbose(stderr)
+
 		return err
 	}
+	printLn(stdout)
 
 	// Fetch notes from origin before pushing
-	stdout, stderr, err = new(exec.PipedExec).
-		Command(git, fetch, origin, "--force", utils.RefsNotes).
-		WorkingDir(wd).
-		RunToStrings()
+	stdout, stderr, err = rc.run(git, fetch, origin, "--force", utils.RefsNotes)
 	if err != nil {
 		logger.Verbose(stderr)
 
@@ -62,24 +54,21 @@ func CreateDevBranch(wd, branchName, mainBranch string, notes []string) error {
 	}
 
 	// Add empty commit to for keeping notes
-	err = new(exec.PipedExec).
-		Command(git, "commit", "--allow-empty", "-m", MsgCommitForNotes).
-		WorkingDir(wd).
-		Run(os.Stdout, os.Stdout)
+	stdout, stderr, err = rc.run(git, "commit", "--allow-empty", "-m", MsgCommitForNotes)
 	if err != nil {
+		logger.Verbose(stderr)
+
 		return err
 	}
+	printLn(stdout)
 	// Link notes to it
-	if err := AddNotes(wd, notes); err != nil {
+	if err := AddNotes(rc, notes); err != nil {
 		return err
 	}
 
 	// Push notes to origin with retry
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command(git, push, origin, utils.RefsNotes).
-			WorkingDir(wd).
-			RunToStrings()
+	err = utils.Retry(rc.Context(), func() error {
+		stdout, stderr, err = rc.run(git, push, origin, utils.RefsNotes)
 
 		return err
 	})
@@ -91,11 +80,8 @@ func CreateDevBranch(wd, branchName, mainBranch string, notes []string) error {
 	utils.DelayIfTest()
 
 	// Push branch to origin with retry
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command(git, push, "-u", origin, branchName).
-			WorkingDir(wd).
-			RunToStrings()
+	err = utils.Retry(rc.Context(), func() error {
+		stdout, stderr, err = rc.run(git, push, "-u", origin, branchName)
 
 		return err
 	})
@@ -10,21 +10,20 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/untillpro/goutils/exec"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
 // Download sources from git repo
-func Download(wd string) error {
+func Download(rc *RepoContext) error {
 	// Step 1: Exit if there are uncommitted changes
-	uncommittedChanges, err := HaveUncommittedChanges(wd)
+	uncommittedChanges, err := HaveUncommittedChanges(rc)
 	if err != nil {
 		return err
 	}
 
 	if uncommittedChanges {
-		return errors.New("there are uncommitted changes in the repository")
+		return ErrUncommittedChanges
 	}
 
 	var (
@@ -32,18 +31,11 @@ func Download(wd string) error {
 		stdout string
 	)
 	// Step 2: fetch origin --prune
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command(git, fetch, origin, "--prune").
-			WorkingDir(wd).
-			RunToStrings()
+	err = utils.Retry(rc.Context(), func() error {
+		stdout, stderr, err = rc.run(git, fetch, origin, "--prune")
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("failed to fetch origin --prune: %w", err)
 		}
 
@@ -55,18 +47,11 @@ func Download(wd string) error {
 	logger.Verbose(stdout)
 
 	// Step 3: git fetch origin --force refs/notes/*:refs/notes/*
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command(git, fetch, origin, "--force", utils.RefsNotes).
-			WorkingDir(wd).
-			RunToStrings()
+	err = utils.Retry(rc.Context(), func() error {
+		stdout, stderr, err = rc.run(git, fetch, origin, "--force", utils.RefsNotes)
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("failed to fetch notes: %w", err)
 		}
 
@@ -77,33 +62,28 @@ func Download(wd string) error {
 	}
 	logger.Verbose(stdout)
 
-	currentBranchName, mainBranchName, isMain, err := GetCurrentBranchInfo(wd)
+	currentBranchName, mainBranchName, isMain, err := GetCurrentBranchInfo(rc)
 	if err != nil {
 		return err
 	}
+	result := &DownloadResult{Branch: currentBranchName, MainBranch: mainBranchName}
+	rc.Result = result
 
 	// check out on the main branch
 	if !isMain {
-		if err := CheckoutOnBranch(wd, mainBranchName); err != nil {
+		if err := CheckoutOnBranch(rc, mainBranchName); err != nil {
 			return err
 		}
 	}
 
 	// Step 4: merge origin Main => Main with fast-forward only
-	_, stderr, err = new(exec.PipedExec).
-		Command(git, "merge", "--ff-only", fmt.Sprintf("origin/%s", mainBranchName)).
-		WorkingDir(wd).
-		RunToStrings()
+	_, stderr, err = rc.run(git, "merge", "--ff-only", fmt.Sprintf("origin/%s", mainBranchName))
 	if err != nil {
 		logger.Verbose(stderr)
 
 		// Check if fast-forward failed
-		if checkAndShowFastForwardFailure(stderr, mainBranchName) {
-			return fmt.Errorf("cannot fast-forward merge origin/%s", mainBranchName)
-		}
-
-		if len(stderr) > 0 {
-			return errors.New(stderr)
+		if checkAndShowFastForwardFailure(rc, err, mainBranchName) {
+			return fmt.Errorf("cannot fast-forward merge origin/%s: %w", mainBranchName, err)
 		}
 
 		return fmt.Errorf("failed to merge origin/%s with --ff-only: %w", mainBranchName, err)
@@ -111,7 +91,7 @@ func Download(wd string) error {
 
 	// check out back on the previous branch
 	if !isMain {
-		if err := CheckoutOnBranch(wd, currentBranchName); err != nil {
+		if err := CheckoutOnBranch(rc, currentBranchName); err != nil {
 			return err
 		}
 	}
@@ -120,22 +100,16 @@ func Download(wd string) error {
 	if !isMain {
 		var hasRemoteBranch bool
 
-		hasRemoteBranch, err = hasRemoteTrackingBranch(wd, currentBranchName)
+		hasRemoteBranch, err = hasRemoteTrackingBranch(rc, currentBranchName)
 		if err != nil {
 			return fmt.Errorf("failed to check remote tracking branch: %w", err)
 		}
 
 		if hasRemoteBranch {
-			_, stderr, err = new(exec.PipedExec).
-				Command(git, "merge", fmt.Sprintf("origin/%s", currentBranchName)).
-				WorkingDir(wd).
-				RunToStrings()
+			_, stderr, err = rc.run(git, "merge", fmt.Sprintf("origin/%s", currentBranchName))
 			if err != nil {
 				logger.Verbose(stderr)
-
-				if len(stderr) > 0 {
-					return errors.New(stderr)
-				}
+				abortIfInterrupted(rc, "merge")
 
 				return fmt.Errorf("failed to merge origin/%s: %w", currentBranchName, err)
 			}
@@ -143,44 +117,42 @@ func Download(wd string) error {
 	}
 
 	// Step 6: If upstream exists - pull upstream/Main with fast-forward only
-	upstreamExists, err := HasRemote(wd, "upstream")
+	upstreamExists, err := HasRemote(rc, "upstream")
 	if err != nil {
 		return fmt.Errorf("failed to check if upstream exists: %w", err)
 	}
 
 	if upstreamExists {
 		if !isMain {
-			if err := CheckoutOnBranch(wd, mainBranchName); err != nil {
+			if err := CheckoutOnBranch(rc, mainBranchName); err != nil {
 				return err
 			}
 		}
 
-		err = utils.Retry(func() error {
-			stdout, stderr, err = new(exec.PipedExec).
-				Command(git, pull, "--ff-only", "upstream", mainBranchName).
-				WorkingDir(wd).
-				RunToStrings()
+		err = utils.Retry(rc.Context(), func() error {
+			stdout, stderr, err = rc.run(git, pull, "--ff-only", "upstream", mainBranchName)
 			if err != nil {
 				logger.Verbose(stderr)
-				if checkAndShowFastForwardFailure(stderr, mainBranchName) {
-					return fmt.Errorf("cannot fast-forward merge upstream/%s", mainBranchName)
-				}
-				if len(stderr) > 0 {
-					return errors.New(stderr)
+				// diverged branches do not converge on retry
+				if errors.Is(err, ErrDiverged) {
+					return utils.NonRetryable(fmt.Errorf("cannot fast-forward merge upstream/%s: %w", mainBranchName, err))
 				}
-
 				return fmt.Errorf("failed to pull upstream/%s with --ff-only: %w", mainBranchName, err)
 			}
 
 			return nil
 		})
 		if err != nil {
+			// the workaround is shown and the main branch is backed up once
+			checkAndShowFastForwardFailure(rc, err, mainBranchName)
+
 			return err
 		}
 		logger.Verbose(stdout)
+		result.UpstreamPulled = true
 
 		if !isMain {
-			if err := CheckoutOnBranch(wd, currentBranchName); err != nil {
+			if err := CheckoutOnBranch(rc, currentBranchName); err != nil {
 				return err
 			}
 		}
@@ -190,16 +162,8 @@ func Download(wd string) error {
 }
 
 // hasRemoteTrackingBranch checks if a remote tracking branch exists for the given branch
-func hasRemoteTrackingBranch(wd string, branchName string) (bool, error) {
-	stdout, stderr, err := new(exec.PipedExec).
-		Command(git, "branch", "-r").
-		WorkingDir(wd).
-		Command("grep", branchName).
-		RunToStrings()
-	if len(stdout) == 0 {
-		return false, nil
-	}
-
+func hasRemoteTrackingBranch(rc *RepoContext, branchName string) (bool, error) {
+	stdout, stderr, err := rc.run(git, "branch", "-r")
 	if err != nil {
 		logger.Verbose(stderr)
 
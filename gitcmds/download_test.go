@@ -0,0 +1,23 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownload_Result(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git branch --show-current").Return("feature-dev\n", "", nil)
+	fake.On("git branch -r").Return("  origin/main\n  upstream/main\n", "", nil)
+	fake.On("git remote").Return("origin\nupstream\n", "", nil)
+
+	require.NoError(t, Download(rc))
+	require.True(t, fake.Ran("git pull --ff-only upstream main"))
+	require.Equal(t, &DownloadResult{Branch: "feature-dev", MainBranch: "main", UpstreamPulled: true}, rc.Result)
+}
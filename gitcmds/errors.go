@@ -0,0 +1,24 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import "errors"
+
+var (
+	ErrCommitTooLarge     = errors.New("attempt to commit too large or too many files")
+	ErrNotGitRepo         = errors.New("this is not a git repository")
+	ErrDiverged           = errors.New("branch has diverged and can not be fast-forwarded")
+	ErrRebaseConflict     = errors.New("conflict while rebasing or merging")
+	ErrUncommittedChanges = errors.New("you have modified files. Please first commit & push them")
+	ErrNoCommits          = errors.New("no commits found")
+	ErrNotOnDevBranch     = errors.New("you must be on dev or pr branch")
+	ErrPRExists           = errors.New("pull request already exists")
+	ErrAlreadyForked      = errors.New("you are in fork already\nExecute 'qs dev [branch name]' to create dev branch")
+	ErrBranchExists       = errors.New("branch already exists")
+	ErrNoNote             = errors.New("no note found")
+	ErrAmbiguousRef       = errors.New("reference matches multiple branches")
+	ErrRemoteRefNotFound  = errors.New("remote ref does not exist")
+)
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/untillpro/qs/internal/runner"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+// changesRemotes returns true if the mutating git command may change remotes, remote branches or the origin URL
+func changesRemotes(args []string) bool {
+	subcommand, params := runner.GitSubcommand(args)
+	switch subcommand {
+	case "remote", fetch, pull, push, "clone":
+		return true
+	case "config":
+		for _, p := range params {
+			if strings.HasPrefix(p, "remote.") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// VerifyHostingAuth checks that the hosting accepts the token by getting the login of the user.
+// The hosting is not asked if the login is kept in the git config and is not expired yet.
+func VerifyHostingAuth(rc *RepoContext) error {
+	_, err := getUserName(rc)
+
+	return err
+}
+
+// cacheTTL returns how long the facts are kept in the local git config, zero if they are not kept
+func cacheTTL(rc *RepoContext) time.Duration {
+	return time.Duration(rc.Settings().Cache.TTLHours) * time.Hour
+}
+
+// cacheScope returns the origin the cached facts belong to, e.g. "github.com/org/repo"
+func cacheScope(rc *RepoContext) (string, error) {
+	repo, org, err := GetRepoAndOrgName(rc)
+	if err != nil {
+		return "", err
+	}
+
+	return rc.Host + slash + org + slash + repo, nil
+}
+
+// loadCachedFact returns the value of the fact kept in the local git config if it is not expired and belongs to the origin.
+// The entry is "<unix time> <scope> <value>", the value may be empty.
+func loadCachedFact(rc *RepoContext, key string) (string, bool) {
+	ttl := cacheTTL(rc)
+	if ttl <= 0 {
+		return "", false
+	}
+	scope, err := cacheScope(rc)
+	if err != nil {
+		return "", false
+	}
+	stdout, _, err := rc.run(git, "config", "--local", "--get", key)
+	if err != nil {
+		return "", false
+	}
+
+	fields := strings.SplitN(strings.TrimSpace(stdout), " ", 3) //nolint:revive
+	if len(fields) < 2 || fields[1] != scope {
+		return "", false
+	}
+	storedAt, err := strconv.ParseInt(fields[0], decimalBase, bitSizeOfInt64)
+	if err != nil || time.Since(time.Unix(storedAt, 0)) > ttl {
+		return "", false
+	}
+	if len(fields) < 3 { //nolint:revive
+		return "", true
+	}
+
+	return fields[2], true
+}
+
+// storeCachedFact keeps the fact in the local git config, nothing is stored in dry-run mode or if the cache is disabled
+func storeCachedFact(rc *RepoContext, key, value string) {
+	if rc.DryRun != nil || cacheTTL(rc) <= 0 {
+		return
+	}
+	scope, err := cacheScope(rc)
+	if err != nil {
+		return
+	}
+	entry := fmt.Sprintf("%d %s %s", time.Now().Unix(), scope, value)
+	if _, stderr, err := rc.run(git, "config", "--local", key, entry); err != nil {
+		logger.Verbose(fmt.Sprintf("failed to cache %s: %s", key, stderr))
+	}
+}
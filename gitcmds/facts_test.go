@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/config"
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// fakeParentHosting serves untillpro/qs as the parent repo and counts the requests
+type fakeParentHosting struct {
+	hosting.HostingProvider
+	requests int
+}
+
+func (h *fakeParentHosting) GetParentRepo(_ context.Context, _ string) (string, error) {
+	h.requests++
+
+	return "untillpro/qs", nil
+}
+
+func TestRepoFacts_Memoized(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git branch -r").Return("  origin/main\n", "", nil)
+	fake.On("git remote").Return("origin\nupstream\n", "", nil)
+	fake.On("git rev-parse --show-toplevel").Return("/repo\n", "", nil)
+
+	for range 2 {
+		mainBranch, err := GetMainBranch(rc)
+		require.NoError(t, err)
+		require.Equal(t, "main", mainBranch)
+		ok, err := HasRemote(rc, "upstream")
+		require.NoError(t, err)
+		require.True(t, ok)
+		root, err := GetRootFolder(rc)
+		require.NoError(t, err)
+		require.Equal(t, "/repo", root)
+	}
+	calls := fake.Calls()
+	require.Equal(t, 1, countCalls(calls, "git branch -r"))
+	require.Equal(t, 1, countCalls(calls, "git remote"))
+	require.Equal(t, 1, countCalls(calls, "git rev-parse --show-toplevel"))
+
+	// checkout does not touch remotes
+	_, _, err := rc.run(git, "checkout", "main")
+	require.NoError(t, err)
+	_, err = GetMainBranch(rc)
+	require.NoError(t, err)
+	require.Equal(t, 1, countCalls(fake.Calls(), "git branch -r"))
+
+	_, _, err = rc.run(git, "remote", "remove", "upstream")
+	require.NoError(t, err)
+	_, err = GetMainBranch(rc)
+	require.NoError(t, err)
+	_, err = HasRemote(rc, "upstream")
+	require.NoError(t, err)
+	calls = fake.Calls()
+	require.Equal(t, 2, countCalls(calls, "git branch -r"), "remote facts are reloaded after the remote is removed")
+	require.Equal(t, 2, countCalls(calls, "git remote"))
+	require.Equal(t, 1, countCalls(calls, "git rev-parse --show-toplevel"))
+}
+
+func TestGetParentRepoName_Cache(t *testing.T) {
+	const getCached = "git config --local --get qs.parent-repo"
+	now := time.Now().Unix()
+	tests := []struct {
+		name         string
+		ttlHours     int
+		cached       string
+		wantRequests int
+	}{
+		{name: "disabled", ttlHours: 0, cached: fmt.Sprintf("%d github.com/fork-account/qs untillpro/qs", now), wantRequests: 1},
+		{name: "missing", ttlHours: 1, wantRequests: 1},
+		{name: "fresh", ttlHours: 1, cached: fmt.Sprintf("%d github.com/fork-account/qs untillpro/qs", now), wantRequests: 0},
+		{name: "not a fork", ttlHours: 1, cached: fmt.Sprintf("%d github.com/fork-account/qs ", now), wantRequests: 0},
+		{name: "expired", ttlHours: 1, cached: fmt.Sprintf("%d github.com/fork-account/qs untillpro/qs", now-7200), wantRequests: 1},
+		{name: "other origin", ttlHours: 1, cached: fmt.Sprintf("%d github.com/other/qs untillpro/qs", now), wantRequests: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, fake := newFakeRepoContext(t)
+			h := &fakeParentHosting{}
+			rc.Hosting = h
+			rc.Config = config.Default()
+			rc.Config.Cache.TTLHours = tt.ttlHours
+			fake.On("git config --local remote.origin.url").Return("https://github.com/fork-account/qs.git\n", "", nil)
+			if len(tt.cached) > 0 {
+				fake.On(getCached).Return(tt.cached+"\n", "", nil)
+			} else {
+				fake.On(getCached).Return("", "", fmt.Errorf("exit status 1"))
+			}
+
+			for range 2 {
+				_, err := GetParentRepoName(rc)
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantRequests, h.requests)
+			require.Equal(t, tt.ttlHours > 0 && tt.wantRequests > 0, fake.Ran("git config --local qs.parent-repo"))
+		})
+	}
+}
+
+func TestRemoveRemote(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git remote").Return("origin\nupstream\n", "", nil).Once()
+	fake.On("git remote").Return("origin\n", "", nil)
+
+	ok, err := HasRemote(rc, "upstream")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, RemoveRemote(rc, "upstream"))
+	require.True(t, fake.Ran("git remote remove upstream"))
+	ok, err = HasRemote(rc, "upstream")
+	require.NoError(t, err)
+	require.False(t, ok, "remotes are reloaded after the remote is removed")
+}
@@ -11,14 +11,14 @@ import (
 	"os"
 	"strings"
 
-	"github.com/untillpro/goutils/exec"
+	"github.com/untillpro/qs/internal/journal"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
-// Fork repo
-func Fork(wd string) (string, error) {
-	repo, org, err := GetRepoAndOrgName(wd)
+// Fork repo, the stash of changed files and the fork are recorded in the journal
+func Fork(rc *RepoContext, j *journal.Journal) (string, error) {
+	repo, org, err := GetRepoAndOrgName(rc)
 	if err != nil {
 		return "", err
 	}
@@ -27,83 +27,56 @@ func Fork(wd string) (string, error) {
 		return "", errors.New(repoNotFound)
 	}
 
-	remoteURL := GetRemoteUpstreamURL(wd)
+	remoteURL := GetRemoteUpstreamURL(rc)
 	if len(remoteURL) > 0 {
-		return repo, errors.New(ErrAlreadyForkedMsg)
+		return repo, ErrAlreadyForked
 	}
 
-	if ok, err := IsMainOrg(wd); !ok || err != nil {
+	if ok, err := IsMainOrg(rc); !ok || err != nil {
 		if err != nil {
 			return repo, fmt.Errorf("IsMainOrg error: %w", err)
 		}
 
-		return repo, errors.New(ErrAlreadyForkedMsg)
+		return repo, ErrAlreadyForked
 	}
 
-	_, chExist, err := ChangedFilesExist(wd)
+	_, chExist, err := ChangedFilesExist(rc)
 	if err != nil {
 		return "", err
 	}
 	if chExist {
-		stdout, stderr, err := new(exec.PipedExec).
-			Command(git, "add", ".").
-			WorkingDir(wd).
-			RunToStrings()
+		stdout, stderr, err := rc.run(git, "add", ".")
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return repo, errors.New(stderr)
-			}
-
 			return repo, fmt.Errorf("git add failed: %w", err)
 		}
 		printLn(stdout)
 
-		stdout, stderr, err = new(exec.PipedExec).
-			Command(git, "stash").
-			WorkingDir(wd).
-			RunToStrings()
+		stdout, stderr, err = rc.run(git, "stash")
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return repo, errors.New(stderr)
-			}
-
 			return repo, fmt.Errorf("git stash failed: %w", err)
 		}
 		printLn(stdout)
-	}
-
-	var (
-		stdout string
-		stderr string
-	)
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command("gh", "repo", "fork", org+slash+repo, "--clone=false").
-			WorkingDir(wd).
-			RunToStrings()
-		if err != nil {
-			logger.Verbose(stderr)
-
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
-			return fmt.Errorf("failed to fork repository: %w", err)
+		if err := j.Done(journal.StepStashed); err != nil {
+			return repo, err
 		}
+	}
 
-		return nil
+	if rc.Planned("fork " + org + slash + repo) {
+		return repo, nil
+	}
+	err = utils.Retry(rc.Context(), func() error {
+		return rc.Hosting.ForkRepo(rc.Context(), org+slash+repo)
 	})
 	if err != nil {
 		return repo, err
 	}
-	printLn(stdout)
 
 	// Get current user name to verify fork
-	userName, err := getUserName(wd)
+	userName, err := getUserName(rc)
 	if err != nil {
 		logger.Verbose(fmt.Sprintf("Failed to get user name for verification: %v", err))
 
@@ -111,16 +84,8 @@ func Fork(wd string) (string, error) {
 	}
 
 	// Verify fork was created and is accessible with retry
-	err = utils.Retry(func() error {
-		// Try to get user email to get a valid token context, then verify repo
-		userEmail, emailErr := utils.GetUserEmail()
-		if emailErr != nil {
-			return fmt.Errorf("failed to verify GitHub authentication: %w", emailErr)
-		}
-		logger.Verbose(fmt.Sprintf("Verified GitHub authentication for user: %s", userEmail))
-
-		// Verify the forked repository exists and is accessible
-		return utils.VerifyGitHubRepoExists(userName, repo, "")
+	err = utils.Retry(rc.Context(), func() error {
+		return rc.Hosting.VerifyRepoExists(rc.Context(), userName+slash+repo)
 	})
 	if err != nil {
 		logger.Verbose(fmt.Sprintf("Fork verification failed: %v", err))
@@ -128,15 +93,15 @@ func Fork(wd string) (string, error) {
 		return repo, fmt.Errorf("fork verification failed: %w", err)
 	}
 	_, _ = fmt.Fprintln(os.Stdout, "Fork created and verified successfully")
+	if err := j.Done(journal.StepForked); err != nil {
+		return repo, err
+	}
 
 	return repo, nil
 }
 
-func GetRemoteUpstreamURL(wd string) string {
-	stdout, _, err := new(exec.PipedExec).
-		Command(git, "config", "--local", "remote.upstream.url").
-		WorkingDir(wd).
-		RunToStrings()
+func GetRemoteUpstreamURL(rc *RepoContext) string {
+	stdout, _, err := rc.run(git, "config", "--local", "remote.upstream.url")
 	if err != nil {
 		return ""
 	}
@@ -144,19 +109,19 @@ func GetRemoteUpstreamURL(wd string) string {
 	return strings.TrimSpace(stdout)
 }
 
-func IsMainOrg(wd string) (bool, error) {
-	_, org, err := GetRepoAndOrgName(wd)
+func IsMainOrg(rc *RepoContext) (bool, error) {
+	_, org, err := GetRepoAndOrgName(rc)
 	if err != nil {
 		return false, err
 	}
-	userName, err := getUserName(wd)
+	userName, err := getUserName(rc)
 
 	return org != userName, err
 }
 
 // MakeUpstream s.e.
-func MakeUpstream(wd string, repo string) error {
-	userName, err := getUserName(wd)
+func MakeUpstream(rc *RepoContext, repo string) error {
+	userName, err := getUserName(rc)
 	if err != nil {
 		return fmt.Errorf("failed to get user name: %w", err)
 	}
@@ -165,37 +130,23 @@ func MakeUpstream(wd string, repo string) error {
 		return errors.New(userNotFound)
 	}
 
-	mainBranch, err := GetMainBranch(wd)
+	mainBranch, err := GetMainBranch(rc)
 	if err != nil {
 		return fmt.Errorf(errMsgFailedToGetMainBranch, err)
 	}
 
-	stdout, stderr, err := new(exec.PipedExec).
-		Command(git, "remote", "rename", "origin", "upstream").
-		WorkingDir(wd).
-		RunToStrings()
+	stdout, stderr, err := rc.run(git, "remote", "rename", "origin", "upstream")
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return errors.New(stderr)
-		}
-
 		return fmt.Errorf("failed to rename origin to upstream: %w", err)
 	}
 	printLn(stdout)
 
-	stdout, stderr, err = new(exec.PipedExec).
-		Command(git, "remote", "add", "origin", "https://github.com/"+userName+slash+repo).
-		WorkingDir(wd).
-		RunToStrings()
+	stdout, stderr, err = rc.run(git, "remote", "add", "origin", rc.repoURL(userName+slash+repo))
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return errors.New(stderr)
-		}
-
 		return fmt.Errorf("failed to add origin remote: %w", err)
 	}
 	printLn(stdout)
@@ -203,18 +154,11 @@ func MakeUpstream(wd string, repo string) error {
 	// delay to ensure remote is added
 	utils.DelayIfTest()
 
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command(git, "fetch", "origin").
-			WorkingDir(wd).
-			RunToStrings()
+	err = utils.Retry(rc.Context(), func() error {
+		stdout, stderr, err = rc.run(git, "fetch", "origin")
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("failed to fetch origin: %w", err)
 		}
 
@@ -225,17 +169,10 @@ func MakeUpstream(wd string, repo string) error {
 	}
 	logger.Verbose(stdout)
 
-	stdout, stderr, err = new(exec.PipedExec).
-		Command(git, branch, "--set-upstream-to", originSlash+mainBranch, mainBranch).
-		WorkingDir(wd).
-		RunToStrings()
+	stdout, stderr, err = rc.run(git, branch, "--set-upstream-to", originSlash+mainBranch, mainBranch)
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return errors.New(stderr)
-		}
-
 		return fmt.Errorf("failed to set upstream for main branch: %w", err)
 	}
 	printLn(stdout)
@@ -243,21 +180,54 @@ func MakeUpstream(wd string, repo string) error {
 	return nil
 }
 
-func getUserName(wd string) (string, error) {
-	stdout, stderr, err := new(exec.PipedExec).
-		Command("gh", "api", "user").
-		WorkingDir(wd).
-		Command("jq", "-r", ".login").
-		RunToStrings()
+// RestoreOrigin reverts MakeUpstream: the origin remote is removed and upstream is renamed back to origin
+func RestoreOrigin(rc *RepoContext) error {
+	upstreamExists, err := HasRemote(rc, "upstream")
+	if err != nil || !upstreamExists {
+		return err
+	}
+	originExists, err := HasRemote(rc, origin)
 	if err != nil {
+		return err
+	}
+	if originExists {
+		if _, stderr, err := rc.run(git, "remote", "remove", origin); err != nil {
+			logger.Verbose(stderr)
+			return fmt.Errorf("failed to remove origin remote: %w", err)
+		}
+	}
+	if _, stderr, err := rc.run(git, "remote", "rename", "upstream", origin); err != nil {
 		logger.Verbose(stderr)
+		return fmt.Errorf("failed to rename upstream to origin: %w", err)
+	}
 
-		if len(stderr) > 0 {
-			return "", errors.New(stderr)
-		}
+	// tracking of the main branch is dropped with the removed origin
+	mainBranch, err := GetMainBranch(rc)
+	if err != nil {
+		logger.Verbose(err)
+		return nil
+	}
+	if _, stderr, err := rc.run(git, branch, "--set-upstream-to", originSlash+mainBranch, mainBranch); err != nil {
+		logger.Verbose(stderr)
+	}
 
-		return "", fmt.Errorf("failed to get user name: %w", err)
+	return nil
+}
+
+// getUserName returns the login of the user, memoized by the invocation and kept in the git config if the cache is enabled
+func getUserName(rc *RepoContext) (string, error) {
+	if len(rc.facts.userLogin) > 0 {
+		return rc.facts.userLogin, nil
+	}
+	userName, ok := loadCachedFact(rc, userLoginCacheKey)
+	if !ok || len(userName) == 0 {
+		var err error
+		if userName, err = rc.Hosting.GetUserLogin(rc.Context()); err != nil {
+			return "", fmt.Errorf("failed to get user name: %w", err)
+		}
+		storeCachedFact(rc, userLoginCacheKey, userName)
 	}
+	rc.facts.userLogin = userName
 
-	return strings.TrimSpace(stdout), nil
+	return userName, nil
 }
@@ -1,10 +1,13 @@
 package gitcmds
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/config"
 	notesPkg "github.com/untillpro/qs/internal/notes"
 )
 
@@ -110,6 +113,45 @@ func TestDeserializeVersionCheck(t *testing.T) {
 	}
 }
 
+func TestGetIssueDescription_StoredTitle(t *testing.T) {
+	t.Setenv("YOUTRACK_URL", "https://example.youtrack.cloud")
+	tests := []struct {
+		issueURL string
+		want     string
+	}{
+		{issueURL: "https://github.com/untillpro/qs/issues/42", want: "Fix bug"},
+		{issueURL: "https://untill.atlassian.net/browse/AIR-270", want: "[AIR-270] Fix bug"},
+		{issueURL: "https://linear.app/untill/issue/ENG-12/fix-bug", want: "[ENG-12] Fix bug"},
+		{issueURL: "https://example.youtrack.cloud/issue/QS-12/fix-bug", want: "[QS-12] Fix bug"},
+		{issueURL: "https://dev.untill.com/projects/#!763090", want: "[763090] Fix bug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.issueURL, func(t *testing.T) {
+			notes, err := notesPkg.Serialize(tt.issueURL, notesPkg.BranchTypeDev, "Fix bug")
+			require.NoError(t, err)
+
+			description, err := GetIssueDescription(nil, []string{notes})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, description)
+		})
+	}
+}
+
+func TestBranchSuffixesFromConfig(t *testing.T) {
+	require := require.New(t)
+	rc := &RepoContext{}
+	require.Equal("feature-pr", PRBranchName(rc, "feature-dev"))
+
+	rc.Config = config.Default()
+	rc.Config.Branch.DevSuffix = "-feature"
+	rc.Config.Branch.PRSuffix = "-review"
+	require.Equal("42-fix-review", PRBranchName(rc, "42-fix-feature"))
+	require.Equal(notesPkg.BranchTypeDev, GetBranchTypeByName(rc, "42-fix-feature"))
+	require.Equal(notesPkg.BranchTypePr, GetBranchTypeByName(rc, "42-fix-review"))
+	require.Equal(notesPkg.BranchTypeUnknown, GetBranchTypeByName(rc, "42-fix-dev"))
+}
+
 func TestNormalizeBranchName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -205,3 +247,123 @@ func TestNormalizeBranchName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetStatus(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	for name, size := range map[string]int{"new.txt": 10, "changed.go": 30, "script.sh": 5, "copy.txt": 20, "conflict.go": 40} {
+		require.NoError(t, os.WriteFile(filepath.Join(rc.Wd, name), make([]byte, size), 0644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(rc.Wd, "sub"), 0755))
+	fake.On("git remote -v").Return("origin\thttps://github.com/me/repo.git (fetch)\norigin\thttps://github.com/me/repo.git (push)\n", "", nil)
+	fake.On("git status --porcelain=v2 --branch -z -uall").Return("# branch.oid 1234567890abcdef1234567890abcdef12345678\x00"+
+		"# branch.head main\x00# branch.upstream origin/main\x00# branch.ab +2 -1\x00"+
+		"1 .M N... 100644 100644 100644 aaa aaa changed.go\x00"+
+		"1 .T N... 120000 120000 100644 bbb bbb script.sh\x00"+
+		"2 C. N... 100644 100644 100644 ccc ccc C75 copy.txt\x00orig.txt\x00"+
+		"1 D. N... 100644 000000 000000 ddd 0000000000000000000000000000000000000000 deleted.go\x00"+
+		"1 .M S.M. 160000 160000 160000 eee eee sub\x00"+
+		"u UU N... 100644 100644 100644 100644 fff ggg hhh conflict.go\x00"+
+		"? new.txt\x00", "", nil)
+	fake.On("git rev-parse --show-toplevel").Return(rc.Wd+"\n", "", nil)
+	fake.On("git cat-file -s aaa").Return("50\n", "", nil)
+	fake.On("git cat-file -s bbb").Return("12\n", "", nil)
+	fake.On("git cat-file -s ddd").Return("7\n", "", nil)
+	fake.On("git cat-file -s ggg").Return("25\n", "", nil)
+	fake.On("git rev-parse --absolute-git-dir").Return(filepath.Join(rc.Wd, ".git")+"\n", "", nil)
+	fake.On("git branch -r").Return("  origin/main\n", "", nil)
+
+	info, err := GetStatus(rc)
+	require.NoError(t, err)
+	require.Equal(t, &StatusInfo{
+		Branch:   "main",
+		Upstream: "origin/main",
+		Ahead:    2,
+		Behind:   1,
+		Remotes:  []RemoteInfo{{Name: "origin", URL: "https://github.com/me/repo.git"}},
+		Files: []FileStatus{
+			{Path: "changed.go", Status: "M", SizeDelta: -20},
+			{Path: "script.sh", Status: "T", SizeDelta: -7},
+			{Path: "copy.txt", OldPath: "orig.txt", Status: "C", SizeDelta: 20},
+			{Path: "deleted.go", Status: "D", SizeDelta: -7},
+			{Path: "sub", Status: "M", Submodule: true},
+			{Path: "conflict.go", Status: "UU", SizeDelta: 15, Conflict: "both modified"},
+			{Path: "new.txt", Status: "??", SizeDelta: 10},
+		},
+		TotalSizeDelta: 45,
+		Workflow:       &WorkflowInfo{Mode: modeSingleRemote, Comparisons: []RefComparison{}, Stashes: []string{}},
+	}, info)
+	require.False(t, fake.Ran("git cat-file -s ccc"), "copied file is new")
+	require.False(t, fake.Ran("git cat-file -s eee"), "submodules are not measured")
+}
+
+func TestParseBranchHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers string
+		want    StatusInfo
+	}{
+		{"no upstream", "# branch.oid abc\x00# branch.head main\x00", StatusInfo{Branch: "main"}},
+		{"no commits yet", "# branch.oid (initial)\x00# branch.head main\x00", StatusInfo{Branch: "main"}},
+		{"detached", "# branch.oid abc\x00# branch.head (detached)\x00", StatusInfo{Branch: "HEAD"}},
+		{"upstream gone", "# branch.head dev\x00# branch.upstream origin/dev\x00", StatusInfo{Branch: "dev", Upstream: "origin/dev"}},
+		{"behind", "# branch.head dev\x00# branch.upstream origin/dev\x00# branch.ab +0 -3\x00", StatusInfo{Branch: "dev", Upstream: "origin/dev", Behind: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var info StatusInfo
+			parseBranchHeaders(tt.headers+"? file\x00", &info)
+			require.Equal(t, tt.want, info)
+		})
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	status := "# branch.head main\x00" +
+		"1 MM N... 100644 100644 100644 aaa bbb dir/file with spaces.go\x00" +
+		"2 R. N... 100644 100644 100644 ccc ccc R100 new -> name.txt\x00old.txt\x00" +
+		"1 .D N... 100644 100644 000000 ddd ddd deleted.go\x00" +
+		"1 A. N... 000000 100644 100644 0000000000000000000000000000000000000000 eee added.go\x00" +
+		"u AA N... 000000 100644 100644 100644 0000000000000000000000000000000000000000 fff ggg both.go\x00" +
+		"? ünïcode.txt\x00! ignored.log\x00"
+
+	require.Equal(t, []statusEntry{
+		{code: "MM", name: "dir/file with spaces.go", oldName: "dir/file with spaces.go", headObject: "aaa", inWorktree: true},
+		{code: "R", name: "new -> name.txt", oldName: "old.txt", headObject: "ccc", inWorktree: true},
+		{code: "D", name: "deleted.go", oldName: "deleted.go", headObject: "ddd"},
+		{code: "A", name: "added.go", oldName: "added.go", inWorktree: true},
+		{code: "AA", name: "both.go", oldName: "both.go", headObject: "fff", inWorktree: true, unmerged: true},
+		{code: "??", name: "ünïcode.txt", oldName: "ünïcode.txt", inWorktree: true},
+		{code: "!!", name: "ignored.log", oldName: "ignored.log", inWorktree: true, ignored: true},
+	}, parseStatus(status))
+}
+
+func TestGetBranchesWithRemoteTracking(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git for-each-ref --format=%(upstream)%09%(upstream:trackshort) refs/heads").Return(
+		"refs/remotes/origin/main\t=\n"+
+			"refs/remotes/origin/in-sync\t=\n"+
+			"refs/remotes/origin/gone\t\n"+
+			"refs/remotes/origin/ahead\t>\n"+
+			"refs/remotes/origin/behind\t<\n"+
+			"refs/remotes/origin/diverged\t<>\n"+
+			"refs/remotes/upstream/other\t=\n"+
+			"\t\n", "", nil)
+	fake.On("git branch -r").Return("  origin/main\n", "", nil)
+
+	branches, err := GetBranchesWithRemoteTracking(rc, "origin")
+	require.NoError(t, err)
+	require.Equal(t, []string{"in-sync", "gone", "ahead"}, branches)
+}
+
+func TestBranchExists(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git branch --list local-dev").Return("  local-dev\n", "", nil)
+	fake.On("git ls-remote --heads origin remote-dev").Return("aaa\trefs/heads/remote-dev\n", "", nil)
+
+	for branchName, want := range map[string]bool{"local-dev": true, "remote-dev": true, "missing-dev": false} {
+		exists, err := BranchExists(rc, branchName)
+		require.NoError(t, err)
+		require.Equal(t, want, exists, branchName)
+	}
+	require.False(t, fake.Ran("git ls-remote --heads origin local-dev"), "origin is not asked for local branches")
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// gitErrorKinds maps fragments of git stderr to the sentinel errors, the first match wins.
+// Messages are not translated since git is run in the C locale.
+var gitErrorKinds = []struct {
+	fragment string
+	kind     error
+}{
+	{"not a git repository", ErrNotGitRepo},
+	{"not possible to fast-forward", ErrDiverged},
+	{"(non-fast-forward)", ErrDiverged},
+	{"could not apply", ErrRebaseConflict},
+	{"conflict (", ErrRebaseConflict},
+	{"would be overwritten by", ErrUncommittedChanges},
+	{"please commit your changes or stash them", ErrUncommittedChanges},
+	{"does not have any commits yet", ErrNoCommits},
+	{"no note found", ErrNoNote},
+	{strings.ToLower(MsgPreCommitError), ErrCommitTooLarge},
+	{"matched multiple", ErrAmbiguousRef},
+	{"remote ref does not exist", ErrRemoteRefNotFound},
+	{"a branch named", ErrBranchExists},
+}
+
+// NewGitError wraps the error of the git command with the given args, nil is returned if err is nil
+func NewGitError(args []string, stderr string, err error) error {
+	if err == nil {
+		return nil
+	}
+	gitErr := &GitError{
+		Args:     args,
+		ExitCode: -1,
+		Stderr:   strings.TrimSpace(stderr),
+		Err:      err,
+		kind:     classifyGitError(stderr),
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		gitErr.ExitCode = exitErr.ExitCode()
+	}
+
+	return gitErr
+}
+
+// classifyGitError returns the sentinel error the stderr of git matches, nil if there is none
+func classifyGitError(stderr string) error {
+	lower := strings.ToLower(stderr)
+	for _, k := range gitErrorKinds {
+		if strings.Contains(lower, k.fragment) {
+			return k.kind
+		}
+	}
+
+	return nil
+}
+
+// Error returns the stderr of git or the error of the runner if stderr is empty
+func (e *GitError) Error() string {
+	if len(e.Stderr) > 0 {
+		return e.Stderr
+	}
+
+	return git + " " + strings.Join(e.Args, " ") + ": " + e.Err.Error()
+}
+
+func (e *GitError) Unwrap() []error {
+	if e.kind == nil {
+		return []error{e.Err}
+	}
+
+	return []error{e.Err, e.kind}
+}
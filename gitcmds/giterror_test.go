@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGitError(t *testing.T) {
+	exitErr := errors.New("exit status 128")
+	tests := []struct {
+		stderr string
+		kind   error
+	}{
+		{"fatal: not a git repository (or any of the parent directories): .git", ErrNotGitRepo},
+		{"fatal: Not possible to fast-forward, aborting.", ErrDiverged},
+		{"! [rejected] main -> main (non-fast-forward)", ErrDiverged},
+		{"error: could not apply 1a2b3c... change", ErrRebaseConflict},
+		{"error: Your local changes to the following files would be overwritten by checkout:", ErrUncommittedChanges},
+		{"error: no note found for object 1a2b3c.", ErrNoNote},
+		{"Attempt to commit too large files: Files size = 110_000 bytes (maximum 100_000)", ErrCommitTooLarge},
+		{"error: pathspec 'main' matched multiple (2) remote tracking branches", ErrAmbiguousRef},
+		{"error: unable to delete 'dev': remote ref does not exist", ErrRemoteRefNotFound},
+		{"fatal: a branch named 'dev' already exists", ErrBranchExists},
+	}
+	for _, test := range tests {
+		t.Run(test.stderr, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", NewGitError([]string{"checkout", "main"}, test.stderr+"\n", exitErr))
+			require.ErrorIs(t, err, test.kind)
+			require.ErrorIs(t, err, exitErr)
+
+			var gitErr *GitError
+			require.ErrorAs(t, err, &gitErr)
+			require.Equal(t, -1, gitErr.ExitCode)
+			require.Equal(t, "wrapped: "+test.stderr, err.Error())
+		})
+	}
+
+	t.Run("unknown stderr", func(t *testing.T) {
+		err := NewGitError([]string{"status"}, "", exitErr)
+		require.EqualError(t, err, "git status: exit status 128")
+		for _, test := range tests {
+			require.NotErrorIs(t, err, test.kind)
+		}
+	})
+
+	require.NoError(t, NewGitError(nil, "", nil))
+}
@@ -10,71 +10,48 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/untillpro/goutils/exec"
+	"github.com/untillpro/qs/internal/tracker"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
-// LinkBranchToGithubIssue links an existing remote branch to a GitHub issue and prepares notes.
+// LinkBranchToIssue links an existing remote branch to the issue if the issue tracker supports it.
 // The branch must already exist on the remote before calling this function.
-func LinkBranchToGithubIssue(wd, parentRepo, githubIssueURL, issueNumber, branchName string, args ...string) (notes []string, err error) {
-	repo, org, err := GetRepoAndOrgName(wd)
+func LinkBranchToIssue(rc *RepoContext, t tracker.IssueTracker, issueURL, branchName string) error {
+	repo, org, err := GetRepoAndOrgName(rc)
 	if err != nil {
-		return nil, fmt.Errorf("GetRepoAndOrgName failed: %w", err)
+		return fmt.Errorf("GetRepoAndOrgName failed: %w", err)
 	}
 
 	if len(repo) == 0 {
-		return nil, errors.New(repoNotFound)
+		return errors.New(repoNotFound)
 	}
 
-	myrepo := org + slash + repo
-
-	if len(args) > 0 {
-		issueURL := args[0]
-		issueRepo := GetGithubIssueRepoFromURL(issueURL)
-		if len(issueRepo) > 0 {
-			parentRepo = issueRepo
-		}
+	branch := tracker.Branch{RepoFullName: org + slash + repo, Name: branchName}
+	if rc.Planned("link branch " + branch.RepoFullName + ":" + branchName + " to issue " + issueURL) {
+		return nil
 	}
-
-	stdout, stderr, err := new(exec.PipedExec).
-		Command("gh", "repo", "set-default", myrepo).
-		WorkingDir(wd).
-		RunToStrings()
-	if err != nil {
-		logger.Verbose(stderr)
-
-		if len(stderr) > 0 {
-			return nil, errors.New(stderr)
+	env := tracker.Env{Hosting: rc.HostingFor(issueURL)}
+	err = utils.Retry(rc.Context(), func() error {
+		err := t.Link(rc.Context(), env, issueURL, branch)
+		if errors.Is(err, tracker.ErrNotSupported) {
+			return utils.NonRetryable(err)
 		}
 
-		return nil, fmt.Errorf("failed to set default repo: %w", err)
-	}
-	printLn(stdout)
+		return err
+	})
+	if errors.Is(err, tracker.ErrNotSupported) {
+		logger.Verbose(t.Name(), "does not support linking branches to issues")
 
-	mainBranch, err := GetMainBranch(wd)
-	if err != nil {
-		return nil, fmt.Errorf(errMsgFailedToGetMainBranch, err)
+		return nil
 	}
-
-	stdout, stderr, err = new(exec.PipedExec).
-		Command("gh", "issue", "develop", issueNumber, "--branch-repo="+myrepo, "--repo="+parentRepo, "--name="+branchName, "--base="+mainBranch).
-		WorkingDir(wd).
-		RunToStrings()
 	if err != nil {
-		logger.Verbose(stderr)
-
-		if len(stderr) > 0 {
-			return nil, errors.New(stderr)
-		}
-
-		return nil, fmt.Errorf("failed to link branch to issue: %w", err)
+		return fmt.Errorf("failed to link branch to issue: %w", err)
 	}
-	logger.Verbose(stdout)
 
 	utils.DelayIfTest()
 
-	return nil, nil
+	return nil
 }
 
 func GetGithubIssueRepoFromURL(url string) (repoName string) {
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/tracker"
+)
+
+// noLinkTracker can not link branches and counts the attempts
+type noLinkTracker struct {
+	tracker.IssueTracker
+	attempts int
+}
+
+func (t *noLinkTracker) Name() string {
+	return "Jira"
+}
+
+func (t *noLinkTracker) Link(_ context.Context, _ tracker.Env, _ string, _ tracker.Branch) error {
+	t.attempts++
+
+	return tracker.ErrNotSupported
+}
+
+func TestLinkBranchToIssue_NotSupported(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	// a retry would hang the test
+	t.Setenv("QS_MAX_RETRIES", "3")
+	t.Setenv("QS_RETRY_DELAY_MS", "600000")
+	fake.On("git config --local remote.origin.url").Return("https://github.com/untillpro/qs.git\n", "", nil)
+	tr := &noLinkTracker{}
+
+	require.NoError(t, LinkBranchToIssue(rc, tr, "https://untill.atlassian.net/browse/AIR-270", "AIR-270-fix-dev"))
+	require.Equal(t, 1, tr.attempts)
+}
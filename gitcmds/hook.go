@@ -6,255 +6,385 @@
 package gitcmds
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
-	"os/user"
 	"path/filepath"
 	"strings"
 
-	"github.com/untillpro/goutils/exec"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
-// SetLocalPreCommitHook - s.e.
-func SetLocalPreCommitHook(wd string) error {
-	// Turn off globa1 hooks
-	err := new(exec.PipedExec).
-		Command(git, "config", "--global", "--get", "core.hookspath").
-		Run(os.Stdout, os.Stdout)
-	if err == nil {
-		_, stderr, err := new(exec.PipedExec).
-			Command(git, "config", "--global", "--unset", "core.hookspath").
-			RunToStrings()
+// InstallHooks adds the qs checks to the pre-commit hook of the repository or, if global is true,
+// to the pre-commit hook of the global hooks directory.
+// Existing hooks and core.hooksPath are kept, the qs checks are run before them.
+// large-file-hook.sh edited by the user is overwritten only if force is true.
+func InstallHooks(rc *RepoContext, global, force bool) (HooksStatus, error) {
+	if rc.Planned("install qs pre-commit hook") {
+		return hooksStatus(rc, global)
+	}
+	chainRepoHook := false
+	if global {
+		status, err := globalHooksStatus(rc)
 		if err != nil {
-			logger.Verbose(stderr)
-
-			if len(stderr) > 0 {
-				return errors.New(stderr)
+			return HooksStatus{}, err
+		}
+		if len(status.HooksPath) == 0 {
+			// setting core.hooksPath disables the hooks of repositories, so qs runs them
+			if err := os.MkdirAll(status.Dir, os.ModePerm); err != nil {
+				return HooksStatus{}, fmt.Errorf("failed to create hooks directory: %w", err)
 			}
-
-			return fmt.Errorf("failed to unset global hooks path: %w", err)
+			if _, stderr, err := rc.run(git, "config", "--global", hooksPathKey, status.Dir); err != nil {
+				logger.Verbose(stderr)
+				return HooksStatus{}, fmt.Errorf("failed to set global %s: %w", hooksPathKey, err)
+			}
+			// remembered to unset core.hooksPath on uninstall
+			if _, stderr, err := rc.run(git, "config", "--global", hooksPathSetByQsKey, "true"); err != nil {
+				logger.Verbose(stderr)
+				return HooksStatus{}, fmt.Errorf("failed to set global %s: %w", hooksPathSetByQsKey, err)
+			}
+			chainRepoHook = true
 		}
 	}
-	dir, err := GetRootFolder(wd)
+
+	status, err := hooksStatus(rc, global)
 	if err != nil {
-		return err
+		return HooksStatus{}, err
 	}
-	PreCommitHooksDirPath := filepath.Join(dir, ".git/hooks")
-
-	if err := os.MkdirAll(PreCommitHooksDirPath, os.ModePerm); err != nil {
-		return fmt.Errorf("failed to create hooks directory: %w", err)
+	if err := os.MkdirAll(status.Dir, os.ModePerm); err != nil {
+		return HooksStatus{}, fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := addHookBlock(filepath.Join(status.Dir, preCommitHookFilename), chainRepoHook); err != nil {
+		return HooksStatus{}, err
+	}
+	if status.LargeFileHook != HookFileUpToDate && (status.LargeFileHook != HookFileModified || force) {
+		if err := writeLargeFileHook(status.Dir); err != nil {
+			return HooksStatus{}, err
+		}
 	}
 
-	PreCommitFilePath := filepath.Join(PreCommitHooksDirPath, "pre-commit")
+	return hooksStatus(rc, global)
+}
 
-	// Check if the file already exists
-	f, err := createOrOpenFile(PreCommitFilePath)
+// UninstallHooks removes the qs checks from the pre-commit hook of the repository or of the global hooks directory.
+// The pre-commit hook is deleted if nothing but the qs checks is left there.
+// large-file-hook.sh edited by the user and core.hooksPath are kept,
+// except the global core.hooksPath set by InstallHooks, which is unset.
+func UninstallHooks(rc *RepoContext, global bool) (HooksStatus, error) {
+	if rc.Planned("uninstall qs pre-commit hook") {
+		return hooksStatus(rc, global)
+	}
+	status, err := hooksStatus(rc, global)
 	if err != nil {
-		return err
+		return HooksStatus{}, err
 	}
-	_ = f.Close()
-
-	if !largeFileHookExist(PreCommitFilePath) {
-		return fillPreCommitFile(dir, PreCommitFilePath)
+	if err := removeHookBlock(filepath.Join(status.Dir, preCommitHookFilename)); err != nil {
+		return HooksStatus{}, err
+	}
+	if status.LargeFileHook == HookFileUpToDate || status.LargeFileHook == HookFileOutdated {
+		if err := os.Remove(filepath.Join(status.Dir, LargeFileHookFilename)); err != nil {
+			return HooksStatus{}, fmt.Errorf("failed to remove large file hook: %w", err)
+		}
+	}
+	if global {
+		if err := unsetGlobalHooksPath(rc, status.HooksPath); err != nil {
+			return HooksStatus{}, err
+		}
 	}
 
-	return nil
+	return hooksStatus(rc, global)
 }
 
-func createOrOpenFile(filepath string) (*os.File, error) {
-	_, err := os.Stat(filepath)
-	var f *os.File
-	if os.IsNotExist(err) {
-		// Create file pre-commit
-		f, err = os.Create(filepath)
-		if err != nil {
-			return nil, err
-		}
-
-		_, err = f.WriteString("#!/bin/bash\n")
-	} else {
-		f, err = os.OpenFile(filepath, os.O_APPEND|os.O_WRONLY, bashFilePerm)
+// unsetGlobalHooksPath unsets the global core.hooksPath if it was set by qs and is not changed since then,
+// so the hooks of repositories are run by git again
+func unsetGlobalHooksPath(rc *RepoContext, hooksPath string) error {
+	// not set key is reported by non-zero exit code
+	setByQs, _, _ := rc.run(git, "config", "--global", "--get", hooksPathSetByQsKey)
+	if strings.TrimSpace(setByQs) != "true" {
+		return nil
 	}
+
+	home, err := os.UserHomeDir()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if filepath.Clean(hooksPath) == filepath.Join(home, defaultGlobalHooksDir) {
+		if _, stderr, err := rc.run(git, "config", "--global", "--unset", hooksPathKey); err != nil {
+			logger.Verbose(stderr)
+			return fmt.Errorf("failed to unset global %s: %w", hooksPathKey, err)
+		}
 	}
+	if _, stderr, err := rc.run(git, "config", "--global", "--unset", hooksPathSetByQsKey); err != nil {
+		logger.Verbose(stderr)
+		return fmt.Errorf("failed to unset global %s: %w", hooksPathSetByQsKey, err)
+	}
+
+	return nil
+}
 
-	return f, nil
+// GetHooksStatus returns the state of the qs hooks of the repository or of the global hooks directory
+func GetHooksStatus(rc *RepoContext, global bool) (HooksStatus, error) {
+	return hooksStatus(rc, global)
 }
 
-func fillPreCommitFile(rootDir, myFilePath string) error {
-	fPreCommit, err := createOrOpenFile(myFilePath)
+// EnsureLargeFileHookUpToDate updates large-file-hook.sh written by an older qs version.
+// The file is kept as is if it is edited by the user.
+func EnsureLargeFileHookUpToDate(rc *RepoContext) error {
+	status, err := hooksStatus(rc, false)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = fPreCommit.Close()
-	}()
+	switch status.LargeFileHook {
+	case HookFileOutdated:
+		if rc.Planned("update " + LargeFileHookFilename) {
+			return nil
+		}
+		return writeLargeFileHook(status.Dir)
+	case HookFileMissing:
+		if status.Installed && !rc.Planned("write "+LargeFileHookFilename) {
+			return writeLargeFileHook(status.Dir)
+		}
+	case HookFileModified:
+		logger.Verbose(LargeFileHookFilename + " is modified by the user and is not updated, run 'qs hooks install --force' to overwrite it")
+	}
 
-	lfPath := rootDir + "/.git/hooks/" + LargeFileHookFilename
+	return nil
+}
 
-	lf, err := os.Create(lfPath)
+func hooksStatus(rc *RepoContext, global bool) (HooksStatus, error) {
+	var status HooksStatus
+	var err error
+	if global {
+		status, err = globalHooksStatus(rc)
+	} else {
+		status, err = localHooksStatus(rc)
+	}
 	if err != nil {
-		return err
+		return HooksStatus{}, err
 	}
-	defer func() {
-		_ = lf.Close()
-	}()
 
-	if _, err := lf.WriteString(largeFileHookContent); err != nil {
-		return fmt.Errorf("failed to write large file hook content: %w", err)
+	preCommit, err := readHookFile(filepath.Join(status.Dir, preCommitHookFilename))
+	if err != nil {
+		return HooksStatus{}, err
+	}
+	status.Installed = strings.Contains(preCommit, qsHookBlockBegin) || strings.Contains(preCommit, LargeFileHookFilename)
+	if strings.Contains(preCommit, preCommitFrameworkMarker) {
+		status.Managers = append(status.Managers, hookManagerPreCommit)
+	}
+	if strings.Contains(filepath.ToSlash(status.HooksPath), huskyDirName) {
+		status.Managers = append(status.Managers, hookManagerHusky)
 	}
 
-	preCommitContentBuf := strings.Builder{}
-	preCommitContentBuf.WriteString("#!/bin/bash\n")
-	preCommitContentBuf.WriteString("\n#Here is large files commit prevent is added by [qs]\n")
-	preCommitContentBuf.WriteString("bash " + lfPath + caret)
-	if _, err := fPreCommit.WriteString(preCommitContentBuf.String()); err != nil {
-		return fmt.Errorf("failed to write pre-commit hook content: %w", err)
+	largeFileHook, err := readHookFile(filepath.Join(status.Dir, LargeFileHookFilename))
+	if err != nil {
+		return HooksStatus{}, err
 	}
+	status.LargeFileHook = largeFileHookState(largeFileHook)
 
-	return new(exec.PipedExec).Command("chmod", "+x", myFilePath).Run(os.Stdout, os.Stdout)
+	return status, nil
 }
 
-func isLargeFileHookContentUpToDate(rootDir string) (bool, error) {
-	hookPath := filepath.Join(rootDir, ".git", "hooks", LargeFileHookFilename)
+// localHooksStatus returns the hooks directory used by git in the repository, core.hooksPath is taken into account
+func localHooksStatus(rc *RepoContext) (HooksStatus, error) {
+	stdout, stderr, err := rc.run(git, "rev-parse", "--path-format=absolute", "--git-path", "hooks")
+	if err != nil {
+		logger.Verbose(stderr)
+		return HooksStatus{}, fmt.Errorf("failed to get hooks directory: %w", err)
+	}
+	// not set core.hooksPath is reported by non-zero exit code
+	hooksPath, _, _ := rc.run(git, "config", "--get", hooksPathKey)
+
+	return HooksStatus{
+		Dir:       strings.TrimSpace(stdout),
+		HooksPath: strings.TrimSpace(hooksPath),
+	}, nil
+}
 
-	// Check if the file exists
-	if _, err := os.Stat(hookPath); os.IsNotExist(err) {
-		return false, nil // File doesn't exist, so it's not up to date
+// globalHooksStatus returns the global core.hooksPath or the directory qs uses as the global one if it is not set
+func globalHooksStatus(rc *RepoContext) (HooksStatus, error) {
+	stdout, _, _ := rc.run(git, "config", "--global", "--path", "--get", hooksPathKey)
+	hooksPath := strings.TrimSpace(stdout)
+	if len(hooksPath) > 0 {
+		return HooksStatus{Dir: hooksPath, HooksPath: hooksPath}, nil
 	}
 
-	// Read the current content
-	currentContent, err := os.ReadFile(hookPath)
+	home, err := os.UserHomeDir()
 	if err != nil {
-		return false, fmt.Errorf("failed to read large file hook: %w", err)
+		return HooksStatus{}, err
 	}
 
-	// Compare with expected content
-	return string(currentContent) == largeFileHookContent, nil
+	return HooksStatus{Dir: filepath.Join(home, defaultGlobalHooksDir)}, nil
 }
 
-func updateLargeFileHookContent(rootDir string) error {
-	hookPath := filepath.Join(rootDir, ".git", "hooks", LargeFileHookFilename)
-
-	// Create or overwrite the hook file
-	lf, err := os.Create(hookPath)
+// readHookFile returns the content of the hook file, empty if the file does not exist
+func readHookFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create large file hook: %w", err)
+		return "", fmt.Errorf("failed to read hook %s: %w", path, err)
 	}
-	defer func() {
-		_ = lf.Close()
-	}()
 
-	if _, err := lf.WriteString(largeFileHookContent); err != nil {
-		return fmt.Errorf("failed to write large file hook content: %w", err)
+	return string(content), nil
+}
+
+func writeHookFile(path, content string) error {
+	if err := os.WriteFile(path, []byte(content), hookFilePerm); err != nil {
+		return fmt.Errorf("failed to write hook %s: %w", path, err)
 	}
+	// WriteFile keeps permissions of the existing file
+	return os.Chmod(path, hookFilePerm)
+}
 
-	return nil
+func writeLargeFileHook(dir string) error {
+	content := largeFileHookContent + hookChecksumPrefix + checksum(largeFileHookContent) + caret
+
+	return writeHookFile(filepath.Join(dir, LargeFileHookFilename), content)
 }
 
-func EnsureLargeFileHookUpToDate(wd string) error {
-	rootDir, err := GetRootFolder(wd)
-	if err != nil {
-		return err
+// largeFileHookState tells whether large-file-hook.sh is written by the current qs version,
+// by an older one or edited by the user
+func largeFileHookState(content string) HookFileState {
+	if len(content) == 0 {
+		return HookFileMissing
 	}
-	upToDate, err := isLargeFileHookContentUpToDate(rootDir)
-	if err != nil {
-		return err
-	}
-	if !upToDate {
-		return updateLargeFileHookContent(rootDir)
+
+	idx := strings.LastIndex(content, caret+hookChecksumPrefix)
+	if idx < 0 {
+		if legacyLargeFileHookChecksums[checksum(content)] {
+			return HookFileOutdated
+		}
+		return HookFileModified
 	}
-	return nil
-}
 
-func getGlobalHookFolder() string {
-	stdout, _, err := new(exec.PipedExec).
-		Command(git, "config", "--global", "core.hooksPath").
-		RunToStrings()
-	if err != nil {
-		return ""
+	body := content[:idx+1]
+	if strings.TrimSpace(content[idx+1+len(hookChecksumPrefix):]) != checksum(body) {
+		return HookFileModified
+	}
+	if body != largeFileHookContent {
+		return HookFileOutdated
 	}
-	return strings.TrimSpace(stdout)
+
+	return HookFileUpToDate
 }
 
-func getLocalHookFolder(rootDir string) string {
-	return rootDir + "/.git/hooks/pre-commit"
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
 }
 
-func LocalPreCommitHookExist(wd string) (bool, error) {
-	rootDir, err := GetRootFolder(wd)
+// addHookBlock inserts the qs block right after the shebang of the pre-commit hook,
+// so the qs checks are run even if the rest of the hook ends with exec.
+// Lines added by older qs versions are replaced by the block.
+func addHookBlock(path string, chainRepoHook bool) error {
+	content, err := readHookFile(path)
 	if err != nil {
-		return false, err
+		return err
 	}
-	fp := getLocalHookFolder(rootDir)
-	if _, err := os.Stat(fp); os.IsNotExist(err) {
-		return false, nil
+	if strings.Contains(content, qsHookBlockBegin) {
+		return nil
 	}
-	return largeFileHookExist(fp), nil
-}
 
-func largeFileHookExist(filepath string) bool {
-	substring := LargeFileHookFilename
-	_, _, err := new(exec.PipedExec).Command("grep", "-l", substring, filepath).RunToStrings()
+	block := qsHookBlockBegin + caret + qsHookLines
+	if chainRepoHook {
+		block += repoHookChainLines
+	}
+	block += qsHookBlockEnd
+
+	lines := removeLegacyHookLines(content)
+	switch {
+	case len(lines) == 0:
+		lines = []string{shebang, block, ""}
+	case strings.HasPrefix(lines[0], "#!"):
+		lines = append([]string{lines[0], block}, lines[1:]...)
+	default:
+		lines = append([]string{block}, lines...)
+	}
 
-	return err == nil
+	return writeHookFile(path, strings.Join(lines, caret))
 }
 
-// SetGlobalPreCommitHook - s.e.
-func SetGlobalPreCommitHook(wd string) error {
-	var err error
-	path := getGlobalHookFolder()
+// removeHookBlock removes the qs block and lines added by older qs versions from the pre-commit hook.
+// The hook is deleted if nothing but the shebang is left.
+func removeHookBlock(path string) error {
+	content, err := readHookFile(path)
+	if err != nil || len(content) == 0 {
+		return err
+	}
 
-	if len(path) == 0 {
-		rootUser, err := user.Current()
-		if err != nil {
-			return err
+	if begin := strings.Index(content, qsHookBlockBegin); begin >= 0 {
+		if end := strings.Index(content[begin:], qsHookBlockEnd); end >= 0 {
+			content = content[:begin] + strings.TrimPrefix(content[begin+end+len(qsHookBlockEnd):], caret)
 		}
+	}
+	lines := removeLegacyHookLines(content)
 
-		path = rootUser.HomeDir
-		path += "/.git/hooks"
-		if err = os.MkdirAll(path, os.ModePerm); err != nil {
-			return err
+	rest := strings.TrimSpace(strings.Join(lines, caret))
+	if len(rest) == 0 || rest == shebang {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove hook %s: %w", path, err)
 		}
+		return nil
 	}
 
-	// Set global hooks folder
-	err = new(exec.PipedExec).
-		Command(git, "config", "--global", "core.hookspath", path).
-		Run(os.Stdout, os.Stdout)
-	if err != nil {
-		return err
-	}
+	return writeHookFile(path, strings.Join(lines, caret))
+}
 
-	filepath := path + "/pre-commit"
-	f, err := createOrOpenFile(filepath)
-	if err != nil {
-		return err
+// removeLegacyHookLines splits the hook into lines dropping the ones appended by older qs versions:
+// a comment, a call of large-file-hook.sh and a repeated shebang
+func removeLegacyHookLines(content string) []string {
+	if len(content) == 0 {
+		return nil
 	}
 
-	_ = f.Close()
-	if !largeFileHookExist(filepath) {
-		rootDir, err := GetRootFolder(wd)
-		if err != nil {
-			return err
+	var lines []string
+	for i, line := range strings.Split(content, caret) {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == legacyHookComment:
+		case strings.HasPrefix(trimmed, "bash ") && strings.HasSuffix(trimmed, LargeFileHookFilename):
+		case i > 0 && trimmed == shebang:
+		default:
+			lines = append(lines, line)
 		}
-		return fillPreCommitFile(rootDir, filepath)
 	}
 
-	return nil
+	return lines
 }
 
-func GetRootFolder(wd string) (string, error) {
-	stdout, _, err := new(exec.PipedExec).
-		Command(git, "rev-parse", "--show-toplevel").
-		WorkingDir(wd).
-		RunToStrings()
-	if err != nil {
-		return "", err
+func (s HookFileState) String() string {
+	switch s {
+	case HookFileMissing:
+		return "missing"
+	case HookFileUpToDate:
+		return "up to date"
+	case HookFileOutdated:
+		return "outdated"
+	case HookFileModified:
+		return "modified by user"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText makes the state readable in JSON output
+func (s HookFileState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// GetRootFolder returns the top level folder of the working tree, memoized by the invocation
+func GetRootFolder(rc *RepoContext) (string, error) {
+	if len(rc.facts.rootFolder) == 0 {
+		stdout, _, err := rc.run(git, "rev-parse", "--show-toplevel")
+		if err != nil {
+			return "", err
+		}
+		rc.facts.rootFolder = strings.TrimSpace(stdout)
 	}
 
-	return strings.TrimSpace(stdout), nil
+	return rc.facts.rootFolder, nil
 }
@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallHooks(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	hooksDir := filepath.Join(rc.Wd, ".husky", "_")
+	fake.On("git rev-parse --path-format=absolute --git-path hooks").Return(hooksDir, "", nil)
+	fake.On("git config --get core.hooksPath").Return(".husky/_\n", "", nil)
+	require.NoError(t, os.MkdirAll(hooksDir, 0755))
+	preCommitPath := filepath.Join(hooksDir, preCommitHookFilename)
+	lfPath := filepath.Join(hooksDir, LargeFileHookFilename)
+	existing := "#!/usr/bin/env sh\n. \"$(dirname \"$0\")/h\"\n"
+	require.NoError(t, os.WriteFile(preCommitPath, []byte(existing), 0755))
+
+	t.Run("existing hook and core.hooksPath are kept", func(t *testing.T) {
+		status, err := InstallHooks(rc, false, false)
+		require.NoError(t, err)
+		require.Equal(t, HooksStatus{
+			Dir:           hooksDir,
+			HooksPath:     ".husky/_",
+			Managers:      []string{hookManagerHusky},
+			Installed:     true,
+			LargeFileHook: HookFileUpToDate,
+		}, status)
+		require.False(t, fake.Ran("git config --global"))
+
+		content, err := os.ReadFile(preCommitPath)
+		require.NoError(t, err)
+		require.Equal(t, "#!/usr/bin/env sh\n"+qsHookBlockBegin+"\n"+qsHookLines+qsHookBlockEnd+"\n. \"$(dirname \"$0\")/h\"\n", string(content))
+
+		// repeated install changes nothing
+		_, err = InstallHooks(rc, false, false)
+		require.NoError(t, err)
+		again, err := os.ReadFile(preCommitPath)
+		require.NoError(t, err)
+		require.Equal(t, content, again)
+	})
+
+	t.Run("user edits are not overwritten", func(t *testing.T) {
+		edited := largeFileHookContent + "echo edited\n"
+		require.NoError(t, os.WriteFile(lfPath, []byte(edited), 0755))
+
+		require.NoError(t, EnsureLargeFileHookUpToDate(rc))
+		status, err := InstallHooks(rc, false, false)
+		require.NoError(t, err)
+		require.Equal(t, HookFileModified, status.LargeFileHook)
+		content, err := os.ReadFile(lfPath)
+		require.NoError(t, err)
+		require.Equal(t, edited, string(content))
+
+		status, err = InstallHooks(rc, false, true)
+		require.NoError(t, err)
+		require.Equal(t, HookFileUpToDate, status.LargeFileHook)
+	})
+
+	t.Run("uninstall restores the hook", func(t *testing.T) {
+		status, err := UninstallHooks(rc, false)
+		require.NoError(t, err)
+		require.False(t, status.Installed)
+		require.Equal(t, HookFileMissing, status.LargeFileHook)
+		content, err := os.ReadFile(preCommitPath)
+		require.NoError(t, err)
+		require.Equal(t, existing, string(content))
+	})
+}
+
+func TestInstallHooks_Legacy(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	hooksDir := filepath.Join(rc.Wd, ".git", "hooks")
+	fake.On("git rev-parse --path-format=absolute --git-path hooks").Return(hooksDir+"\n", "", nil)
+	require.NoError(t, os.MkdirAll(hooksDir, 0755))
+	preCommitPath := filepath.Join(hooksDir, preCommitHookFilename)
+	legacy := "#!/bin/bash\necho lint\n#!/bin/bash\n\n" + legacyHookComment + "\nbash " + filepath.Join(hooksDir, LargeFileHookFilename) + "\n"
+	require.NoError(t, os.WriteFile(preCommitPath, []byte(legacy), 0644))
+
+	status, err := GetHooksStatus(rc, false)
+	require.NoError(t, err)
+	require.True(t, status.Installed)
+
+	require.NoError(t, EnsureLargeFileHookUpToDate(rc))
+	_, err = InstallHooks(rc, false, false)
+	require.NoError(t, err)
+	content, err := os.ReadFile(preCommitPath)
+	require.NoError(t, err)
+	require.Equal(t, "#!/bin/bash\n"+qsHookBlockBegin+"\n"+qsHookLines+qsHookBlockEnd+"\necho lint\n\n", string(content))
+	info, err := os.Stat(preCommitPath)
+	require.NoError(t, err)
+	require.Equal(t, hookFilePerm, info.Mode().Perm())
+
+	// the hook is deleted if nothing but the qs checks is left
+	onlyQs := "#!/bin/bash\n#!/bin/bash\n\n" + legacyHookComment + "\nbash " + filepath.Join(hooksDir, LargeFileHookFilename) + "\n"
+	require.NoError(t, os.WriteFile(preCommitPath, []byte(onlyQs), 0644))
+	_, err = UninstallHooks(rc, false)
+	require.NoError(t, err)
+	require.NoFileExists(t, preCommitPath)
+	require.NoFileExists(t, filepath.Join(hooksDir, LargeFileHookFilename))
+}
+
+func TestLargeFileHookState(t *testing.T) {
+	current := largeFileHookContent + hookChecksumPrefix + checksum(largeFileHookContent) + "\n"
+	older := "#!/bin/bash\nexit 0\n"
+	tests := []struct {
+		name    string
+		content string
+		want    HookFileState
+	}{
+		{"missing", "", HookFileMissing},
+		{"current", current, HookFileUpToDate},
+		{"older with checksum", older + hookChecksumPrefix + checksum(older) + "\n", HookFileOutdated},
+		{"edited", "#!/bin/bash\necho hi\n" + current, HookFileModified},
+		{"without checksum", largeFileHookContent, HookFileModified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, largeFileHookState(tt.content))
+		})
+	}
+}
+
+func TestInstallHooks_Global(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	hooksDir := filepath.Join(home, defaultGlobalHooksDir)
+
+	t.Run("core.hooksPath set by qs is unset on uninstall", func(t *testing.T) {
+		rc, fake := newFakeRepoContext(t)
+		fake.On("git config --global --path --get core.hooksPath").Return("", "", errors.New("exit status 1")).Once()
+		fake.On("git config --global --path --get core.hooksPath").Return(hooksDir+"\n", "", nil)
+		fake.On("git config --global --get qs.hooksPathSet").Return("true\n", "", nil)
+
+		status, err := InstallHooks(rc, true, false)
+		require.NoError(t, err)
+		require.True(t, status.Installed)
+		require.True(t, fake.Ran("git config --global core.hooksPath "+hooksDir))
+		require.True(t, fake.Ran("git config --global qs.hooksPathSet true"))
+
+		status, err = UninstallHooks(rc, true)
+		require.NoError(t, err)
+		require.False(t, status.Installed)
+		require.True(t, fake.Ran("git config --global --unset core.hooksPath"))
+		require.True(t, fake.Ran("git config --global --unset qs.hooksPathSet"))
+		require.NoFileExists(t, filepath.Join(hooksDir, preCommitHookFilename))
+	})
+
+	t.Run("core.hooksPath set by the user is kept", func(t *testing.T) {
+		rc, fake := newFakeRepoContext(t)
+		userDir := filepath.Join(home, "hooks")
+		fake.On("git config --global --path --get core.hooksPath").Return(userDir+"\n", "", nil)
+		fake.On("git config --global --get qs.hooksPathSet").Return("", "", errors.New("exit status 1"))
+
+		_, err := InstallHooks(rc, true, false)
+		require.NoError(t, err)
+		require.False(t, fake.Ran("git config --global core.hooksPath"))
+		require.False(t, fake.Ran("git config --global qs.hooksPathSet"))
+
+		_, err = UninstallHooks(rc, true)
+		require.NoError(t, err)
+		require.False(t, fake.Ran("git config --global --unset"))
+		require.NoFileExists(t, filepath.Join(userDir, preCommitHookFilename))
+	})
+}
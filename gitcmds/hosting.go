@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/hosting/gitea"
+	"github.com/untillpro/qs/internal/hosting/github"
+	"github.com/untillpro/qs/internal/hosting/gitlab"
+	"github.com/untillpro/qs/internal/runner"
+)
+
+// HostingFor returns the provider of the service hosting the given URL, e.g. an issue URL.
+// Returns the provider of the repository if the URL is on the same host.
+func (rc *RepoContext) HostingFor(rawURL string) hosting.HostingProvider {
+	host := urlHost(rawURL)
+	if len(host) == 0 || host == rc.Host {
+		return rc.Hosting
+	}
+
+	return newHostingProvider(strings.SplitN(rawURL, "://", 2)[0]+"://"+host, rc.Runner, rc.Settings().Hosting.Kind)
+}
+
+// newHostingProvider returns the API client of the service at webURL, e.g. "https://gitlab.example.com".
+// kinds are the values of the hosting.kind setting.
+func newHostingProvider(webURL string, r runner.CommandRunner, kinds []string) hosting.HostingProvider {
+	switch hostingKind(urlHost(webURL), kinds) {
+	case hostingGitLab:
+		return gitlab.New(webURL+gitlab.APIPath, gitlab.DefaultToken())
+	case hostingGitea:
+		return gitea.New(webURL+gitea.APIPath, gitea.DefaultToken())
+	default:
+		return github.NewForWebURL(webURL, github.DefaultToken(r, urlHost(webURL)))
+	}
+}
+
+// hostingKind returns the kind of the service at host; Forgejo is served as Gitea.
+// kinds are "host=kind" entries for the given hosts and "kind" entries for hosts not recognized by name.
+// A "host=kind" entry wins over the name of the host, github.com is always GitHub.
+// Unknown GitHub hosts are GitHub Enterprise Server instances.
+func hostingKind(host string, kinds []string) string {
+	if host == defaultHost {
+		return hostingGitHub
+	}
+	fallback := ""
+	for _, entry := range kinds {
+		entryHost, kind, ok := strings.Cut(entry, "=")
+		if !ok {
+			if len(fallback) == 0 {
+				fallback = normalizeHostingKind(entryHost)
+			}
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(entryHost), host) {
+			if kind = normalizeHostingKind(kind); len(kind) > 0 {
+				return kind
+			}
+		}
+	}
+
+	switch {
+	case host == gitlabHost, strings.Contains(host, hostingGitLab):
+		return hostingGitLab
+	case host == codebergHost, strings.Contains(host, hostingGitea), strings.Contains(host, hostingForgejo):
+		return hostingGitea
+	case len(fallback) > 0:
+		return fallback
+	}
+
+	return hostingGitHub
+}
+
+// normalizeHostingKind returns the known kind of the hosting in lower case, forgejo is gitea, empty if the kind is unknown
+func normalizeHostingKind(kind string) string {
+	switch kind = strings.ToLower(strings.TrimSpace(kind)); kind {
+	case hostingGitHub, hostingGitLab:
+		return kind
+	case hostingGitea, hostingForgejo:
+		return hostingGitea
+	}
+
+	return ""
+}
+
+// originRemoteURL returns the parsed URL of the origin remote of the repository in wd.
+// Returns nil if there is no origin or its URL can not be parsed.
+func originRemoteURL(wd string) *RemoteURL {
+	repo, err := OpenGitRepository(wd)
+	if err != nil {
+		return nil
+	}
+	remote, err := repo.Remote(origin)
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return nil
+	}
+	remoteURL, err := ParseGitRemoteURL(remote.Config().URLs[0])
+	if err != nil {
+		return nil
+	}
+
+	return remoteURL
+}
+
+// urlHost returns the host of the http(s) URL or empty string
+func urlHost(rawURL string) string {
+	if !strings.HasPrefix(rawURL, "http") {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Host
+}
+
+// repoURL returns the URL of the repository on the host of the context.
+// The protocol of the origin remote is used, https if there is no origin.
+func (rc *RepoContext) repoURL(repoFullName string) string {
+	if rc.Origin != nil {
+		return rc.Origin.WithPath(repoFullName)
+	}
+
+	return protocolHTTPS + "://" + rc.Host + slash + repoFullName
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/hosting/gitea"
+	"github.com/untillpro/qs/internal/hosting/github"
+	"github.com/untillpro/qs/internal/hosting/gitlab"
+	"github.com/untillpro/qs/internal/runner"
+)
+
+func TestHostingKind(t *testing.T) {
+	tests := []struct {
+		name  string
+		host  string
+		kinds []string
+		want  string
+	}{
+		{name: "github.com", host: "github.com", want: hostingGitHub},
+		{name: "github.com ignores kind", host: "github.com", kinds: []string{hostingGitLab, "github.com=gitlab"}, want: hostingGitHub},
+		{name: "gitlab.com", host: "gitlab.com", want: hostingGitLab},
+		{name: "self-managed GitLab by name", host: "gitlab.example.com", want: hostingGitLab},
+		{name: "codeberg.org", host: "codeberg.org", want: hostingGitea},
+		{name: "self-hosted Forgejo by name", host: "forgejo.example.com", want: hostingGitea},
+		{name: "unknown host", host: "git.example.com", want: hostingGitHub},
+		{name: "unknown host with kind", host: "git.example.com", kinds: []string{"GitLab"}, want: hostingGitLab},
+		{name: "unknown host with kind forgejo", host: "git.example.com", kinds: []string{hostingForgejo}, want: hostingGitea},
+		{name: "kind does not apply to hosts recognized by name", host: "gitlab.example.com", kinds: []string{hostingGitea}, want: hostingGitLab},
+		{name: "kind of the host", host: "git.company.com", kinds: []string{"git.other.com=gitea", "Git.Company.com=gitlab"}, want: hostingGitLab},
+		{name: "kind of the host wins over the name", host: "gitlab.company.com", kinds: []string{"gitlab.company.com=forgejo"}, want: hostingGitea},
+		{name: "kind of the host wins over kind", host: "git.company.com", kinds: []string{hostingGitea, "git.company.com=gitlab"}, want: hostingGitLab},
+		{name: "kind of other host", host: "git.company.com", kinds: []string{"git.other.com=gitlab"}, want: hostingGitHub},
+		{name: "unknown kind", host: "git.company.com", kinds: []string{"git.company.com=bitbucket"}, want: hostingGitHub},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, hostingKind(tt.host, tt.kinds))
+		})
+	}
+}
+
+func TestHostingFor(t *testing.T) {
+	require := require.New(t)
+	rc := &RepoContext{Runner: runner.NewFake(), Host: "gitlab.example.com"}
+	rc.Hosting = newHostingProvider("https://"+rc.Host, rc.Runner, nil)
+	require.IsType(&gitlab.Client{}, rc.Hosting)
+
+	require.Same(rc.Hosting, rc.HostingFor("https://gitlab.example.com/group/qs/-/issues/7"))
+	require.Same(rc.Hosting, rc.HostingFor("not a URL"))
+	require.IsType(&github.Client{}, rc.HostingFor("https://github.com/untillpro/qs/issues/42"))
+	require.IsType(&github.Client{}, rc.HostingFor("https://github.example.com/untillpro/qs/issues/42"))
+
+	rc.Settings().Hosting.Kind = []string{"git.company.com=gitea"}
+	require.IsType(&gitea.Client{}, rc.HostingFor("https://git.company.com/org/qs/issues/3"))
+}
+
+func TestMakeUpstreamForBranch_UsesOriginHost(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	rc.Host = "gitlab.example.com"
+
+	require.NoError(t, MakeUpstreamForBranch(rc, "group/qs"))
+	require.True(t, fake.Ran("git remote add upstream https://gitlab.example.com/group/qs"))
+}
@@ -0,0 +1,213 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/untillpro/qs/internal/journal"
+	"github.com/untillpro/qs/utils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+// BeginJournal starts the journal of the multi-step command recording the current branch
+// and the SHAs of the refs, missing refs are skipped.
+// The journal is kept in memory only in dry-run mode.
+func BeginJournal(rc *RepoContext, command string, refs ...string) (*journal.Journal, error) {
+	dir := ""
+	if rc.DryRun == nil {
+		var err error
+		if dir, err = journalDir(rc); err != nil {
+			return nil, err
+		}
+	}
+
+	branchName, err := GetCurrentBranchName(rc)
+	if err != nil {
+		return nil, err
+	}
+
+	shas := make(map[string]string, len(refs))
+	for _, ref := range refs {
+		if sha := RefSHA(rc, ref); len(sha) > 0 {
+			shas[ref] = sha
+		}
+	}
+
+	return journal.Begin(dir, command, branchName, shas)
+}
+
+// LoadJournal returns the journal of the interrupted command, nil if there is none.
+// Changes of the journal are not written in dry-run mode.
+func LoadJournal(rc *RepoContext) (*journal.Journal, error) {
+	dir, err := journalDir(rc)
+	if err != nil {
+		return nil, err
+	}
+	j, err := journal.Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	if rc.DryRun != nil {
+		j.Detach()
+	}
+
+	return j, nil
+}
+
+// journalDir returns the directory of the journal in the git directory
+func journalDir(rc *RepoContext) (string, error) {
+	stdout, stderr, err := rc.run(git, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		logger.Verbose(stderr)
+		return "", fmt.Errorf("failed to get git directory: %w", err)
+	}
+
+	return filepath.Join(strings.TrimSpace(stdout), journal.DirName), nil
+}
+
+// RefSHA returns the SHA the ref points to, empty if the ref does not exist
+func RefSHA(rc *RepoContext, ref string) string {
+	stdout, _, err := rc.run(git, "rev-parse", "--verify", "--quiet", ref)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(stdout)
+}
+
+// DeleteBranchIfExists backs up the branch and deletes it locally and from origin, missing branches are skipped
+func DeleteBranchIfExists(rc *RepoContext, branchName string) error {
+	if len(RefSHA(rc, refsHeads+branchName)) > 0 {
+		if _, err := BackupBranch(rc, branchName); err != nil {
+			return err
+		}
+		if _, stderr, err := rc.run(git, branch, "-D", branchName); err != nil {
+			logger.Verbose(stderr)
+			return fmt.Errorf("failed to delete local branch %s: %w", branchName, err)
+		}
+	}
+
+	stdout, stderr, err := rc.run(git, "ls-remote", "--heads", origin, branchName)
+	if err != nil {
+		logger.Verbose(stderr)
+		return fmt.Errorf("failed to check remote branch %s: %w", branchName, err)
+	}
+	if len(strings.TrimSpace(stdout)) == 0 {
+		return nil
+	}
+
+	return utils.Retry(rc.Context(), func() error {
+		_, stderr, err := rc.run(git, push, origin, "--delete", branchName)
+		if err != nil {
+			logger.Verbose(stderr)
+			return fmt.Errorf("failed to delete remote branch %s: %w", branchName, err)
+		}
+
+		return nil
+	})
+}
+
+// ResumePR completes qs pr interrupted after the dev branch is converted to the PR branch.
+// If the PR branch is not created yet, the dev branch is restored and qs pr is run again.
+func ResumePR(rc *RepoContext, j *journal.Journal) error {
+	var data prJournalData
+	if err := j.ReadData(&data); err != nil {
+		return err
+	}
+
+	if !j.IsDone(journal.StepPRBranchCreated) {
+		if err := restoreDevBranch(rc, j, data); err != nil {
+			return err
+		}
+	} else {
+		if _, stderr, err := rc.run(git, "checkout", data.PRBranch); err != nil {
+			logger.Verbose(stderr)
+			return fmt.Errorf("failed to checkout %s: %w", data.PRBranch, err)
+		}
+		if !j.IsDone(journal.StepDevBranchRemoved) {
+			if err := DeleteBranchIfExists(rc, data.DevBranch); err != nil {
+				return err
+			}
+		}
+	}
+	if err := j.Finish(); err != nil {
+		return err
+	}
+
+	return Pr(rc, data.Draft)
+}
+
+// RollbackPR restores the dev branch converted by interrupted qs pr and deletes the PR branch.
+// Nothing is rolled back if the pull request is already created.
+func RollbackPR(rc *RepoContext, j *journal.Journal) error {
+	var data prJournalData
+	if err := j.ReadData(&data); err != nil {
+		return err
+	}
+
+	if j.IsDone(journal.StepPushed) {
+		parentRepoName, err := GetParentRepoName(rc)
+		if err != nil {
+			return err
+		}
+		prInfo, err := DoesPrExist(rc, parentRepoName, data.PRBranch, PRStateOpen)
+		if err != nil {
+			return err
+		}
+		if prInfo != nil {
+			return fmt.Errorf("%w: %s, close it to roll back", ErrPRExists, prInfo.URL)
+		}
+	}
+
+	if err := restoreDevBranch(rc, j, data); err != nil {
+		return err
+	}
+
+	if j.IsDone(journal.StepDevBranchRemoved) {
+		// the dev branch is pushed back to the commit it pointed to on origin
+		if sha := j.Refs[refsRemotesOrigin+data.DevBranch]; len(sha) > 0 {
+			err := utils.Retry(rc.Context(), func() error {
+				_, stderr, err := rc.run(git, push, origin, sha+":"+refsHeads+data.DevBranch)
+				if err != nil {
+					logger.Verbose(stderr)
+					return fmt.Errorf("failed to push %s to origin: %w", data.DevBranch, err)
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if _, stderr, err := rc.run(git, branch, "--set-upstream-to", originSlash+data.DevBranch, data.DevBranch); err != nil {
+				logger.Verbose(stderr)
+			}
+		}
+	}
+
+	return j.Finish()
+}
+
+// restoreDevBranch checks out the dev branch at the commit it pointed to before qs pr and deletes the PR branch.
+// Changes left by the squash merge are discarded.
+func restoreDevBranch(rc *RepoContext, j *journal.Journal, data prJournalData) error {
+	sha := j.Refs[refsHeads+data.DevBranch]
+	if len(sha) == 0 {
+		return errors.New("original commit of " + data.DevBranch + " is not recorded")
+	}
+	if _, err := BackupBranch(rc, data.DevBranch); err != nil {
+		return err
+	}
+	if _, stderr, err := rc.run(git, "checkout", "--force", "-B", data.DevBranch, sha); err != nil {
+		logger.Verbose(stderr)
+		return fmt.Errorf("failed to restore %s: %w", data.DevBranch, err)
+	}
+
+	return DeleteBranchIfExists(rc, data.PRBranch)
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/journal"
+	"github.com/untillpro/qs/internal/runner"
+)
+
+func TestRollbackPR(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	gitDir := filepath.Join(rc.Wd, ".git")
+	fake.On("git rev-parse --absolute-git-dir").Return(gitDir+"\n", "", nil)
+	fake.On("git branch --show-current").Return("feature-dev\n", "", nil)
+	fake.On("git rev-parse --verify --quiet refs/heads/feature-dev").Return("aaa\n", "", nil)
+	fake.On("git rev-parse --verify --quiet refs/remotes/origin/feature-dev").Return("bbb\n", "", nil)
+	fake.On("git rev-parse --verify --quiet refs/heads/feature-pr").Return("ccc\n", "", nil)
+
+	j, err := BeginJournal(rc, journal.CommandPR, refsHeads+"feature-dev", refsRemotesOrigin+"feature-dev")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{refsHeads + "feature-dev": "aaa", refsRemotesOrigin + "feature-dev": "bbb"}, j.Refs)
+	require.NoError(t, j.SetData(prJournalData{DevBranch: "feature-dev", PRBranch: "feature-pr"}))
+	require.NoError(t, j.Done(journal.StepPRBranchCreated))
+	require.NoError(t, j.Done(journal.StepDevBranchRemoved))
+
+	loaded, err := LoadJournal(rc)
+	require.NoError(t, err)
+	require.NoError(t, RollbackPR(rc, loaded))
+
+	require.True(t, fake.Ran("git checkout --force -B feature-dev aaa"))
+	require.True(t, fake.Ran("git branch -D feature-pr"))
+	require.False(t, fake.Ran("git push origin --delete feature-pr"), "PR branch is not on origin")
+	require.True(t, fake.Ran("git push origin bbb:refs/heads/feature-dev"))
+
+	loaded, err = LoadJournal(rc)
+	require.NoError(t, err)
+	require.Nil(t, loaded)
+}
+
+func TestBeginJournal_DryRun(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	gitDir := filepath.Join(rc.Wd, ".git")
+	fake.On("git rev-parse --absolute-git-dir").Return(gitDir+"\n", "", nil)
+	rc.DryRun = runner.NewDryRun(fake, io.Discard)
+	rc.Runner = rc.DryRun
+
+	j, err := BeginJournal(rc, journal.CommandDev)
+	require.NoError(t, err)
+	require.NoError(t, j.Done(journal.StepBranchCreated))
+
+	loaded, err := LoadJournal(rc)
+	require.NoError(t, err)
+	require.Nil(t, loaded)
+}
@@ -8,14 +8,14 @@ package gitcmds
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
-	"github.com/untillpro/goutils/exec"
 	notesPkg "github.com/untillpro/qs/internal/notes"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
-func AddNotes(wd string, notes []string) error {
+func AddNotes(rc *RepoContext, notes []string) error {
 	var filtered []string
 	for _, s := range notes {
 		if str := strings.TrimSpace(s); len(str) > 0 {
@@ -25,15 +25,10 @@ func AddNotes(wd string, notes []string) error {
 	if len(filtered) == 0 {
 		return nil
 	}
-	stdout, stderr, err := new(exec.PipedExec).
-		Command(git, "notes", "append", "-m", strings.Join(filtered, caret+caret)). // double caret for backward compatibility
-		WorkingDir(wd).
-		RunToStrings()
+	// double caret for backward compatibility
+	stdout, stderr, err := rc.run(git, "notes", "append", "-m", strings.Join(filtered, caret+caret))
 	if err != nil {
 		logger.Verbose(stderr)
-		if len(stderr) > 0 {
-			return errors.New(stderr)
-		}
 		return fmt.Errorf("failed to add note: %w", err)
 	}
 	printLn(stdout)
@@ -45,57 +40,60 @@ func AddNotes(wd string, notes []string) error {
 // - notes
 // - revision count
 // - error if any
-func GetNotes(wd, branchName string) (notes []string, revCount int, err error) {
-	mainBranchName, err := GetMainBranch(wd)
+func GetNotes(rc *RepoContext, branchName string) (notes []string, revCount int, err error) {
+	mainBranchName, err := GetMainBranch(rc)
 	if err != nil {
 		return nil, 0, fmt.Errorf(errMsgFailedToGetMainBranch, err)
 	}
 
-	return getNotesWithMainBranch(wd, branchName, mainBranchName)
+	return getNotesWithMainBranch(rc, branchName, mainBranchName)
 }
 
-func getNotesWithMainBranch(wd, branchName, mainBranchName string) (notes []string, revCount int, err error) {
-	stdout, stderr, err := new(exec.PipedExec).
-		Command(git, "rev-list", mainBranchName+".."+branchName).
-		WorkingDir(wd).
-		RunToStrings()
+func getNotesWithMainBranch(rc *RepoContext, branchName, mainBranchName string) (notes []string, revCount int, err error) {
+	rn, err := loadRangeNotes(rc, mainBranchName+".."+branchName)
 	if err != nil {
-		logger.Verbose(stderr)
-
-		return notes, 0, fmt.Errorf("failed to get commit list: %w", err)
+		return nil, 0, err
+	}
+	if rn.revCount == 0 {
+		return nil, 0, fmt.Errorf("%w in current branch", ErrNoCommits)
 	}
-	if len(stdout) == 0 {
-		return notes, 0, errors.New("error: No commits found in current branch")
+	if len(rn.notes) == 0 {
+		return nil, rn.revCount, errors.New("error: No notes found in current branch")
 	}
 
-	revList := strings.Split(strings.TrimSpace(stdout), caret)
-	for _, rev := range revList {
-		stdout, stderr, err := new(exec.PipedExec).
-			Command(git, "notes", "show", rev).
-			WorkingDir(wd).
-			RunToStrings()
-		if err != nil {
-			if strings.Contains(stderr, "no note found") {
-				continue
-			}
-			logger.Verbose(stderr)
+	return slices.Clone(rn.notes), rn.revCount, nil
+}
 
-			return notes, len(revList), fmt.Errorf("failed to get notes: %w", err)
-		}
-		rawNotes := strings.Split(stdout, caret)
-		for _, rawNote := range rawNotes {
-			note := strings.TrimSpace(rawNote)
-			if len(note) > 0 {
-				notes = append(notes, note)
+// loadRangeNotes returns the notes of the commits of the range read by one git log, the result is cached by the context
+func loadRangeNotes(rc *RepoContext, revRange string) (rangeNotes, error) {
+	if rn, ok := rc.notes[revRange]; ok {
+		return rn, nil
+	}
+
+	// <sha>\0<notes>\0 for each commit
+	stdout, stderr, err := rc.run(git, "log", "-z", "--format=%H%x00%N", revRange)
+	if err != nil {
+		logger.Verbose(stderr)
+
+		return rangeNotes{}, fmt.Errorf("failed to get notes of %s: %w", revRange, err)
+	}
+
+	var rn rangeNotes
+	fields := strings.Split(stdout, nul)
+	for i := 0; i+1 < len(fields); i += 2 {
+		rn.revCount++
+		for _, rawNote := range strings.Split(fields[i+1], caret) {
+			if note := strings.TrimSpace(rawNote); len(note) > 0 {
+				rn.notes = append(rn.notes, note)
 			}
 		}
 	}
-
-	if len(notes) == 0 {
-		return notes, len(revList), errors.New("error: No notes found in current branch")
+	if rc.notes == nil {
+		rc.notes = make(map[string]rangeNotes)
 	}
+	rc.notes[revRange] = rn
 
-	return notes, len(revList), nil
+	return rn, nil
 }
 
 func GetBodyFromNotes(rawNotes []string) string {
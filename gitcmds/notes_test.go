@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotesWithMainBranch_Cached(t *testing.T) {
+	const logNotes = "git log -z --format=%H%x00%N main..feature-dev"
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git branch --show-current").Return("feature-dev\n", "", nil)
+	fake.On("git branch -r").Return("  origin/main\n", "", nil)
+	fake.On(logNotes).Return("ccc\x00Third\n\x00bbb\x00\x00aaa\x00First\n\nhttps://example.com/issues/1\n\x00", "", nil)
+
+	notes, revCount, err := getNotesWithMainBranch(rc, "feature-dev", "main")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Third", "First", "https://example.com/issues/1"}, notes)
+	require.Equal(t, 3, revCount)
+
+	_, _, err = getNotesWithMainBranch(rc, "feature-dev", "main")
+	require.NoError(t, err)
+	branchName, _, err := GetBranchType(rc)
+	require.NoError(t, err)
+	require.Equal(t, "feature-dev", branchName)
+	require.Equal(t, 1, countCalls(fake.Calls(), logNotes), "notes are loaded once")
+
+	require.NoError(t, AddNotes(rc, []string{"Second"}))
+	_, _, err = getNotesWithMainBranch(rc, "feature-dev", "main")
+	require.NoError(t, err)
+	require.Equal(t, 2, countCalls(fake.Calls(), logNotes), "notes are reloaded after the change")
+}
+
+func TestGetNotesWithMainBranch_NoCommits(t *testing.T) {
+	rc, _ := newFakeRepoContext(t)
+
+	_, _, err := getNotesWithMainBranch(rc, "feature-dev", "main")
+	require.ErrorIs(t, err, ErrNoCommits)
+}
+
+func countCalls(calls []string, cmdLine string) int {
+	count := 0
+	for _, call := range calls {
+		if call == cmdLine {
+			count++
+		}
+	}
+
+	return count
+}
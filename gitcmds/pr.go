@@ -1,99 +1,120 @@
 package gitcmds
 
 import (
-	"bufio"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strings"
 
-	"github.com/untillpro/goutils/exec"
+	"github.com/untillpro/qs/internal/journal"
 	notesPkg "github.com/untillpro/qs/internal/notes"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
-func Pr(wd string, needDraft bool) error {
-	currentBranchName, branchType, err := GetBranchType(wd)
+func Pr(rc *RepoContext, needDraft bool) error {
+	currentBranchName, branchType, err := GetBranchType(rc)
 	if err != nil {
 		return err
 	}
 
 	logger.Verbose(fmt.Sprintf("branch type is %s", branchType.String()))
 	if branchType == notesPkg.BranchTypeUnknown {
-		return errors.New("you must be on dev or pr branch")
+		return ErrNotOnDevBranch
 	}
 
-	parentRepoName, err := GetParentRepoName(wd)
+	parentRepoName, err := GetParentRepoName(rc)
 	if err != nil {
 		return err
 	}
 
-	mainBranch, err := GetMainBranch(wd)
+	mainBranch, err := GetMainBranch(rc)
 	if err != nil {
 		return err
 	}
 
-	_, _, err = new(exec.PipedExec).
-		Command(git, fetch, origin, "--force", utils.RefsNotes).
-		WorkingDir(wd).
-		RunToStrings()
+	_, _, err = rc.run(git, fetch, origin, "--force", utils.RefsNotes)
 	if err != nil {
 		logger.Verbose(fmt.Sprintf("Failed to fetch notes: %v", err))
 	}
 
-	notes, revCount, err := getNotesWithMainBranch(wd, currentBranchName, mainBranch)
+	notes, revCount, err := getNotesWithMainBranch(rc, currentBranchName, mainBranch)
 	if err != nil {
 		return err
 	}
 
-	issueDescription, err := GetIssueDescription(notes)
+	issueDescription, err := GetIssueDescription(rc, notes)
 	if err != nil {
 		return err
 	}
 
+	// the dev branch is converted to the PR branch in several steps, they are recorded to be recovered by qs recover
+	var j *journal.Journal
 	// If we are on dev branch than we need to create pr branch
 	if branchType == notesPkg.BranchTypeDev {
-		var response string
 		// Only add upstream if we have a parent repo and upstream doesn't exist
 		// In single remote mode (no parent repo), we don't need upstream
-		upstreamExists, err := HasRemote(wd, "upstream")
+		upstreamExists, err := HasRemote(rc, "upstream")
 		if err != nil {
 			return err
 		}
 
 		if len(parentRepoName) > 0 && !upstreamExists {
-			fmt.Print("Upstream not found.\nRepository " + parentRepoName + " will be added as upstream. Agree[y/n]?")
-			_, _ = fmt.Scanln(&response)
-			if response != pushYes {
+			agree, err := rc.Prompt().Confirm("Upstream not found.\nRepository " + parentRepoName + " will be added as upstream. Agree")
+			if err != nil {
+				return err
+			}
+			if !agree {
 				fmt.Print(msgOkSeeYou)
 				return nil
 			}
-			response = ""
-			if err := MakeUpstreamForBranch(wd, parentRepoName); err != nil {
+			if err := MakeUpstreamForBranch(rc, parentRepoName); err != nil {
 				return err
 			}
 			upstreamExists = true
 		}
 
 		// Check if there are any modified files in the current branch
-		if _, ok, err := ChangedFilesExist(wd); ok || err != nil {
+		if _, ok, err := ChangedFilesExist(rc); ok || err != nil {
 			if err != nil {
 				return err
 			}
 
-			return errors.New(errMsgModFiles)
+			return ErrUncommittedChanges
+		}
+
+		// failed checks and fetches leave no journal behind
+		if err := syncDevBranch(rc, currentBranchName, revCount, upstreamExists, mainBranch); err != nil {
+			return fmt.Errorf("failed to create PR branch: %w", err)
 		}
 
-		prBranchName, err := createPRBranch(wd, currentBranchName, issueDescription, notes, revCount, upstreamExists, mainBranch)
+		j, err = BeginJournal(rc, journal.CommandPR, refsHeads+currentBranchName, refsRemotesOrigin+currentBranchName)
+		if err != nil {
+			return err
+		}
+		err = j.SetData(prJournalData{
+			DevBranch: currentBranchName,
+			PRBranch:  PRBranchName(rc, currentBranchName),
+			Draft:     needDraft,
+		})
+		if err != nil {
+			return err
+		}
+
+		prBranchName, err := createPRBranch(rc, currentBranchName, issueDescription, notes, upstreamExists, mainBranch)
 		if err != nil {
 			return fmt.Errorf("failed to create PR branch: %w", err)
 		}
+		if err := j.Done(journal.StepPRBranchCreated); err != nil {
+			return err
+		}
 
-		// Remove dev branch after creating PR-branch
-		if err := RemoveBranch(wd, currentBranchName); err != nil {
+		// Remove dev branch after creating PR-branch, the step is left pending if it fails
+		if err := RemoveBranch(rc, currentBranchName); err != nil {
 			logger.Verbose(fmt.Errorf("failed to remove branch: %w", err))
+			_, _ = fmt.Fprintf(os.Stdout, "Dev branch %s is not removed, remove it manually\n", currentBranchName)
+		} else if err := j.Done(journal.StepDevBranchRemoved); err != nil {
+			return err
 		}
 
 		// Current branch now is pr branch
@@ -101,64 +122,67 @@ func Pr(wd string, needDraft bool) error {
 	}
 
 	// push notes and commits to origin
-	if err := pushPRBranch(wd, currentBranchName); err != nil {
+	if err := pushPRBranch(rc, currentBranchName); err != nil {
+		return err
+	}
+	if err := j.Done(journal.StepPushed); err != nil {
 		return err
 	}
 
 	// Check whether PR already exists
-	prInfo, _, _, err := DoesPrExist(wd, parentRepoName, currentBranchName, PRStateOpen)
+	prInfo, err := DoesPrExist(rc, parentRepoName, currentBranchName, PRStateOpen)
 	if err != nil {
 		return err
 	}
 	if prInfo != nil {
 		_, _ = fmt.Fprintln(os.Stdout, "pull request already exists for this branch")
+		rc.Result = &PRResult{
+			Number:        prInfo.Number,
+			URL:           prInfo.URL,
+			Title:         prInfo.Title,
+			Branch:        currentBranchName,
+			AlreadyExists: true,
+		}
 
-		return nil
+		return j.Finish()
 	}
 
-	notes, revCount, err = getNotesWithMainBranch(wd, currentBranchName, mainBranch)
-	if err != nil {
-		return err
+	// the PR branch is not created in dry-run mode, so the notes of the dev branch are used
+	if rc.DryRun == nil {
+		notes, revCount, err = getNotesWithMainBranch(rc, currentBranchName, mainBranch)
+		if err != nil {
+			return err
+		}
 	}
 
 	if revCount == 0 {
-		return errors.New("error: No commits found in pr branch")
+		return fmt.Errorf("%w in pr branch", ErrNoCommits)
 	}
 
 	// Create PR
-	stdout, stderr, err := createPR(
-		wd,
+	if err := createPR(
+		rc,
 		parentRepoName,
 		currentBranchName,
+		mainBranch,
 		issueDescription,
 		notes,
 		needDraft,
-	)
-	if err != nil {
-		logger.Verbose(stdout)
-		logger.Verbose(stderr)
-
+	); err != nil {
 		return fmt.Errorf("failed to create PR: %w", err)
 	}
 
-	return nil
+	return j.Finish()
 }
 
 // pushPRBranch pushes the PR branch to origin.
-func pushPRBranch(wd, prBranchName string) error {
+func pushPRBranch(rc *RepoContext, prBranchName string) error {
 	// Push notes to origin
-	err := utils.Retry(func() error {
-		_, stderr, err := new(exec.PipedExec).
-			Command(git, push, origin, utils.RefsNotes).
-			WorkingDir(wd).
-			RunToStrings()
+	err := utils.Retry(rc.Context(), func() error {
+		_, stderr, err := rc.run(git, push, origin, utils.RefsNotes)
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("failed to push notes to origin: %w", err)
 		}
 
@@ -171,18 +195,11 @@ func pushPRBranch(wd, prBranchName string) error {
 	utils.DelayIfTest()
 
 	// Push PR branch to origin
-	err = utils.Retry(func() error {
-		_, stderr, err := new(exec.PipedExec).
-			Command(git, push, "-u", origin, prBranchName).
-			WorkingDir(wd).
-			RunToStrings()
+	err = utils.Retry(rc.Context(), func() error {
+		_, stderr, err := rc.run(git, push, "-u", origin, prBranchName)
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("failed to push PR branch %s to origin: %w", prBranchName, err)
 		}
 
@@ -194,355 +211,193 @@ func pushPRBranch(wd, prBranchName string) error {
 	return err
 }
 
-// DoesPrExist checks if a pull request exists for the current branch.
+// DoesPrExist checks if a pull request in the given state exists for the branch of origin.
+// In single remote mode (no parent repo) pull requests are looked up in the origin repo itself.
 // Returns:
 // - PRInfo object if PR exists, nil otherwise
-// - stdout from the command execution
-// - stderr from the command execution
 // - error if any
-func DoesPrExist(wd, parentRepo, currentBranchName string, prState PRState) (*PRInfo, string, string, error) {
-	var (
-		prInfo   PRInfo
-		stdout   string
-		stderr   string
-		err      error
-		prExists bool
-	)
-
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command(
-				"gh",
-				"pr",
-				"list",
-				"--repo",
-				parentRepo,
-				"--head",
-				currentBranchName,
-				"--limit",
-				"1",
-				"--state",
-				string(prState),
-				"--json",
-				"url,title",
-			).
-			WorkingDir(wd).
-			RunToStrings()
-		if err != nil {
-			logger.Verbose(stderr)
-
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
-			return fmt.Errorf("failed to list PRs for branch %s: %w", currentBranchName, err)
-		}
-
-		if strings.Contains(stdout, "no pull requests match your search") {
-			return nil
-		}
-
-		// Otherwise, assume PR exists
-		if stdout == "" {
-			// safety check: gh may sometimes return nothing at all
-			return nil
-		}
-		var infos []PRInfo
-		if err := json.Unmarshal([]byte(stdout), &infos); err != nil {
-			return fmt.Errorf("failed to parse gh pr list output: %w", err)
-		}
-		if len(infos) > 0 {
-			prExists = true
-			prInfo.URL = strings.TrimSpace(infos[0].URL)
-			prInfo.Title = strings.TrimSpace(infos[0].Title)
-		}
-
-		return nil
-	})
+func DoesPrExist(rc *RepoContext, parentRepo, branchName string, prState PRState) (*PRInfo, error) {
+	repo, org, err := GetRepoAndOrgName(rc)
 	if err != nil {
-		return nil, stdout, stderr, err
+		return nil, err
 	}
 
-	if !prExists {
-		return nil, stdout, stderr, nil
+	targetRepo := parentRepo
+	if len(targetRepo) == 0 {
+		targetRepo = org + slash + repo
 	}
 
-	return &prInfo, stdout, stderr, nil
-}
+	var prInfo *PRInfo
+	err = utils.Retry(rc.Context(), func() error {
+		var findErr error
+		prInfo, findErr = rc.Hosting.FindPR(rc.Context(), targetRepo, org, branchName, prState)
 
-// createPRBranch creates a new branch for the pull request and checks out on it.
-// Returns:
-// - name of the PR branch
-// - error if any operation fails
-func createPRBranch(wd, devBranchName, issueDescription string, notes []string, revCount int, upstreamExists bool, mainBranchName string) (string, error) {
-	notesObj, err := notesPkg.ReadNotes(notes)
+		return findErr
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to read notes: %w", err)
+		return nil, err
 	}
-	// update branch type in notes object
-	notesObj.BranchType = notesPkg.BranchTypePr
 
-	description := DefaultCommitMessage
-	if len(issueDescription) > 0 {
-		description = issueDescription
-	}
-
-	// Step 2: Generate a name for the PR branch
-	// e.g. feature-dev -> feature-pr
-	prBranchName := strings.TrimSuffix(devBranchName, "-dev") + "-pr"
+	return prInfo, nil
+}
 
+// syncDevBranch fetches main branches and notes and fast-forwards the dev branch to origin/main and upstream/main.
+// It is run before the dev branch is converted to the PR branch, so its failures leave nothing to recover.
+func syncDevBranch(rc *RepoContext, devBranchName string, revCount int, upstreamExists bool, mainBranchName string) error {
 	upstreamRemote := "upstream"
 	if !upstreamExists {
 		upstreamRemote = "origin"
 	}
-	upstreamMain := upstreamRemote + "/" + mainBranchName
 
 	var (
 		stdout string
 		stderr string
+		err    error
 	)
 
-	// Step 3: Fetch the latest upstream main
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command("git", "fetch", upstreamRemote).
-			WorkingDir(wd).
-			RunToStrings()
+	// Fetch the latest upstream main
+	err = utils.Retry(rc.Context(), func() error {
+		stdout, stderr, err = rc.run("git", "fetch", upstreamRemote)
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("failed to fetch upstream: %w", err)
 		}
 
 		return nil
 	}) // Retry up to 3 times for fetching upstream
 	if err != nil {
-		return "", err
+		return err
 	}
 	logger.Verbose(stdout)
 
-	// Step 4: Fetch notes from the origin
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command(git, fetch, origin, "--force", utils.RefsNotes).
-			WorkingDir(wd).
-			RunToStrings()
+	// Fetch notes from the origin
+	err = utils.Retry(rc.Context(), func() error {
+		stdout, stderr, err = rc.run(git, fetch, origin, "--force", utils.RefsNotes)
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("failed to fetch notes from origin: %w", err)
 		}
 
 		return nil
 	}) // Retry up to 3 times for fetching notes
 	if err != nil {
-		return "", err
+		return err
 	}
 	logger.Verbose(stdout)
 
-	// Step 5: Checkout on the dev branch
+	// Checkout on the dev branch
 	// if we have only 1 revision in dev branch then it is just a commit for keeping notes
 	if revCount < 2 {
-		return "", errors.New("error: No commits found in dev branch")
+		return fmt.Errorf("%w in dev branch", ErrNoCommits)
 	}
 
-	_, stderr, err = new(exec.PipedExec).
-		Command("git", "checkout", devBranchName).
-		WorkingDir(wd).
-		RunToStrings()
+	_, stderr, err = rc.run("git", "checkout", devBranchName)
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return "", errors.New(stderr)
-		}
-
-		return "", fmt.Errorf("failed to checkout dev branch: %w", err)
+		return fmt.Errorf("failed to checkout dev branch: %w", err)
 	}
 
-	// Step 6: Merge from origin/main
-	stdout, stderr, err = new(exec.PipedExec).
-		Command("git", "merge", "--ff-only", "origin/"+mainBranchName).
-		WorkingDir(wd).
-		RunToStrings()
+	// Merge from origin/main
+	stdout, stderr, err = rc.run("git", "merge", "--ff-only", "origin/"+mainBranchName)
 	if err != nil {
 		logger.Verbose(stderr)
 
 		// Check if fast-forward failed
-		if checkAndShowFastForwardFailure(stderr, mainBranchName) {
-			return "", fmt.Errorf("cannot fast-forward merge origin/%s into dev branch", mainBranchName)
-		}
-
-		if len(stderr) > 0 {
-			return "", errors.New(stderr)
+		if checkAndShowFastForwardFailure(rc, err, mainBranchName) {
+			return fmt.Errorf("cannot fast-forward merge origin/%s into dev branch: %w", mainBranchName, err)
 		}
 
-		return "", fmt.Errorf("failed to merge origin/%s into dev branch: %w", mainBranchName, err)
+		return fmt.Errorf("failed to merge origin/%s into dev branch: %w", mainBranchName, err)
 	}
 	logger.Verbose(stdout)
 
-	// Step 7: Merge from upstream/main if upstream exists
+	// Merge from upstream/main if upstream exists
 	if upstreamExists {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command("git", "merge", "--ff-only", "upstream/"+mainBranchName).
-			WorkingDir(wd).
-			RunToStrings()
+		stdout, stderr, err = rc.run("git", "merge", "--ff-only", "upstream/"+mainBranchName)
 		if err != nil {
 			logger.Verbose(stderr)
 
 			// Check if fast-forward failed
-			if checkAndShowFastForwardFailure(stderr, mainBranchName) {
-				return "", fmt.Errorf("cannot fast-forward merge upstream/%s into dev branch", mainBranchName)
+			if checkAndShowFastForwardFailure(rc, err, mainBranchName) {
+				return fmt.Errorf("cannot fast-forward merge upstream/%s into dev branch: %w", mainBranchName, err)
 			}
 
-			if len(stderr) > 0 {
-				return "", errors.New(stderr)
-			}
-
-			return "", fmt.Errorf("failed to merge upstream/%s into dev branch: %w", mainBranchName, err)
+			return fmt.Errorf("failed to merge upstream/%s into dev branch: %w", mainBranchName, err)
 		}
 		logger.Verbose(stdout)
 	}
 
-	// Step 8: Create a new PR branch from upstream/main
-	_, stderr, err = new(exec.PipedExec).
-		Command("git", "checkout", "-b", prBranchName, upstreamMain).
-		WorkingDir(wd).
-		RunToStrings()
+	return nil
+}
+
+// createPRBranch creates a new branch for the pull request from the synced dev branch and checks out on it.
+// Returns:
+// - name of the PR branch
+// - error if any operation fails
+func createPRBranch(rc *RepoContext, devBranchName, issueDescription string, notes []string, upstreamExists bool, mainBranchName string) (string, error) {
+	notesObj, err := notesPkg.ReadNotes(notes)
 	if err != nil {
-		logger.Verbose(stderr)
+		return "", fmt.Errorf("failed to read notes: %w", err)
+	}
+	// update branch type in notes object
+	notesObj.BranchType = notesPkg.BranchTypePr
 
-		if len(stderr) > 0 {
-			return "", errors.New(stderr)
-		}
+	description := DefaultCommitMessage
+	if len(issueDescription) > 0 {
+		description = issueDescription
+	}
 
-		return "", fmt.Errorf("failed to create PR branch %s from %s: %w", prBranchName, upstreamMain, err)
+	// Generate a name for the PR branch
+	// e.g. feature-dev -> feature-pr
+	prBranchName := PRBranchName(rc, devBranchName)
+
+	upstreamRemote := "upstream"
+	if !upstreamExists {
+		upstreamRemote = "origin"
 	}
+	upstreamMain := upstreamRemote + "/" + mainBranchName
+
+	var (
+		stdout string
+		stderr string
+	)
 
-	// Step 9: Squash merge dev into a PR branch
-	stdout, stderr, err = new(exec.PipedExec).
-		Command("git", "merge", "--squash", devBranchName).
-		WorkingDir(wd).
-		RunToStrings()
+	// Create a new PR branch from upstream/main
+	_, stderr, err = rc.run("git", "checkout", "-b", prBranchName, upstreamMain)
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return "", errors.New(stderr)
-		}
+		return "", fmt.Errorf("failed to create PR branch %s from %s: %w", prBranchName, upstreamMain, err)
+	}
+
+	// Squash merge dev into a PR branch
+	stdout, stderr, err = rc.run("git", "merge", "--squash", devBranchName)
+	if err != nil {
+		logger.Verbose(stderr)
 
 		return "", fmt.Errorf("failed to squash merge dev branch %s into PR branch %s: %w", devBranchName, prBranchName, err)
 	}
 	logger.Verbose(stdout)
 
-	// Step 10: Commit the squashed changes
-	_, stderr, err = new(exec.PipedExec).
-		Command("git", "commit", "-m", description).
-		WorkingDir(wd).
-		RunToStrings()
+	// Commit the squashed changes
+	_, stderr, err = rc.run("git", "commit", "-m", description)
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return "", errors.New(stderr)
-		}
-
 		return "", fmt.Errorf("failed to commit squashed changes: %w", err)
 	}
 
-	// Step 11: Add an empty commit to create a commit object and link notes to it
-	if err := AddNotes(wd, updateNotesObjInNoteLines(notes, *notesObj)); err != nil {
+	// Add an empty commit to create a commit object and link notes to it
+	if err := AddNotes(rc, updateNotesObjInNoteLines(notes, *notesObj)); err != nil {
 		return "", err
 	}
 
 	return prBranchName, nil
 }
 
-// GetGitHubIssueDescription retrieves the title and body of a GitHub issue from its URL.
-func GetGitHubIssueDescription(issueURL string) (string, error) {
-	// Extract issue number from URL
-	parts := strings.Split(issueURL, "/")
-	if len(parts) < 1 {
-		return "", fmt.Errorf("invalid issue URL format: %s", issueURL)
-	}
-	issueNumber := parts[len(parts)-1]
-
-	// Extract owner and repo from URL
-	repoURL, err := convertIssuesURLToRepoURL(issueURL)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse repo from issue URL: %w", err)
-	}
-
-	urlParts := strings.Split(repoURL, "/")
-	if len(urlParts) < 5 { //nolint:revive
-		return "", fmt.Errorf("invalid GitHub URL format: %s", repoURL)
-	}
-	owner := urlParts[3] //nolint:revive
-	repo := urlParts[4]  //nolint:revive
-
-	// Use gh CLI to get issue details in JSON format with retry logic
-	var issueData struct {
-		Title string `json:"title"`
-		Body  string `json:"body"`
-	}
-
-	err = utils.Retry(func() error {
-		stdout, stderr, err := new(exec.PipedExec).
-			Command(
-				"gh",
-				"issue",
-				"view",
-				issueNumber,
-				"--repo",
-				fmt.Sprintf("%s/%s", owner, repo),
-				"--json",
-				"title,body",
-			).
-			RunToStrings()
-		if err != nil {
-			logger.Verbose(stderr)
-
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
-			return fmt.Errorf("failed to retrieve issue data for %s/%s#%s: %w", owner, repo, issueNumber, err)
-		}
-
-		// Parse JSON response
-		if err := json.Unmarshal([]byte(stdout), &issueData); err != nil {
-			return fmt.Errorf("failed to parse issue data: %w", err)
-		}
-
-		return nil
-	}) // Retry up to 3 times for issue retrieval
-
-	if err != nil {
-		return "", err
-	}
-
-	return issueData.Title, nil
-}
-
-func convertIssuesURLToRepoURL(issueURL string) (string, error) {
-	// Remove trailing issue number
-	issuesParts := strings.Split(issueURL, "/issues/")
-	if len(issuesParts) < 2 {
-		return "", fmt.Errorf("invalid GitHub issue URL format: %s", issueURL)
-	}
-
-	repoURL := issuesParts[0]
-	return repoURL, nil
-}
-
 // updateNotesObjInNoteLines updates notes by removing old notes and adding new ones
 func updateNotesObjInNoteLines(notes []string, notesObj notesPkg.Notes) []string {
 	newNotes := make([]string, 0, len(notes))
@@ -557,33 +412,30 @@ func updateNotesObjInNoteLines(notes []string, notesObj notesPkg.Notes) []string
 }
 
 func createPR(
-	wd,
+	rc *RepoContext,
 	parentRepoName,
 	prBranchName,
+	mainBranchName,
 	issueDescription string,
 	notes []string,
 	asDraft bool,
-) (stdout string, stderr string, err error) {
+) error {
 	if len(notes) == 0 {
-		return "", "", errors.New(ErrMsgPRNotesImpossible)
+		return errors.New(ErrMsgPRNotesImpossible)
 	}
 
 	var isCustomBranch bool
 	prTitle := issueDescription
 	if len(prTitle) == 0 {
 		isCustomBranch = true
-		fmt.Print("Enter pull request title: ")
-		reader := bufio.NewReader(os.Stdin)
-
-		// Read until newline (includes spaces)
-		prTitle, err = reader.ReadString(caretByte)
+		var err error
+		prTitle, err = rc.Prompt().Input("Enter pull request title")
 		if err != nil {
-			return "", "", err
+			return err
 		}
 
-		prTitle = strings.TrimSpace(prTitle)
-		if len(prTitle) < minPRTitleLength {
-			return "", "", errors.New("too short pull request title")
+		if len(prTitle) < rc.Settings().PR.MinTitleLength {
+			return errors.New("too short pull request title")
 		}
 	}
 
@@ -599,9 +451,9 @@ func createPR(
 	}
 	strBody := fmt.Sprintln(b)
 
-	repoName, forkAccount, err := GetRepoAndOrgName(wd)
+	repoName, forkAccount, err := GetRepoAndOrgName(rc)
 	if err != nil {
-		return "", "", err
+		return err
 	}
 
 	repo := parentRepoName
@@ -610,48 +462,56 @@ func createPR(
 		repo = forkAccount + slash + repoName
 	}
 
-	// Determine the head reference for the PR
-	// In fork mode: use "forkAccount:branchName" format
-	// In single remote mode: use just "branchName" format
-	headRef := prBranchName
-	if len(parentRepoName) > 0 {
-		// Fork mode: specify the fork account
-		headRef = forkAccount + ":" + prBranchName
-	}
-
-	args := []string{
-		"pr",
-		"create",
-		fmt.Sprintf(`--head=%s`, headRef),
-		fmt.Sprintf(`--repo=%s`, repo),
-		fmt.Sprintf(`--body=%s`, strings.TrimSpace(strBody)),
-		fmt.Sprintf(`--title=%s`, strings.TrimSpace(prTitle)),
-	}
-	if asDraft {
-		args = append(args, "--draft")
+	// The head of the PR is always the branch of origin:
+	// the fork in fork mode or the repo itself in single remote mode
+	if rc.Planned(fmt.Sprintf("create pull request %q from %s:%s to %s:%s", strings.TrimSpace(prTitle), forkAccount, prBranchName, repo, mainBranchName)) {
+		return nil
 	}
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command("gh", args...).
-			RunToStrings()
-
-		return err
+	var prInfo *PRInfo
+	attempt := 0
+	err = utils.Retry(rc.Context(), func() error {
+		attempt++
+		// the failed request could have created the pull request, it is not created twice
+		if attempt > 1 {
+			existing, findErr := rc.Hosting.FindPR(rc.Context(), repo, forkAccount, prBranchName, PRStateOpen)
+			if findErr != nil {
+				return findErr
+			}
+			if existing != nil {
+				prInfo = existing
+				return nil
+			}
+		}
+		var createErr error
+		prInfo, createErr = rc.Hosting.CreatePR(
+			rc.Context(),
+			repo,
+			forkAccount,
+			prBranchName,
+			mainBranchName,
+			strings.TrimSpace(prTitle),
+			strings.TrimSpace(strBody),
+			asDraft,
+		)
+
+		return createErr
 	})
 	if err != nil {
-		return stdout, stderr, err
+		return err
 	}
 
-	prInfo, stdout, stderr, err := DoesPrExist(wd, parentRepoName, prBranchName, PRStateOpen)
-	if err != nil {
-		return stdout, stderr, err
-	}
-	if prInfo == nil {
-		return stdout, stderr, errors.New("PR not created")
-	}
 	// print PR URL
 	if len(prInfo.URL) > 0 {
 		fmt.Println(prInfo.URL)
 	}
+	rc.Result = &PRResult{
+		Number: prInfo.Number,
+		URL:    prInfo.URL,
+		Title:  strings.TrimSpace(prTitle),
+		Branch: prBranchName,
+		Base:   mainBranchName,
+		Draft:  asDraft,
+	}
 
-	return stdout, stderr, err
+	return nil
 }
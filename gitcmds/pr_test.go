@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/journal"
+	notesPkg "github.com/untillpro/qs/internal/notes"
+)
+
+func TestPr_FailedChecksLeaveNoJournal(t *testing.T) {
+	notes, err := notesPkg.Serialize("", notesPkg.BranchTypeDev, "Fix the bug")
+	require.NoError(t, err)
+	tests := []struct {
+		name      string
+		log       string
+		fetchErr  error
+		wantNoRev bool
+	}{
+		{name: "no commits", log: "aaa\x00" + notes + "\n\x00", wantNoRev: true},
+		{name: "failed fetch", log: "bbb\x00\x00aaa\x00" + notes + "\n\x00", fetchErr: errors.New("exit status 128")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, fake := newFakeRepoContext(t)
+			rc.Hosting = &fakeParentHosting{}
+			fake.On("git rev-parse --absolute-git-dir").Return(filepath.Join(rc.Wd, ".git")+"\n", "", nil)
+			fake.On("git branch --show-current").Return("feature-dev\n", "", nil)
+			fake.On("git branch -r").Return("  origin/main\n  upstream/main\n", "", nil)
+			fake.On("git remote").Return("origin\nupstream\n", "", nil)
+			fake.On("git config --local remote.origin.url").Return("https://github.com/fork-account/qs.git\n", "", nil)
+			fake.On("git log -z --format=%H%x00%N main..feature-dev").Return(tt.log, "", nil)
+			fake.On("git fetch upstream").Return("", "fatal: unable to access", tt.fetchErr)
+
+			err := Pr(rc, false)
+			require.Error(t, err)
+			if tt.wantNoRev {
+				require.ErrorIs(t, err, ErrNoCommits)
+			}
+			require.False(t, fake.Ran("git checkout -b feature-pr"), "the dev branch is not converted")
+
+			j, err := LoadJournal(rc)
+			require.NoError(t, err)
+			require.Nil(t, j)
+		})
+	}
+}
+
+func TestPr_DevBranchNotRemoved(t *testing.T) {
+	notes, err := notesPkg.Serialize("", notesPkg.BranchTypeDev, "Fix the bug")
+	require.NoError(t, err)
+	rc, fake := newFakeRepoContext(t)
+	rc.Hosting = &fakeParentHosting{}
+	fake.On("git rev-parse --absolute-git-dir").Return(filepath.Join(rc.Wd, ".git")+"\n", "", nil)
+	fake.On("git branch --show-current").Return("feature-dev\n", "", nil)
+	fake.On("git branch -r").Return("  origin/main\n  upstream/main\n", "", nil)
+	fake.On("git remote").Return("origin\nupstream\n", "", nil)
+	fake.On("git config --local remote.origin.url").Return("https://github.com/fork-account/qs.git\n", "", nil)
+	fake.On("git log -z --format=%H%x00%N main..feature-dev").Return("bbb\x00\x00aaa\x00"+notes+"\n\x00", "", nil)
+	fake.On("git branch -D feature-dev").Return("", "error: branch not found", errors.New("exit status 1"))
+	fake.On("git push").Return("", "fatal: unable to access", errors.New("exit status 128"))
+
+	require.Error(t, Pr(rc, false))
+	require.True(t, fake.Ran("git branch -D feature-dev"))
+
+	j, err := LoadJournal(rc)
+	require.NoError(t, err)
+	require.NotNil(t, j)
+	require.True(t, j.IsDone(journal.StepPRBranchCreated))
+	require.False(t, j.IsDone(journal.StepDevBranchRemoved))
+}
+
+// lostResponseHosting creates the pull request but fails to return the response
+type lostResponseHosting struct {
+	hosting.HostingProvider
+	creates int
+	pr      *PRInfo
+}
+
+func (h *lostResponseHosting) CreatePR(_ context.Context, _, _, _, _, _, _ string, _ bool) (*PRInfo, error) {
+	h.creates++
+	h.pr = &PRInfo{Number: 7, URL: "https://github.com/untillpro/qs/pull/7"}
+
+	return nil, errors.New("connection reset by peer")
+}
+
+func (h *lostResponseHosting) FindPR(_ context.Context, _, _, _ string, _ PRState) (*PRInfo, error) {
+	return h.pr, nil
+}
+
+func TestCreatePR_NotCreatedTwice(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	t.Setenv("QS_MAX_RETRIES", "3")
+	t.Setenv("QS_RETRY_DELAY_MS", "1")
+	h := &lostResponseHosting{}
+	rc.Hosting = h
+	fake.On("git config --local remote.origin.url").Return("https://github.com/fork-account/qs.git\n", "", nil)
+
+	err := createPR(rc, "untillpro/qs", "feature-pr", "main", "Fix the bug", []string{"Fix the bug"}, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, h.creates)
+	require.Equal(t, &PRResult{
+		Number: 7,
+		URL:    "https://github.com/untillpro/qs/pull/7",
+		Title:  "Fix the bug",
+		Branch: "feature-pr",
+		Base:   "main",
+	}, rc.Result)
+}
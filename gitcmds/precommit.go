@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+// PreCommitCheck checks the total size and the number of new (untracked and added) files against the hook config.
+// Files matching hook.exclude globs are not counted.
+// If a limit is exceeded, the new files are reported sorted by size and ErrCommitTooLarge is returned.
+func PreCommitCheck(rc *RepoContext) error {
+	stdout, stderr, err := rc.run(git, "status", "--porcelain=v2", "-z", "-uall")
+	if err != nil {
+		logger.Verbose(stderr)
+
+		return fmt.Errorf("git status failed: %w", err)
+	}
+
+	files, totalSize, err := newFilesSize(rc, stdout)
+	if err != nil {
+		return err
+	}
+
+	hook := rc.Settings().Hook
+	tooLarge := totalSize > int64(hook.MaxTotalSize)
+	tooMany := len(files) > hook.MaxFiles
+	if !tooLarge && !tooMany {
+		return nil
+	}
+
+	if tooLarge {
+		fmt.Printf(" Attempt to commit too large files: Files size = %s bytes (maximum %s)\n",
+			formatSizeWithUnderscores(totalSize), formatSizeWithUnderscores(int64(hook.MaxTotalSize)))
+	}
+	if tooMany {
+		fmt.Printf(" Attempt to commit too much files: Files number = %d (maximum %d)\n", len(files), hook.MaxFiles)
+	}
+	reportFiles(files)
+
+	return ErrCommitTooLarge
+}
+
+// newFilesSize returns new files of the status output which are not excluded by hook config, sorted by size descending
+func newFilesSize(rc *RepoContext, statusOutput string) (files []fileInfo, totalSize int64, err error) {
+	exclude := rc.Settings().Hook.Exclude
+	for _, entry := range parseStatus(statusOutput) {
+		if entry.unmerged || entry.submodule || !isNewFile(entry.code) || isExcluded(entry.name, exclude) {
+			continue
+		}
+
+		// hooks are run in the root of the working tree, the paths of the status are relative to it.
+		// A file added and then deleted from the working tree is committed as staged, so its staged blob is measured
+		var size int64
+		if entry.inWorktree {
+			size, err = getFileSize(rc.Wd, entry.name)
+		} else {
+			size, err = getObjectSize(rc, ":"+entry.name, entry.name)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		files = append(files, fileInfo{name: entry.name, sizeIncrease: size})
+		totalSize += size
+	}
+
+	slices.SortStableFunc(files, func(a, b fileInfo) int {
+		return cmp.Compare(b.sizeIncrease, a.sizeIncrease)
+	})
+
+	return files, totalSize, nil
+}
+
+// isExcluded returns true if the path or its base name matches any of the globs
+func isExcluded(path string, globs []string) bool {
+	for _, glob := range globs {
+		if ok, _ := filepath.Match(glob, path); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(glob, filepath.Base(path)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reportFiles prints the largest files
+func reportFiles(files []fileInfo) {
+	fmt.Println(" New files by size:")
+	for i, file := range files {
+		if i == maxReportedFiles {
+			fmt.Printf("   ... and %d more\n", len(files)-maxReportedFiles)
+
+			break
+		}
+		fmt.Printf("   %15s  %s\n", formatSizeWithUnderscores(file.sizeIncrease), file.name)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/config"
+)
+
+func TestPreCommitCheck(t *testing.T) {
+	rc, fake := newFakeRepoContext(t)
+	files := map[string]int{"big.bin": 60000, "dir/medium.bin": 50000, "app.wasm": 90000, "changed.go": 70000}
+	for name, size := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(rc.Wd, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(rc.Wd, name), make([]byte, size), 0644))
+	}
+	status := "1 A. N... 000000 100644 100644 0000000000000000000000000000000000000000 e69de29bb2d1d6434b8b29ae775ad8c2e48c5391 dir/medium.bin\x00" +
+		"1 .M N... 100644 100644 100644 e69de29bb2d1d6434b8b29ae775ad8c2e48c5391 e69de29bb2d1d6434b8b29ae775ad8c2e48c5391 changed.go\x00" +
+		"? big.bin\x00? app.wasm\x00"
+	fake.On("git status --porcelain=v2 -z -uall").Return(status, "", nil)
+
+	t.Run("limits exceeded", func(t *testing.T) {
+		require.ErrorIs(t, PreCommitCheck(rc), ErrCommitTooLarge)
+	})
+
+	t.Run("files sorted by size, excluded and changed files skipped", func(t *testing.T) {
+		newFiles, totalSize, err := newFilesSize(rc, status)
+		require.NoError(t, err)
+		require.Equal(t, []fileInfo{{name: "big.bin", sizeIncrease: 60000}, {name: "dir/medium.bin", sizeIncrease: 50000}}, newFiles)
+		require.Equal(t, int64(110000), totalSize)
+	})
+
+	t.Run("added file deleted from the working tree", func(t *testing.T) {
+		const deletedStatus = "1 AD N... 000000 100644 000000 0000000000000000000000000000000000000000 e69de29bb2d1d6434b8b29ae775ad8c2e48c5391 gone.bin\x00"
+		fake.On("git cat-file -s :gone.bin").Return("70000\n", "", nil)
+
+		newFiles, totalSize, err := newFilesSize(rc, deletedStatus)
+		require.NoError(t, err)
+		require.Equal(t, []fileInfo{{name: "gone.bin", sizeIncrease: 70000}}, newFiles)
+		require.Equal(t, int64(70000), totalSize)
+	})
+
+	t.Run("within configured limits", func(t *testing.T) {
+		rc.Config = config.Default()
+		rc.Config.Hook.MaxTotalSize = 200000
+		require.NoError(t, PreCommitCheck(rc))
+
+		rc.Config.Hook.MaxFiles = 1
+		require.ErrorIs(t, PreCommitCheck(rc), ErrCommitTooLarge)
+	})
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitcmds
+
+import (
+	"context"
+
+	"github.com/untillpro/qs/internal/config"
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/prompt"
+	"github.com/untillpro/qs/internal/runner"
+)
+
+// RepoContext is a per-invocation context shared by gitcmds functions
+type RepoContext struct {
+	// Ctx is cancelled on interrupt, running git processes and retries are stopped then.
+	// context.Background() is used if nil
+	Ctx context.Context
+	// Wd is the working directory the command is executed in
+	Wd string
+	// Runner spawns git processes
+	Runner runner.CommandRunner
+	// Origin is the parsed URL of the origin remote, nil if there is no origin
+	Origin *RemoteURL
+	// Host is the web host of the origin remote, e.g. "github.com"
+	Host string
+	// Hosting is the API of the service hosting the repository
+	Hosting hosting.HostingProvider
+	// Config is the layered qs config, built-in defaults are used if nil
+	Config *config.Config
+	// Prompter asks the user questions, interactive stdin prompter is used if nil
+	Prompter prompt.Prompter
+	// Result is the structured result of the command, printed by --output json
+	Result any
+	// DryRun is set by --dry-run, it is the Runner as well
+	DryRun *runner.DryRun
+	// notes are the notes of commit ranges loaded by the invocation, they are dropped by any git command changing the repository
+	notes map[string]rangeNotes
+	// facts are memoized by the getters like GetMainBranch
+	facts repoFacts
+}
+
+// NewRepoContext returns a RepoContext which runs real git processes in wd and talks
+// to the API of the service hosting the origin remote (GitHub, GitHub Enterprise Server, GitLab or Gitea).
+// The config is loaded from the config files and the environment, the built-in defaults are used if it fails.
+func NewRepoContext(wd string) *RepoContext {
+	cfg, err := config.Load(wd, nil)
+	if err != nil {
+		cfg = config.Default()
+	}
+
+	return NewRepoContextWithConfig(wd, cfg)
+}
+
+// NewRepoContextWithConfig returns a RepoContext like NewRepoContext does but with the given config
+func NewRepoContextWithConfig(wd string, cfg *config.Config) *RepoContext {
+	r := runner.New()
+	originURL := originRemoteURL(wd)
+	host, webURL := defaultHost, protocolHTTPS+"://"+defaultHost
+	if originURL != nil && len(originURL.WebHost()) > 0 {
+		host, webURL = originURL.WebHost(), originURL.WebURL()
+	}
+
+	return &RepoContext{
+		Wd:      wd,
+		Runner:  r,
+		Origin:  originURL,
+		Host:    host,
+		Hosting: newHostingProvider(webURL, r, cfg.Hosting.Kind),
+		Config:  cfg,
+	}
+}
+
+// Context returns the context the commands are run with
+func (rc *RepoContext) Context() context.Context {
+	if rc.Ctx == nil {
+		return context.Background()
+	}
+
+	return rc.Ctx
+}
+
+// WithoutCancel returns a copy of the context which is not cancelled on interrupt, it is used to clean up after an interrupted command
+func (rc *RepoContext) WithoutCancel() *RepoContext {
+	cleanup := *rc
+	cleanup.Ctx = context.WithoutCancel(rc.Context())
+
+	return &cleanup
+}
+
+// Settings returns the config of the context or the built-in defaults if it is not loaded
+func (rc *RepoContext) Settings() *config.Config {
+	if rc.Config == nil {
+		rc.Config = config.Default()
+	}
+
+	return rc.Config
+}
+
+// Prompt returns the prompter of the context or the interactive one if it is not set
+func (rc *RepoContext) Prompt() prompt.Prompter {
+	if rc.Prompter == nil {
+		rc.Prompter = prompt.New(false, false)
+	}
+
+	return rc.Prompter
+}
+
+// Planned records the mutating operation and returns true in dry-run mode, the caller must skip the operation then
+func (rc *RepoContext) Planned(operation string) bool {
+	if rc.DryRun == nil {
+		return false
+	}
+	rc.DryRun.Plan(operation)
+
+	return true
+}
+
+// Git executes the git command in the working directory of the context the way gitcmds does:
+// errors are wrapped in GitError and the cached facts are invalidated by mutating commands
+func (rc *RepoContext) Git(args ...string) (stdout string, stderr string, err error) {
+	return rc.run(git, args...)
+}
+
+// run executes the command in the working directory of the context, errors of git are wrapped in GitError
+func (rc *RepoContext) run(name string, args ...string) (stdout string, stderr string, err error) {
+	if name == git && !runner.IsReadOnlyGit(args) {
+		rc.notes = nil
+		if changesRemotes(args) {
+			rc.facts.remote = remoteFacts{}
+		}
+	}
+	stdout, stderr, err = rc.Runner.Run(rc.Context(), rc.Wd, name, args...)
+	if err != nil && name == git {
+		err = NewGitError(args, stderr, err)
+	}
+
+	return stdout, stderr, err
+}
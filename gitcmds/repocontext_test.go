@@ -0,0 +1,191 @@
+package gitcmds
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/runner"
+)
+
+func newFakeRepoContext(t *testing.T) (*RepoContext, *runner.Fake) {
+	// no delays and retries against scripted outputs
+	t.Setenv("GH_TIMEOUT_MS", "0")
+	t.Setenv("QS_MAX_RETRIES", "0")
+
+	fake := runner.NewFake()
+
+	return &RepoContext{Wd: t.TempDir(), Runner: fake, Host: defaultHost}, fake
+}
+
+func TestGetMainBranch(t *testing.T) {
+	tests := []struct {
+		name       string
+		remotes    string
+		wantBranch string
+		wantErr    bool
+	}{
+		{
+			name:       "main",
+			remotes:    "  origin/HEAD -> origin/main\n  origin/main\n  origin/feature-dev\n",
+			wantBranch: "main",
+		},
+		{
+			name:       "master",
+			remotes:    "  origin/master\n  upstream/master\n",
+			wantBranch: "master",
+		},
+		{
+			name:    "both main and master",
+			remotes: "  origin/main\n  origin/master\n",
+			wantErr: true,
+		},
+		{
+			name:    "neither main nor master",
+			remotes: "  origin/maintenance\n  origin/develop\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rc, fake := newFakeRepoContext(t)
+			fake.On("git branch -r").Return(tt.remotes, "", nil)
+
+			mainBranch, err := GetMainBranch(rc)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantBranch, mainBranch)
+		})
+	}
+}
+
+func TestCreateDevBranch(t *testing.T) {
+	require := require.New(t)
+	rc, fake := newFakeRepoContext(t)
+
+	err := CreateDevBranch(rc, "42-fix bug-dev", "main", []string{`{"version":"1.0","branch_type":1}`})
+	require.NoError(err)
+
+	require.Equal([]string{
+		"git checkout main",
+		"git checkout -B 42-fix-bug-dev",
+		"git fetch origin --force refs/notes/*:refs/notes/*",
+		"git commit --allow-empty -m " + MsgCommitForNotes,
+		`git notes append -m {"version":"1.0","branch_type":1}`,
+		"git push origin refs/notes/*:refs/notes/*",
+		"git push -u origin 42-fix-bug-dev",
+	}, fake.Calls())
+}
+
+func TestCreateDevBranch_AmbiguousMainBranch(t *testing.T) {
+	require := require.New(t)
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git checkout main").Return("", "error: pathspec 'main' matched multiple (2) remote tracking branches", errors.New("exit status 128"))
+
+	err := CreateDevBranch(rc, "feature-dev", "main", nil)
+	require.NoError(err)
+	require.True(fake.Ran("git checkout --track origin/main"))
+	require.True(fake.Ran("git push -u origin feature-dev"))
+}
+
+func TestCreateDevBranch_PushFails(t *testing.T) {
+	require := require.New(t)
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git push -u origin").Return("", "fatal: unable to access", errors.New("exit status 128"))
+
+	err := CreateDevBranch(rc, "feature-dev", "main", nil)
+	require.ErrorContains(err, "failed to push branch to origin")
+}
+
+func TestRemoveBranch(t *testing.T) {
+	t.Run("remote branch is deleted", func(t *testing.T) {
+		require := require.New(t)
+		rc, fake := newFakeRepoContext(t)
+
+		require.NoError(RemoveBranch(rc, "feature-dev"))
+		require.Equal([]string{
+			"git rev-parse --verify --quiet refs/heads/feature-dev",
+			"git branch -D feature-dev",
+			"git push origin --delete feature-dev",
+		}, fake.Calls())
+	})
+
+	t.Run("branch is backed up with notes", func(t *testing.T) {
+		require := require.New(t)
+		rc, fake := newFakeRepoContext(t)
+		fake.On("git rev-parse --verify --quiet refs/heads/feature-dev").Return("a1\n", "", nil)
+		fake.On("git rev-parse --verify --quiet refs/notes/commits").Return("n1\n", "", nil)
+
+		require.NoError(RemoveBranch(rc, "feature-dev"))
+		calls := fake.Calls()
+		require.Len(calls, 6)
+		require.Regexp(`^git update-ref refs/qs/backup-notes/\d{8}-\d{6} n1$`, calls[2])
+		require.Regexp(`^git update-ref refs/qs/backup/\d{8}-\d{6}/feature-dev a1$`, calls[3])
+		require.Equal("git branch -D feature-dev", calls[4])
+	})
+
+	t.Run("remote branch does not exist", func(t *testing.T) {
+		rc, fake := newFakeRepoContext(t)
+		fake.On("git push origin --delete").Return("", "error: unable to delete 'feature-dev': remote ref does not exist", errors.New("exit status 1"))
+
+		require.NoError(t, RemoveBranch(rc, "feature-dev"))
+	})
+
+	t.Run("local branch cannot be deleted", func(t *testing.T) {
+		require := require.New(t)
+		rc, fake := newFakeRepoContext(t)
+		fake.On("git branch -D").Return("", "error: branch 'feature-dev' not found.", errors.New("exit status 1"))
+
+		var gitErr *GitError
+		require.ErrorAs(RemoveBranch(rc, "feature-dev"), &gitErr)
+		require.Equal("error: branch 'feature-dev' not found.", gitErr.Stderr)
+		require.False(fake.Ran("git push"))
+	})
+}
+
+func TestSyncMainBranch_RebaseConflict(t *testing.T) {
+	require := require.New(t)
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git pull --rebase upstream main").Return("", "error: could not apply 1a2b3c... change", errors.New("exit status 1"))
+
+	err := SyncMainBranch(rc, "main", true)
+	require.ErrorContains(err, "unable to rebase on upstream/main")
+	require.ErrorIs(err, ErrRebaseConflict)
+	require.True(fake.Ran("git rebase --abort"))
+	require.False(fake.Ran("git push"))
+}
+
+func TestSyncMainBranch_Interrupted(t *testing.T) {
+	require := require.New(t)
+	rc, fake := newFakeRepoContext(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rc.Ctx = ctx
+
+	err := SyncMainBranch(rc, "main", true)
+	require.ErrorIs(err, context.Canceled)
+	require.True(fake.Ran("git rebase --abort"), "rebase is aborted by the context which is not cancelled")
+	require.False(fake.Ran("git pull --rebase origin"))
+}
+
+func TestRepoContext_Git(t *testing.T) {
+	require := require.New(t)
+	rc, fake := newFakeRepoContext(t)
+	fake.On("git push").Return("", "fatal: unable to access", errors.New("exit status 128"))
+
+	rc.notes = map[string]rangeNotes{"main..dev": {}}
+	_, _, err := rc.Git("status")
+	require.NoError(err)
+	require.NotNil(rc.notes, "read-only commands keep the notes")
+
+	_, _, err = rc.Git("push", "--follow-tags", "origin")
+	var gitErr *GitError
+	require.ErrorAs(err, &gitErr)
+	require.Equal("fatal: unable to access", gitErr.Stderr)
+	require.Nil(rc.notes, "mutating commands drop the notes")
+}
@@ -6,49 +6,35 @@
 package gitcmds
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 
-	"github.com/untillpro/goutils/exec"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
 // Status shows git repo status
-func Status(wd string) error {
-	stdout, stderr, err := new(exec.PipedExec).
-		Command("git", "remote", "-v").
-		WorkingDir(wd).
-		Command("grep", fetch).
-		Command("sed", "s/(fetch)//").
-		RunToStrings()
+func Status(rc *RepoContext) error {
+	stdout, stderr, err := rc.run(git, "remote", "-v")
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return errors.New(stderr)
-		}
-
 		return err
 	}
 
-	// Print the colorized git status output
-	printLn(stdout)
+	// Print fetch URLs of remotes only
+	for _, line := range strings.Split(stdout, caret) {
+		if strings.Contains(line, fetch) {
+			fmt.Println(strings.Replace(line, "(fetch)", "", 1))
+		}
+	}
 
 	// Get git status output with colors for display
-	statusStdout, statusStderr, err := new(exec.PipedExec).
-		Command("git", "-c", "color.status=always", "status", "-s", "-b", "-uall").
-		WorkingDir(wd).
-		RunToStrings()
+	statusStdout, stderr, err := rc.run(git, "-c", "color.status=always", "status", "-s", "-b", "-uall")
 	if err != nil {
-		logger.Verbose(statusStderr)
-
-		if len(statusStderr) > 0 {
-			return errors.New(statusStderr)
-		}
+		logger.Verbose(stderr)
 
 		return fmt.Errorf("git status failed: %w", err)
 	}
@@ -56,22 +42,15 @@ func Status(wd string) error {
 	// Print the colorized git status output
 	printLn(statusStdout)
 
-	// Get clean output for parsing (without color codes)
-	cleanStatusStdout, stderr, err := new(exec.PipedExec).
-		Command("git", "status", "-s", "-b", "-uall").
-		WorkingDir(wd).
-		RunToStrings()
+	// Get porcelain output for parsing
+	cleanStatusStdout, stderr, err := rc.run(git, "status", "--porcelain=v2", "-z", "-uall")
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return errors.New(stderr)
-		}
-
 		return fmt.Errorf("failed to get clean status for parsing: %w", err)
 	}
 
-	files, err := getListOfChangedFiles(wd, cleanStatusStdout)
+	files, err := getListOfChangedFiles(rc, cleanStatusStdout)
 	if err != nil {
 		return fmt.Errorf("failed to get list of changed and new files: %w", err)
 	}
@@ -84,140 +63,249 @@ func Status(wd string) error {
 	return nil
 }
 
-// getListOfChangedFiles parses the git status output and returns lists of changed files
-func getListOfChangedFiles(wd, statusOutput string) ([]fileInfo, error) {
-	lines := strings.Split(strings.TrimSpace(statusOutput), "\n")
-	if len(lines) == 0 {
-		return []fileInfo{}, nil
-	}
+// GetStatus returns remotes, the current branch with ahead/behind counters, changed files with size deltas and the workflow info
+func GetStatus(rc *RepoContext) (*StatusInfo, error) {
+	stdout, stderr, err := rc.run(git, "remote", "-v")
+	if err != nil {
+		logger.Verbose(stderr)
 
-	files := make([]fileInfo, 0, len(lines))
+		return nil, err
+	}
+	info := &StatusInfo{Remotes: parseRemotes(stdout), Files: []FileStatus{}}
 
-	stdout, stderr, err := new(exec.PipedExec).
-		Command(git, "rev-parse", "--absolute-git-dir").
-		WorkingDir(wd).
-		RunToStrings()
+	stdout, stderr, err = rc.run(git, "status", "--porcelain=v2", "--branch", "-z", "-uall")
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return nil, errors.New(stderr)
-		}
+		return nil, fmt.Errorf("git status failed: %w", err)
+	}
+	parseBranchHeaders(stdout, info)
 
-		return nil, fmt.Errorf("failed to get absolute git dir: %w", err)
+	files, err := getListOfChangedFiles(rc, stdout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list of changed and new files: %w", err)
+	}
+	for _, file := range files {
+		fs := FileStatus{
+			Path:      file.name,
+			Status:    file.status,
+			SizeDelta: file.sizeDelta,
+			Conflict:  file.conflict,
+			Submodule: file.submodule,
+		}
+		if file.oldName != file.name {
+			fs.OldPath = file.oldName
+		}
+		info.Files = append(info.Files, fs)
+		info.TotalSizeDelta += file.sizeIncrease
 	}
-	gitDir := strings.TrimSpace(stdout)
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
+	branchName := info.Branch
+	if branchName == detachedHead {
+		branchName = ""
+	}
+	if info.Workflow, err = GetWorkflowInfo(rc, branchName); err != nil {
+		return nil, err
+	}
 
-		// Skip a branch information line (starts with ##)
-		if strings.HasPrefix(line, "##") {
-			continue
-		}
+	return info, nil
+}
 
-		// Parse git status line format: "XY filename"
+// parseRemotes returns remotes with fetch URLs from the git remote -v output
+func parseRemotes(output string) []RemoteInfo {
+	remotes := []RemoteInfo{}
+	for _, line := range strings.Split(output, caret) {
 		// nolint:revive
-		if len(line) < 4 {
-			continue // Line too short to contain status + space + filename
+		if fields := strings.Fields(line); len(fields) == 3 && fields[2] == "(fetch)" {
+			remotes = append(remotes, RemoteInfo{Name: fields[0], URL: fields[1]})
 		}
+	}
 
-		statusCode := strings.TrimSpace(line[:2])
-		name := strings.TrimSpace(line[2:])
-
-		// Unquote Git filenames (Git quotes filenames with spaces and special characters)
-		name = unquoteGitFilename(name)
+	return remotes
+}
 
-		oldName := name
-		if name == "" {
-			continue // Skip empty filenames
+// parseBranchHeaders fills the branch, upstream and ahead/behind counters from the "# branch." headers of the porcelain v2 git status output, e.g.
+// "# branch.head main", "# branch.upstream origin/main" and "# branch.ab +1 -2"
+func parseBranchHeaders(statusOutput string, info *StatusInfo) {
+	for _, record := range strings.Split(statusOutput, nul) {
+		header, ok := strings.CutPrefix(record, "# branch.")
+		if !ok {
+			continue
+		}
+		key, value, _ := strings.Cut(header, " ")
+		switch key {
+		case "head":
+			info.Branch = value
+			if value == "(detached)" {
+				info.Branch = detachedHead
+			}
+		case "upstream":
+			info.Upstream = value
+		case "ab":
+			ahead, behind, _ := strings.Cut(value, " ")
+			info.Ahead, _ = strconv.Atoi(strings.TrimPrefix(ahead, "+"))
+			info.Behind, _ = strconv.Atoi(strings.TrimPrefix(behind, "-"))
 		}
+	}
+}
 
-		if strings.Contains(name, " -> ") {
-			// Handle renamed files (format: "old -> new")
-			parts := strings.Split(name, " -> ")
-			if len(parts) == 2 {
-				name = strings.TrimSpace(unquoteGitFilename(parts[1]))    // Use the new filename
-				oldName = strings.TrimSpace(unquoteGitFilename(parts[0])) // Use the old filename
-			} else {
-				// TODO: Handle file name which -> as part of the file name
-				continue // Skip malformed renamed files
+// parseStatus parses the porcelain v2 git status output separated by NUL, headers are skipped.
+// Paths are relative to the root of the working tree.
+func parseStatus(statusOutput string) []statusEntry {
+	records := strings.Split(statusOutput, nul)
+	entries := make([]statusEntry, 0, len(records))
+	for i := 0; i < len(records); i++ {
+		kind, rest, _ := strings.Cut(records[i], " ")
+		var entry statusEntry
+		switch kind {
+		case "1":
+			// 1 XY sub mH mI mW hH hI path
+			fields, ok := splitStatusRecord(rest, ordinaryStatusFields)
+			if !ok {
+				continue
+			}
+			entry = trackedStatusEntry(fields[0], fields[1], fields[2], fields[4], fields[5], fields[7])
+			entry.oldName = entry.name
+		case "2":
+			// 2 XY sub mH mI mW hH hI Xscore path, the original path is the next record
+			fields, ok := splitStatusRecord(rest, renamedStatusFields)
+			if !ok || i+1 >= len(records) {
+				continue
+			}
+			i++
+			entry = trackedStatusEntry(fields[0], fields[1], fields[2], fields[4], fields[5], fields[8])
+			entry.oldName = records[i]
+			if strings.HasPrefix(fields[7], "C") {
+				// the original file is kept, so the copy is new
+				entry.headObject = ""
+			}
+		case "u":
+			// u XY sub m1 m2 m3 mW h1 h2 h3 path, stage 2 is the version of HEAD
+			fields, ok := splitStatusRecord(rest, unmergedStatusFields)
+			if !ok {
+				continue
 			}
+			entry = trackedStatusEntry(fields[0], fields[1], fields[3], fields[5], fields[7], fields[9])
+			entry.oldName = entry.name
+			entry.unmerged = true
+		case "?":
+			entry = statusEntry{code: "??", name: rest, oldName: rest, inWorktree: true}
+		case "!":
+			entry = statusEntry{code: "!!", name: rest, oldName: rest, inWorktree: true, ignored: true}
+		default:
+			continue
 		}
+		if entry.name == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
 
-		var (
-			err1 error
-			err2 error
-		)
+	return entries
+}
+
+// trackedStatusEntry returns the entry of the tracked file by the fields of the porcelain v2 record
+func trackedStatusEntry(xy, sub, headMode, worktreeMode, headObject, path string) statusEntry {
+	entry := statusEntry{
+		code:       porcelainCode(xy),
+		name:       path,
+		inWorktree: worktreeMode != missingFileMode,
+		submodule:  strings.HasPrefix(sub, "S"),
+	}
+	if headMode != missingFileMode {
+		entry.headObject = headObject
+	}
+
+	return entry
+}
+
+// splitStatusRecord returns the fields of the porcelain v2 record without the leading kind,
+// the last field is the path which may contain spaces
+func splitStatusRecord(record string, fieldsCount int) ([]string, bool) {
+	fields := strings.SplitN(record, " ", fieldsCount)
+
+	return fields, len(fields) == fieldsCount
+}
+
+// porcelainCode converts the XY status of the porcelain v2 output to the trimmed code of the short output, e.g. ".M" to "M"
+func porcelainCode(xy string) string {
+	return strings.TrimSpace(strings.ReplaceAll(xy, ".", " "))
+}
+
+// isNewFile returns true for untracked and added files
+func isNewFile(statusCode string) bool {
+	return statusCode == "??" || strings.HasPrefix(statusCode, "A")
+}
+
+// getListOfChangedFiles returns the files of the status output with size deltas.
+// The size in HEAD is compared with the size in the working tree, submodules and ignored files are not measured.
+func getListOfChangedFiles(rc *RepoContext, statusOutput string) ([]fileInfo, error) {
+	entries := parseStatus(statusOutput)
+	if len(entries) == 0 {
+		return []fileInfo{}, nil
+	}
+
+	files := make([]fileInfo, 0, len(entries))
+
+	stdout, stderr, err := rc.run(git, "rev-parse", "--show-toplevel")
+	if err != nil {
+		logger.Verbose(stderr)
+
+		return nil, fmt.Errorf("failed to get root of the working tree: %w", err)
+	}
+	rootDir := strings.TrimSpace(stdout)
 
+	for _, entry := range entries {
 		oldSize := int64(0)
 		newFileSize := int64(0)
-		switch statusCode {
-		case `A`, `AM`:
-			newFileSize, err1 = getFileSize(wd, name)
-		case `M`, `MM`, `RM`:
-			newFileSize, err1 = getFileSize(wd, name)
-			oldSize, err2 = getFileSizeFromHEAD(wd, gitDir, oldName)
-		case `D`, `MD`:
-			oldSize, err2 = getFileSizeFromHEAD(wd, gitDir, oldName)
-		case `R`:
-			newFileSize, err1 = getFileSize(wd, name)
-			oldSize = newFileSize
-		case `??`:
-			newFileSize, err2 = getFileSize(wd, name)
-		default:
-			return nil, fmt.Errorf("unknown file status %s for file %s", statusCode, name)
+		if !entry.submodule && !entry.ignored {
+			if entry.inWorktree {
+				if newFileSize, err = getFileSize(rootDir, entry.name); err != nil {
+					return nil, err
+				}
+			}
+			if len(entry.headObject) > 0 {
+				if oldSize, err = getObjectSize(rc, entry.headObject, entry.oldName); err != nil {
+					return nil, err
+				}
+			}
 		}
 
-		if err2 != nil {
-			return nil, err2
-		}
-		if err1 != nil {
-			return nil, err1
-		}
+		sizeDelta := newFileSize - oldSize
+		sizeIncrease := max(sizeDelta, 0) // Ensure size increase is not negative
 
-		sizeIncrease := newFileSize - oldSize
-		if sizeIncrease < 0 {
-			sizeIncrease = 0 // Ensure size increase is not negative
-		}
-
-		files = append(files, fileInfo{
-			name:         name,
+		file := fileInfo{
+			name:         entry.name,
 			sizeIncrease: sizeIncrease,
-		})
+			status:       entry.code,
+			oldName:      entry.oldName,
+			sizeDelta:    sizeDelta,
+			submodule:    entry.submodule,
+		}
+		if entry.unmerged {
+			file.conflict = conflictDescriptions[entry.code]
+		}
+		files = append(files, file)
 	}
 
 	return files, nil
 }
 
-func getFileSizeFromHEAD(wd, gitDir, fileName string) (int64, error) {
-	repoRootDir := filepath.Dir(gitDir)
-	// compute relative path
-	relativePath, err := filepath.Rel(repoRootDir, wd)
-	if err != nil {
-		return 0, fmt.Errorf("failed to compute relative path from repo root to working dir: %w", err)
-	}
-	// workaround for Windows paths
-	filePath := strings.ReplaceAll(filepath.Join(relativePath, fileName), "\\", "/")
-	stdout, stderr, err := new(exec.PipedExec).
-		Command(git, "cat-file", "-s", fmt.Sprintf("HEAD:%s", filePath)).
-		WorkingDir(wd).
-		RunToStrings()
+// getObjectSize returns the size of the git object of the file
+func getObjectSize(rc *RepoContext, object, fileName string) (int64, error) {
+	stdout, stderr, err := rc.run(git, "cat-file", "-s", object)
 	if err != nil {
-		logger.Error(stderr)
-
-		if len(stderr) > 0 {
-			return 0, errors.New(stderr)
-		}
+		logger.Verbose(stderr)
 
-		return 0, fmt.Errorf("failed to get file size from HEAD for %s: %w", fileName, err)
+		return 0, fmt.Errorf("failed to get size of %s stored in git: %w", fileName, err)
 	}
 
 	return strconv.ParseInt(strings.TrimSpace(stdout), decimalBase, bitSizeOfInt64)
 }
 
 func getFileSize(wd, fileName string) (int64, error) {
-	fileInfo, err := os.Stat(filepath.Join(wd, fileName))
+	// symbolic links are measured as git stores them, by the length of the target
+	fileInfo, err := os.Lstat(filepath.Join(wd, fileName))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return 0, fmt.Errorf("file %s does not exist: %w", fileName, err)
@@ -258,19 +346,28 @@ func displaySummary(files []fileInfo) error {
 		}
 	}
 
+	displayConflicts(files)
+
 	return nil
 }
 
-func unquoteGitFilename(filename string) string {
-	// Unquote Git filenames that are quoted with double quotes
-	if strings.HasPrefix(filename, `"`) && strings.HasSuffix(filename, `"`) {
-		filename = filename[1 : len(filename)-1]
-		// Unescape common escape sequences
-		filename = strings.ReplaceAll(filename, `\"`, `"`)
-		filename = strings.ReplaceAll(filename, `\\`, `\`)
+// displayConflicts lists unmerged files, nothing is printed if there are none
+func displayConflicts(files []fileInfo) {
+	headerPrinted := false
+	for _, file := range files {
+		if file.conflict == "" {
+			continue
+		}
+		if !headerPrinted {
+			fmt.Println()
+			fmt.Println("Conflicts:")
+			headerPrinted = true
+		}
+		fmt.Printf("  %-16s %s\n", file.conflict+":", file.name)
+	}
+	if headerPrinted {
+		fmt.Println("  Resolve the conflicts and run git add, or abort the merge or rebase")
 	}
-
-	return filename
 }
 
 // formatSizeWithUnderscores formats a number with underscores as thousand separators
@@ -1,11 +1,240 @@
 package gitcmds
 
+import (
+	"time"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
 type fileInfo struct {
 	name         string
 	sizeIncrease int64
+	// status, oldName, sizeDelta, conflict and submodule are filled by getListOfChangedFiles
+	status    string
+	oldName   string
+	sizeDelta int64
+	conflict  string
+	submodule bool
+}
+
+// statusEntry is a file record of the porcelain v2 git status output
+type statusEntry struct {
+	// code is the trimmed XY status, e.g. "M", "AM", "UU", "??" or "!!"
+	code string
+	name string
+	// oldName is the name before rename or copy, equals to name for other statuses
+	oldName string
+	// headObject is the object of the file in HEAD, empty if the file is new or copied
+	headObject string
+	// inWorktree is false if the file is deleted from the working tree
+	inWorktree bool
+	submodule  bool
+	unmerged   bool
+	ignored    bool
+}
+
+// rangeNotes are the notes of the commits of a range
+type rangeNotes struct {
+	// notes are the non-empty lines of the notes, the latest commit first
+	notes    []string
+	revCount int
+}
+
+// repoFacts are the facts of the repository memoized by the invocation, empty values are not loaded yet
+type repoFacts struct {
+	// remote facts are dropped by git commands changing remotes or remote branches
+	remote     remoteFacts
+	rootFolder string
+	userLogin  string
+}
+
+type remoteFacts struct {
+	mainBranch string
+	repo       string
+	org        string
+	// parentRepo is empty if the repo is not a fork, so parentRepoLoaded tells whether it is loaded
+	parentRepo       string
+	parentRepoLoaded bool
+	// remotes are the names of the remotes, nil if not loaded
+	remotes []string
+}
+
+// StatusInfo is the repository status reported by qs
+type StatusInfo struct {
+	// Branch is the current branch, "HEAD" if it is detached
+	Branch string `json:"branch"`
+	// Upstream is the remote tracking branch, empty if it is not set
+	Upstream string       `json:"upstream,omitempty"`
+	Ahead    int          `json:"ahead"`
+	Behind   int          `json:"behind"`
+	Remotes  []RemoteInfo `json:"remotes"`
+	Files    []FileStatus `json:"files"`
+	// TotalSizeDelta is the sum of positive size deltas of the files
+	TotalSizeDelta int64         `json:"totalSizeDelta"`
+	Workflow       *WorkflowInfo `json:"workflow,omitempty"`
+}
+
+// WorkflowInfo is the state of the current branch in the qs workflow
+type WorkflowInfo struct {
+	// Mode is "fork" if the upstream remote exists, "single remote" otherwise
+	Mode string `json:"mode"`
+	// BranchType is "dev", "pr" or "unknown" by notes or the branch name, empty for the main branch
+	BranchType string `json:"branchType,omitempty"`
+	IssueURL   string `json:"issueUrl,omitempty"`
+	// Comparisons are the counters of HEAD against origin/<branch>, origin/<main> and upstream/<main>, missing refs are skipped
+	Comparisons []RefComparison `json:"comparisons"`
+	// PR is the open pull request of the branch, nil if there is none or the hosting is not available
+	PR *OpenPR `json:"pr,omitempty"`
+	// Stashes are the stash entries, e.g. "stash@{0}: WIP on main: 1234567 message"
+	Stashes []string `json:"stashes"`
+	// InProgress is the operation to be continued or aborted: "rebase", "merge", "cherry-pick" or "revert"
+	InProgress string `json:"inProgress,omitempty"`
+}
+
+// RefComparison is the number of commits HEAD is ahead and behind of the ref
+type RefComparison struct {
+	Ref    string `json:"ref"`
+	Ahead  int    `json:"ahead"`
+	Behind int    `json:"behind"`
+}
+
+// OpenPR is the open pull request with its review and checks state
+type OpenPR struct {
+	Number int                 `json:"number"`
+	URL    string              `json:"url"`
+	Review hosting.ReviewState `json:"review,omitempty"`
+	Checks hosting.ChecksState `json:"checks,omitempty"`
+}
+
+// RemoteInfo is a remote with its fetch URL
+type RemoteInfo struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// FileStatus is a changed file of the working tree
+type FileStatus struct {
+	Path string `json:"path"`
+	// OldPath is the path before rename, empty for other statuses
+	OldPath string `json:"oldPath,omitempty"`
+	// Status is the short git status code, e.g. "M", "AM" or "??"
+	Status string `json:"status"`
+	// SizeDelta is the size change in bytes, negative if the file shrank, zero for submodules and ignored files
+	SizeDelta int64 `json:"sizeDelta"`
+	// Conflict describes the unmerged file, e.g. "both modified", empty if the file is merged
+	Conflict  string `json:"conflict,omitempty"`
+	Submodule bool   `json:"submodule,omitempty"`
+}
+
+// UploadResult is the result of qs u
+type UploadResult struct {
+	Branch string `json:"branch"`
+	// Committed is false if there is nothing to commit or the user declined to commit too large files
+	Committed     bool   `json:"committed"`
+	CommitMessage string `json:"commitMessage,omitempty"`
+	Pushed        bool   `json:"pushed"`
+}
+
+// DownloadResult is the result of qs d
+type DownloadResult struct {
+	Branch     string `json:"branch"`
+	MainBranch string `json:"mainBranch"`
+	// UpstreamPulled is true if the main branch is pulled from the upstream remote as well
+	UpstreamPulled bool `json:"upstreamPulled"`
+}
+
+// PRResult is the result of qs pr
+type PRResult struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+	Title  string `json:"title,omitempty"`
+	Branch string `json:"branch"`
+	Base   string `json:"base,omitempty"`
+	Draft  bool   `json:"draft"`
+	// AlreadyExists is true if the open pull request existed before
+	AlreadyExists bool `json:"alreadyExists"`
+}
+
+// HookFileState is the state of large-file-hook.sh
+type HookFileState int
+
+const (
+	HookFileMissing HookFileState = iota
+	HookFileUpToDate
+	// HookFileOutdated means the file is written by an older qs version
+	HookFileOutdated
+	// HookFileModified means the file is edited by the user
+	HookFileModified
+)
+
+// HooksStatus describes the qs hooks of a hooks directory
+type HooksStatus struct {
+	// Dir is the hooks directory, core.hooksPath is taken into account
+	Dir string `json:"dir"`
+	// HooksPath is the value of core.hooksPath, empty if it is not set
+	HooksPath string `json:"hooksPath,omitempty"`
+	// Managers are other hook managers detected, e.g. husky or pre-commit
+	Managers []string `json:"managers,omitempty"`
+	// Installed is true if the pre-commit hook runs the qs checks
+	Installed     bool          `json:"installed"`
+	LargeFileHook HookFileState `json:"largeFileHook"`
+}
+
+type (
+	PRInfo  = hosting.PRInfo
+	PRState = hosting.PRState
+)
+
+// RemoteURL is a parsed git remote URL
+type RemoteURL struct {
+	// Protocol is one of "https", "http", "ssh", "git", "file"
+	Protocol string
+	// SCPLike is true for scp-like ssh URLs, e.g. git@github.com:org/repo.git
+	SCPLike bool
+	// User is the user of the URL, e.g. "git" for ssh URLs
+	User string
+	// Host is the host name without port, empty for file URLs
+	Host string
+	Port string
+	// Path is the repository path without .git suffix, e.g. "org/repo" or "group/sub/repo".
+	// Path of file URLs is absolute.
+	Path string
+	// Account is the owner of the repository, e.g. "org" or "group/sub".
+	// For http(s) URLs with user info it is the user name.
+	Account string
+	Repo    string
+	// Token is the password of http(s) URLs with user info
+	Token string
+	// GitSuffix is true if the URL ends with .git
+	GitSuffix bool
+}
+
+// prJournalData is the state of qs pr kept in the journal
+type prJournalData struct {
+	DevBranch string `json:"devBranch"`
+	PRBranch  string `json:"prBranch"`
+	Draft     bool   `json:"draft"`
+}
+
+// Backup is a branch saved by qs before it is deleted or reset
+type Backup struct {
+	// ID is <timestamp>/<branch>
+	ID        string    `json:"id"`
+	Branch    string    `json:"branch"`
+	CreatedAt time.Time `json:"createdAt"`
+	SHA       string    `json:"sha"`
+	Subject   string    `json:"subject"`
+	// NotesSHA is the commit of the notes snapshot, empty if there were no notes
+	NotesSHA string `json:"notesSha,omitempty"`
 }
 
-type PRInfo struct {
-	Title string `json:"title"`
-	URL   string `json:"url"`
+// GitError is a failed git command.
+// errors.Is matches the error returned by the runner and the sentinel the stderr is classified as, e.g. ErrDiverged.
+type GitError struct {
+	Args []string
+	// ExitCode is the exit code of git, -1 if git did not exit
+	ExitCode int
+	Stderr   string
+	Err      error
+	kind     error
 }
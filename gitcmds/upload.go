@@ -11,92 +11,64 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/untillpro/goutils/exec"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
 // Upload uploads sources to git repo
-func Upload(cmd *cobra.Command, wd, currentBranch string, needToCommit bool) error {
+func Upload(cmd *cobra.Command, rc *RepoContext, currentBranch string, needToCommit bool) error {
+	result := &UploadResult{Branch: currentBranch}
+	rc.Result = result
 	if needToCommit {
 		commitMessage := cmd.Context().Value(utils.CtxKeyCommitMessage).(string)
 
-		stdout, stderr, err := new(exec.PipedExec).
-			Command(git, "add", ".").
-			WorkingDir(wd).
-			RunToStrings()
+		stdout, stderr, err := rc.run(git, "add", ".")
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("git add failed: %w", err)
 		}
 		logger.Verbose(stdout)
 
 		params := []string{"commit", "-a", mimm, commitMessage}
 
-		_, stderr, err = new(exec.PipedExec).
-			Command(git, params...).
-			WorkingDir(wd).
-			RunToStrings()
-		if strings.Contains(stderr, MsgPreCommitError) {
-			var response string
+		_, stderr, err = rc.run(git, params...)
+		if errors.Is(err, ErrCommitTooLarge) {
 			fmt.Println("")
 			printLn(strings.TrimSpace(stderr))
-			fmt.Print("Do you want to commit anyway(y/n)?")
-			_, _ = fmt.Scanln(&response)
-
-			if response != "y" {
+			commitAnyway, err := rc.Prompt().Confirm("Do you want to commit anyway")
+			if err != nil {
+				return err
+			}
+			if !commitAnyway {
 				return nil
 			}
 
 			params = append(params, "-n")
-			_, stderr, err = new(exec.PipedExec).
-				Command(git, params...).
-				WorkingDir(wd).
-				RunToStrings()
+			_, stderr, err = rc.run(git, params...)
 		}
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("git commit failed: %w", err)
 		}
+		result.Committed = true
+		result.CommitMessage = commitMessage
 	}
 
 	// make pull before push
-	stdout, stderr, err := new(exec.PipedExec).
-		Command(git, pull).
-		WorkingDir(wd).
-		RunToStrings()
+	stdout, stderr, err := rc.run(git, pull)
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return errors.New(stderr)
-		}
-
 		return fmt.Errorf("error pulling before push: %w", err)
 	}
 
-	err = utils.Retry(func() error {
-		stdout, stderr, err = new(exec.PipedExec).
-			Command(git, push, origin, utils.RefsNotes).
-			WorkingDir(wd).
-			RunToStrings()
+	err = utils.Retry(rc.Context(), func() error {
+		stdout, stderr, err = rc.run(git, push, origin, utils.RefsNotes)
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("git push notes failed: %w", err)
 		}
 
@@ -108,7 +80,7 @@ func Upload(cmd *cobra.Command, wd, currentBranch string, needToCommit bool) err
 
 	utils.DelayIfTest()
 
-	hasUpstream, err := hasUpstreamBranch(wd, currentBranch)
+	hasUpstream, err := hasUpstreamBranch(rc, currentBranch)
 	if err != nil {
 		return fmt.Errorf("failed to check upstream branch: %w", err)
 	}
@@ -118,19 +90,12 @@ func Upload(cmd *cobra.Command, wd, currentBranch string, needToCommit bool) err
 		pushArgs = []string{push, "-u", origin, currentBranch}
 	}
 
-	err = utils.Retry(func() error {
+	err = utils.Retry(rc.Context(), func() error {
 		var pushErr error
-		stdout, stderr, pushErr = new(exec.PipedExec).
-			Command(git, pushArgs...).
-			WorkingDir(wd).
-			RunToStrings()
+		stdout, stderr, pushErr = rc.run(git, pushArgs...)
 		if pushErr != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("git push failed: %w", pushErr)
 		}
 
@@ -140,16 +105,14 @@ func Upload(cmd *cobra.Command, wd, currentBranch string, needToCommit bool) err
 		return err
 	}
 	logger.Verbose(stdout)
+	result.Pushed = true
 
 	return nil
 }
 
 // hasUpstreamBranch checks if the current branch has an upstream tracking branch configured
-func hasUpstreamBranch(wd string, branchName string) (bool, error) {
-	stdout, _, err := new(exec.PipedExec).
-		Command(git, "config", "--get", fmt.Sprintf("branch.%s.remote", branchName)).
-		WorkingDir(wd).
-		RunToStrings()
+func hasUpstreamBranch(rc *RepoContext, branchName string) (bool, error) {
+	stdout, _, err := rc.run(git, "config", "--get", fmt.Sprintf("branch.%s.remote", branchName))
 
 	if err != nil {
 		// If the config doesn't exist, git config returns exit code 1
@@ -13,6 +13,7 @@ require (
 	github.com/untillpro/goutils v0.0.0-20231201170327-3c33c6010100
 	github.com/voedger/voedger v1.202405300917.1
 	golang.org/x/mod v0.33.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -42,5 +43,4 @@ require (
 	golang.org/x/net v0.50.0 // indirect
 	golang.org/x/sys v0.41.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -9,10 +9,18 @@ import (
 	"os/signal"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/untillpro/qs/gitcmds"
 	"github.com/untillpro/qs/internal/commands"
+	"github.com/untillpro/qs/internal/config"
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/jira"
+	"github.com/untillpro/qs/internal/output"
+	"github.com/untillpro/qs/internal/prompt"
+	"github.com/untillpro/qs/internal/runner"
+	"github.com/untillpro/qs/internal/tracker/youtrack"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
@@ -23,10 +31,10 @@ func updateCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
 		Use:   commands.CommandNameU,
 		Short: "Upload sources to repo",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := gitcmds.Status(params.Dir); err != nil {
+			if err := gitcmds.Status(params.Repo); err != nil {
 				return err
 			}
-			return commands.U(cmd, commintMessage, params.Dir)
+			return commands.U(cmd, commintMessage, params.Repo)
 		},
 	}
 	uploadCmd.Flags().StringVarP(&commintMessage, "message", "m", "", "Use the given string as the commit message")
@@ -39,7 +47,7 @@ func downloadCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
 		Use:   commands.CommandNameD,
 		Short: "Download sources from repo",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return gitcmds.Download(params.Dir)
+			return gitcmds.Download(params.Repo)
 		},
 	}
 
@@ -51,7 +59,7 @@ func releaseCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
 		Use:   commands.CommandNameR,
 		Short: "Create a release",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return commands.Release(params.Dir)
+			return commands.Release(params.Repo)
 		},
 	}
 
@@ -63,7 +71,7 @@ func guiCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
 		Use:   commands.CommandNameG,
 		Short: "Show GUI",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return gitcmds.Gui(params.Dir)
+			return gitcmds.Gui(params.Repo)
 		},
 	}
 
@@ -81,7 +89,7 @@ func prCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
 				needDraft = true
 			}
 
-			return gitcmds.Pr(params.Dir, needDraft)
+			return gitcmds.Pr(params.Repo, needDraft)
 		},
 	}
 	cmd.Flags().BoolP("draft", "d", false, "Create draft of pull request")
@@ -89,12 +97,12 @@ func prCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
 	return cmd
 }
 
-func versionCmd(_ context.Context) *cobra.Command {
+func versionCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   commands.CommandNameVersion,
 		Short: "Print qs version",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return commands.Version()
+			return commands.Version(params.Repo)
 		},
 	}
 
@@ -120,7 +128,7 @@ func devCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
 		Use:   commands.CommandNameDev,
 		Short: "Create developer branch",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return commands.Dev(cmd, params.Dir, doDelete, ignoreHook, args)
+			return commands.Dev(cmd, params.Repo, doDelete, ignoreHook, args)
 		},
 	}
 
@@ -130,22 +138,237 @@ func devCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
 	return cmd
 }
 
+func configCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   commands.CommandNameConfig,
+		Short: "Get, set or list qs settings",
+	}
+
+	getCmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of the setting",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.ConfigGet(params.Repo, args[0])
+		},
+	}
+
+	global := false
+	setCmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Write the setting to the repository " + config.RepoFileName + " or to the user config",
+		Args:  cobra.ExactArgs(2), //nolint:revive
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.ConfigSet(params.Repo, args[0], args[1], global)
+		},
+	}
+	setCmd.Flags().BoolVarP(&global, "global", "g", false, "Write to the user config file instead of the repository one")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print all effective settings",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			commands.ConfigList(params.Repo)
+
+			return nil
+		},
+	}
+
+	cmd.AddCommand(getCmd, setCmd, listCmd)
+
+	return cmd
+}
+
+func hookCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:    commands.CommandNameHook,
+		Short:  "Run git hook checks",
+		Hidden: true,
+	}
+
+	preCommitCmd := &cobra.Command{
+		Use:   "pre-commit",
+		Short: "Check size and number of new files to be committed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.HookPreCommit(params.Repo)
+		},
+	}
+
+	cmd.AddCommand(preCommitCmd)
+
+	return cmd
+}
+
+func hooksCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   commands.CommandNameHooks,
+		Short: "Manage git hooks with qs checks",
+	}
+
+	global := false
+	force := false
+	installCmd := &cobra.Command{
+		Use:   "install",
+		Short: "Add qs checks to the pre-commit hook keeping existing hooks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.HooksInstall(params.Repo, global, force)
+		},
+	}
+	installCmd.Flags().BoolVar(&force, "force", false, "Overwrite "+gitcmds.LargeFileHookFilename+" edited by the user")
+
+	uninstallCmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove qs checks from the pre-commit hook",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.HooksUninstall(params.Repo, global)
+		},
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Print the state of qs hooks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.HooksStatus(params.Repo, global)
+		},
+	}
+
+	// --local is the default, it is declared to make the scope explicit
+	cmd.PersistentFlags().BoolVarP(&global, "global", "g", false, "Use the global hooks directory (core.hooksPath)")
+	cmd.PersistentFlags().Bool("local", true, "Use the hooks directory of the repository")
+	cmd.MarkFlagsMutuallyExclusive("global", "local")
+	cmd.AddCommand(installCmd, uninstallCmd, statusCmd)
+
+	return cmd
+}
+
+func recoverCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
+	resume := false
+	rollback := false
+	discard := false
+	var cmd = &cobra.Command{
+		Use:   commands.CommandNameRecover,
+		Short: "Resume or roll back interrupted qs dev, pr, fork or r",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			action := commands.RecoverActionNone
+			switch {
+			case resume:
+				action = commands.RecoverActionResume
+			case rollback:
+				action = commands.RecoverActionRollback
+			case discard:
+				action = commands.RecoverActionDiscard
+			}
+
+			return commands.Recover(params.Repo, action)
+		},
+	}
+	cmd.Flags().BoolVar(&resume, "resume", false, "Complete the remaining steps of the interrupted command")
+	cmd.Flags().BoolVar(&rollback, "rollback", false, "Undo the completed steps of the interrupted command")
+	cmd.Flags().BoolVar(&discard, "discard", false, "Forget the interrupted command keeping the repository as is")
+	cmd.MarkFlagsMutuallyExclusive("resume", "rollback", "discard")
+
+	return cmd
+}
+
+func backupsCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   commands.CommandNameBackups,
+		Short: "List, restore or prune branches backed up before qs deleted or reset them",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print the backups, the latest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.BackupsList(params.Repo)
+		},
+	}
+
+	force := false
+	restoreCmd := &cobra.Command{
+		Use:   "restore <id|timestamp|branch>",
+		Short: "Bring back the branch and its notes from the backup",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.BackupsRestore(params.Repo, args[0], force)
+		},
+	}
+	restoreCmd.Flags().BoolVar(&force, "force", false, "Reset the existing branch to the backup, the branch is backed up before")
+
+	keepDays := commands.DefaultBackupKeepDays
+	pruneCmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Delete old backups",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.BackupsPrune(params.Repo, keepDays)
+		},
+	}
+	pruneCmd.Flags().IntVar(&keepDays, "keep-days", commands.DefaultBackupKeepDays, "Keep backups created during the given number of days, 0 deletes all")
+
+	cmd.AddCommand(listCmd, restoreCmd, pruneCmd)
+
+	return cmd
+}
+
+func undoCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   commands.CommandNameUndo,
+		Short: "Bring back the branches deleted or reset by the last qs command",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return commands.Undo(params.Repo)
+		},
+	}
+
+	return cmd
+}
+
 func forkCmd(_ context.Context, params *qsGlobalParams) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   commands.CommandNameFork,
 		Short: "Fork original repo",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return commands.Fork(params.Dir)
+			return commands.Fork(params.Repo)
 		},
 	}
 
 	return cmd
 }
 
-// checkRequiredBashCommands checks if all required bash commands are available
-func checkRequiredBashCommands() error {
+// skipPrerequisites returns true if the command or its parent command does not need prerequisite checks
+func skipPrerequisites(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if cmdsSkipPrerequisites[c.Name()] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyConfig applies the config to the packages configured globally
+func applyConfig(cfg *config.Config) {
+	utils.SetRetryDefaults(
+		cfg.Retry.MaxRetries,
+		time.Duration(cfg.Retry.DelayMs)*time.Millisecond,
+		time.Duration(cfg.Retry.MaxDelayMs)*time.Millisecond,
+	)
+	jira.SetDefaultSiteURL(cfg.Jira.URL)
+	youtrack.SetSiteURL(cfg.YouTrack.URL)
+}
+
+// checkRequiredCommands checks if all required commands are available
+func checkRequiredCommands() error {
 	missing := []string{}
-	for _, cmd := range requiredBashCommands {
+	for _, cmd := range requiredCommands {
 		_, err := exec.LookPath(cmd)
 		if err != nil {
 			missing = append(missing, cmd)
@@ -179,14 +402,50 @@ func ExecRootCmd(ctx context.Context, args []string) (context.Context, error) {
 		forkCmd(ctx, params),
 		devCmd(ctx, params),
 		prCmd(ctx, params),
+		configCmd(ctx, params),
+		hookCmd(ctx, params),
+		hooksCmd(ctx, params),
+		recoverCmd(ctx, params),
+		backupsCmd(ctx, params),
+		undoCmd(ctx, params),
 		upgradeCmd(ctx),
-		versionCmd(ctx),
+		versionCmd(ctx, params),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return ExecCommandAndCatchInterrupt(rootCmd)
+	cmdCtx, err := ExecCommandAndCatchInterrupt(rootCmd)
+	if err != nil && params.Repo != nil {
+		if hint := commands.InterruptedHint(params.Repo, err); len(hint) > 0 {
+			_, _ = fmt.Fprintln(os.Stderr, hint)
+		}
+	}
+	if params.JSONStdout != nil {
+		err = writeJSONOutput(params, err)
+	}
+
+	return cmdCtx, err
+}
+
+// writeJSONOutput restores stdout and prints the result of the command as a single JSON document
+func writeJSONOutput(params *qsGlobalParams, cmdErr error) error {
+	os.Stdout = params.JSONStdout
+	doc := output.Document{Command: params.Command}
+	if params.Repo != nil {
+		doc.Result = params.Repo.Result
+		if params.Repo.DryRun != nil {
+			doc.DryRun = params.Repo.DryRun.Planned()
+		}
+	}
+	if cmdErr != nil {
+		doc.Error = cmdErr.Error()
+	}
+	if err := output.Write(os.Stdout, doc); err != nil {
+		return errors.Join(cmdErr, err)
+	}
+
+	return cmdErr
 }
 
 func initChangeDirFlags(cmds []*cobra.Command, params *qsGlobalParams) error {
@@ -198,7 +457,11 @@ func initChangeDirFlags(cmds []*cobra.Command, params *qsGlobalParams) error {
 		if cmd.Name() == "version" {
 			continue
 		}
-		cmd.Flags().StringVarP(&params.Dir, "change-dir", "C", wd, "change to dir before running the command. Any files named on the command line are interpreted after changing directories")
+		flags := cmd.Flags()
+		if cmd.HasSubCommands() {
+			flags = cmd.PersistentFlags()
+		}
+		flags.StringVarP(&params.Dir, "change-dir", "C", wd, "change to dir before running the command. Any files named on the command line are interpreted after changing directories")
 	}
 	return nil
 }
@@ -227,6 +490,7 @@ func goAndCatchInterrupt(cmd *cobra.Command, f func(ctx context.Context) (*cobra
 
 	ctxWithCancel, cancel := context.WithCancel(cmd.Context())
 	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
 
 	var err error
 	wg := sync.WaitGroup{}
@@ -241,12 +505,15 @@ func goAndCatchInterrupt(cmd *cobra.Command, f func(ctx context.Context) (*cobra
 	select {
 	case sig := <-signals:
 		logger.Info("signal received:", sig)
+		// the command is cancelled and cleans up, the next interrupt terminates qs immediately
+		signal.Stop(signals)
 		cancel()
 	case <-ctxWithCancel.Done():
 	}
 	wg.Wait()
 
-	return cmdExecuted.Context(), err
+	// values set by the command are kept, the cancellation is not
+	return context.WithoutCancel(cmdExecuted.Context()), err
 }
 
 func PrepareRootCmd(ctx context.Context, use string, short string, args []string, version string, params *qsGlobalParams, cmds ...*cobra.Command) (*cobra.Command, error) {
@@ -254,6 +521,17 @@ func PrepareRootCmd(ctx context.Context, use string, short string, args []string
 		Use:   use,
 		Short: short,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			format, err := output.ParseFormat(params.Output)
+			if err != nil {
+				return err
+			}
+			params.Command = cmd.CommandPath()
+			if format == output.FormatJSON {
+				// human-readable output goes to stderr, stdout is left for the JSON document
+				params.JSONStdout = os.Stdout
+				os.Stdout = os.Stderr
+			}
+
 			// Set log level first - handle all log level options
 			if ok, _ := cmd.Flags().GetBool("trace"); ok {
 				logger.SetLogLevel(logger.LogLevelTrace)
@@ -266,58 +544,106 @@ func PrepareRootCmd(ctx context.Context, use string, short string, args []string
 				logger.SetLogLevel(logger.LogLevelInfo)
 			}
 
+			cfg, err := config.Load(params.Dir, params.ConfigOverrides)
+			if err != nil {
+				return err
+			}
+			applyConfig(cfg)
+			params.Config = cfg
+			params.Repo = gitcmds.NewRepoContextWithConfig(params.Dir, cfg)
+			params.Repo.Ctx = cmd.Context()
+			params.Repo.Prompter = prompt.New(params.AssumeYes, params.NoInput)
+			if params.DryRun {
+				dryRun := runner.NewDryRun(params.Repo.Runner, os.Stdout)
+				params.Repo.Runner = dryRun
+				params.Repo.DryRun = dryRun
+			}
+
 			// Skip checks for commands that don't need them
-			if cmdsSkipPrerequisites[cmd.Name()] {
+			if skipPrerequisites(cmd) {
 				return nil
 			}
 
-			// Check required bash commands
-			if err := checkRequiredBashCommands(); err != nil {
+			// Check required commands
+			if err := checkRequiredCommands(); err != nil {
 				return err
 			}
 
 			// Check QS version (unless skipped)
 			skipQsVerCheck, _ := strconv.ParseBool(os.Getenv(commands.EnvSkipQsVersionCheck))
-			if !skipQsVerCheck && !commands.CheckQsVer() {
+			if !skipQsVerCheck && !commands.CheckQsVer(params.Repo.Prompter) {
 				fmt.Println("Ok, see you")
 				os.Exit(1)
 			}
 
-			// Check GitHub CLI (for commands that need it)
-			if cmdsNeedGH[cmd.Name()] {
-				if err := utils.CheckGH(); err != nil {
-					return err
+			// Check hosting authentication (for commands that need it)
+			if cmdsNeedHosting[cmd.Name()] {
+				if err := gitcmds.VerifyHostingAuth(params.Repo); err != nil {
+					return fmt.Errorf("%s %w: %w", params.Repo.Host, hosting.ErrAuthFailed, err)
 				}
 			}
 
 			if cmd.Name() != commands.CommandNameUpgrade && cmd.Name() != commands.CommandNameVersion {
-				ok, err := gitcmds.CheckIfGitRepo(params.Dir)
+				ok, err := gitcmds.CheckIfGitRepo(params.Repo)
 				if err != nil {
 					return err
 				}
 				if !ok {
-					return errors.New("this is not a git repository")
+					return gitcmds.ErrNotGitRepo
 				}
 			}
 
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return gitcmds.Status(params.Dir)
+			if params.JSONStdout != nil {
+				info, err := gitcmds.GetStatus(params.Repo)
+				if err != nil {
+					return err
+				}
+				params.Repo.Result = info
+
+				return nil
+			}
+
+			return gitcmds.Dashboard(params.Repo)
 		},
 	}
 
 	rootCmd.SetContext(ctx)
 	rootCmd.SetArgs(args[1:])
+	// subcommands get the context of the root command when executed, it is cancelled on interrupt
 	rootCmd.AddCommand(cmds...)
-	// Set context for all subcommands
-	for _, cmd := range cmds {
-		cmd.SetContext(ctx)
-	}
 
 	rootCmd.PersistentFlags().BoolVarP(&commands.Verbose, "verbose", "v", false, "Verbose output")
 	rootCmd.PersistentFlags().Bool("trace", false, "Extremely verbose output")
+	rootCmd.PersistentFlags().BoolVarP(&params.AssumeYes, "yes", "y", false, "Answer yes to all confirmations (also "+prompt.EnvAssumeYes+"=true)")
+	rootCmd.PersistentFlags().BoolVar(&params.NoInput, "no-input", false, "Fail instead of asking questions")
+	rootCmd.MarkFlagsMutuallyExclusive("yes", "no-input")
+	rootCmd.PersistentFlags().BoolVar(&params.DryRun, "dry-run", false, "Run read-only queries only and print mutating operations instead of running them")
+	rootCmd.PersistentFlags().StringVarP(&params.Output, "output", "o", string(output.FormatText), "Output format: text or json")
+	rootCmd.PersistentFlags().StringArrayVarP(&params.ConfigOverrides, "config", "c", nil, "Override a setting for this run, e.g. -c branch.dev_suffix=-feature")
 	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	})
+	markArgsErrorsAsUsage(rootCmd)
 	err := initChangeDirFlags(rootCmd.Commands(), params)
 	return rootCmd, err
 }
+
+// markArgsErrorsAsUsage wraps errors of positional args validation of the command and its subcommands in ErrUsage
+func markArgsErrorsAsUsage(cmd *cobra.Command) {
+	if validateArgs := cmd.Args; validateArgs != nil {
+		cmd.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validateArgs(cmd, args); err != nil {
+				return fmt.Errorf("%w: %w", ErrUsage, err)
+			}
+
+			return nil
+		}
+	}
+	for _, subCmd := range cmd.Commands() {
+		markArgsErrorsAsUsage(subCmd)
+	}
+}
@@ -3,8 +3,8 @@ package cmdproc
 import "github.com/untillpro/qs/internal/commands"
 
 var (
-	requiredBashCommands = []string{"grep", "sed", "jq", "gawk", "wc", "curl", "chmod"}
-	cmdsNeedGH           = map[string]bool{
+	requiredCommands = []string{"git"}
+	cmdsNeedHosting  = map[string]bool{
 		commands.CommandNameFork: true,
 		commands.CommandNameDev:  true,
 		commands.CommandNamePR:   true,
@@ -12,6 +12,9 @@ var (
 	cmdsSkipPrerequisites = map[string]bool{
 		commands.CommandNameVersion: true,
 		commands.CommandNameUpgrade: true,
+		commands.CommandNameConfig:  true,
+		commands.CommandNameHook:    true,
+		commands.CommandNameHooks:   true,
 		"help":                      true,
 	}
 )
@@ -0,0 +1,11 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package cmdproc
+
+import "errors"
+
+// ErrUsage wraps errors of parsing flags and positional args
+var ErrUsage = errors.New("invalid usage")
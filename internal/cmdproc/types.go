@@ -1,5 +1,30 @@
 package cmdproc
 
+import (
+	"os"
+
+	"github.com/untillpro/qs/gitcmds"
+	"github.com/untillpro/qs/internal/config"
+)
+
 type qsGlobalParams struct {
 	Dir string
+	// ConfigOverrides are "key=value" settings given by --config flags
+	ConfigOverrides []string
+	// Config is the layered config loaded before the command runs
+	Config *config.Config
+	// AssumeYes answers yes to all confirmations, set by --yes
+	AssumeYes bool
+	// NoInput fails on any question instead of reading stdin, set by --no-input
+	NoInput bool
+	// DryRun prints mutating operations instead of running them, set by --dry-run
+	DryRun bool
+	// Output is the output format set by --output
+	Output string
+	// Command is the path of the executed command, e.g. "qs dev"
+	Command string
+	// JSONStdout is the original stdout the JSON document is printed to, nil for text output
+	JSONStdout *os.File
+	// Repo is the per-invocation context created from Dir before the command runs
+	Repo *gitcmds.RepoContext
 }
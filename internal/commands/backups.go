@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/untillpro/qs/gitcmds"
+)
+
+// BackupsList prints the branches backed up before they were deleted or reset, the latest first
+func BackupsList(rc *gitcmds.RepoContext) error {
+	backups, err := gitcmds.ListBackups(rc)
+	if err != nil {
+		return err
+	}
+	rc.Result = &BackupsResult{Backups: backups}
+	if len(backups) == 0 {
+		fmt.Println("No backups found")
+		return nil
+	}
+	for _, b := range backups {
+		fmt.Printf("%s  %s  %s\n", b.ID, shortSHA(b.SHA), b.Subject)
+	}
+
+	return nil
+}
+
+// BackupsRestore brings back the branches of the backups matching the ID, the timestamp or the branch name.
+// Existing branches are reset to the backup only if force is true.
+func BackupsRestore(rc *gitcmds.RepoContext, query string, force bool) error {
+	backups, err := gitcmds.ListBackups(rc)
+	if err != nil {
+		return err
+	}
+	found := gitcmds.FindBackups(backups, query)
+	if len(found) == 0 {
+		return fmt.Errorf("no backup found for %s, see 'qs backups list'", query)
+	}
+
+	return restoreBackups(rc, found, force)
+}
+
+// Undo brings back the branches deleted or reset by the last qs command
+func Undo(rc *gitcmds.RepoContext) error {
+	backups, err := gitcmds.ListBackups(rc)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		rc.Result = &BackupsResult{}
+		fmt.Println("Nothing to undo, no backups found")
+		return nil
+	}
+
+	// the list starts with the latest backups
+	var latest []gitcmds.Backup
+	for _, b := range backups {
+		if !b.CreatedAt.Equal(backups[0].CreatedAt) {
+			break
+		}
+		latest = append(latest, b)
+	}
+
+	return restoreBackups(rc, latest, false)
+}
+
+// BackupsPrune deletes the backups older than keepDays days
+func BackupsPrune(rc *gitcmds.RepoContext, keepDays int) error {
+	if keepDays < 0 {
+		return errors.New("number of days to keep backups must not be negative")
+	}
+	pruned, err := gitcmds.PruneBackups(rc, time.Now().AddDate(0, 0, -keepDays))
+	rc.Result = &BackupsResult{Backups: pruned}
+	for _, b := range pruned {
+		fmt.Println("Deleted backup", b.ID)
+	}
+	if err == nil && len(pruned) == 0 {
+		fmt.Println("No backups older than", keepDays, "days")
+	}
+
+	return err
+}
+
+func restoreBackups(rc *gitcmds.RepoContext, backups []gitcmds.Backup, force bool) error {
+	result := &BackupsResult{}
+	rc.Result = result
+
+	branches := make([]string, 0, len(backups))
+	for _, b := range backups {
+		branches = append(branches, b.Branch+" ("+shortSHA(b.SHA)+")")
+	}
+	agree, err := rc.Prompt().Confirm(fmt.Sprintf("Branches will be restored from backup %s: %s. Continue",
+		backups[0].CreatedAt.Local().Format(time.DateTime), strings.Join(branches, ", ")))
+	if err != nil {
+		return err
+	}
+	if !agree {
+		fmt.Print(msgOkSeeYou)
+		return nil
+	}
+
+	for _, b := range backups {
+		if err := gitcmds.RestoreBackup(rc, b, force); err != nil {
+			return err
+		}
+		result.Backups = append(result.Backups, b)
+		fmt.Printf("Branch '%s' is restored at %s\n", b.Branch, shortSHA(b.SHA))
+	}
+
+	return nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > shortSHALen {
+		return sha[:shortSHALen]
+	}
+
+	return sha
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/untillpro/qs/gitcmds"
+	"github.com/untillpro/qs/internal/config"
+)
+
+// ConfigGet prints the effective value of the config setting
+func ConfigGet(rc *gitcmds.RepoContext, key string) error {
+	value, err := rc.Settings().Get(key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	rc.Result = &ConfigValue{Key: key, Value: value}
+
+	return nil
+}
+
+// ConfigList prints all effective config settings
+func ConfigList(rc *gitcmds.RepoContext) {
+	result := &ConfigListResult{}
+	for _, line := range rc.Settings().List() {
+		fmt.Println(line)
+		key, value, _ := strings.Cut(line, "=")
+		result.Settings = append(result.Settings, ConfigValue{Key: key, Value: value})
+	}
+	rc.Result = result
+}
+
+// ConfigSet writes the config setting to .qs.yaml of the repository or to the user config file if global is true
+func ConfigSet(rc *gitcmds.RepoContext, key, value string, global bool) error {
+	file, err := config.RepoFile(rc.Wd)
+	if global {
+		file, err = config.UserFile()
+	}
+	if err != nil {
+		return err
+	}
+	if rc.Planned("write " + key + "=" + value + " to " + file) {
+		return nil
+	}
+
+	if err := config.SaveValue(file, key, value); err != nil {
+		return err
+	}
+	fmt.Printf("%s=%s written to %s\n", key, value, file)
+
+	return nil
+}
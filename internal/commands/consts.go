@@ -4,10 +4,9 @@ var Verbose bool
 
 const (
 	msgOkSeeYou = "Ok, see you"
-	pushYes     = "y"
+	msgUndoHint = "Deleted branches are backed up, run 'qs undo' to bring them back"
 
-	EnvSkipQsVersionCheck   = "QS_SKIP_QS_VERSION_CHECK"
-	minimumCommitMessageLen = 8
+	EnvSkipQsVersionCheck = "QS_SKIP_QS_VERSION_CHECK"
 )
 
 const (
@@ -20,4 +19,22 @@ const (
 	CommandNameU       = "u"
 	CommandNameR       = "r"
 	CommandNameG       = "g"
+	CommandNameConfig  = "config"
+	CommandNameHook    = "hook"
+	CommandNameHooks   = "hooks"
+	CommandNameRecover = "recover"
+	CommandNameBackups = "backups"
+	CommandNameUndo    = "undo"
+)
+
+// DefaultBackupKeepDays is the age of backups kept by qs backups prune by default
+const DefaultBackupKeepDays = 30
+
+const shortSHALen = 7
+
+const (
+	RecoverActionNone     RecoverAction = ""
+	RecoverActionResume   RecoverAction = "resume"
+	RecoverActionRollback RecoverAction = "rollback"
+	RecoverActionDiscard  RecoverAction = "discard"
 )
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
 
 	"github.com/atotto/clipboard"
@@ -13,25 +13,26 @@ import (
 	"github.com/untillpro/qs/gitcmds"
 	"github.com/untillpro/qs/internal/issue"
 	"github.com/untillpro/qs/internal/jira"
+	"github.com/untillpro/qs/internal/journal"
 	"github.com/untillpro/qs/internal/notes"
+	"github.com/untillpro/qs/internal/tracker"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
-func Dev(cmd *cobra.Command, wd string, doDelete bool, ignoreHook bool, args []string) error {
-	parentRepo, err := gitcmds.GetParentRepoName(wd)
+func Dev(cmd *cobra.Command, rc *gitcmds.RepoContext, doDelete bool, ignoreHook bool, args []string) error {
+	parentRepo, err := gitcmds.GetParentRepoName(rc)
 	if err != nil {
 		return err
 	}
 
 	// qs dev -d is running
 	if doDelete {
-		return deleteBranches(wd, parentRepo)
+		return deleteBranches(rc, parentRepo)
 	}
 	// qs dev is running
 	var devBranchName string
 	var notes []string
-	var response string
 
 	if len(args) == 0 {
 		clipargs := strings.TrimSpace(getArgStringFromClipboard(cmd.Context()))
@@ -42,7 +43,7 @@ func Dev(cmd *cobra.Command, wd string, doDelete bool, ignoreHook bool, args []s
 	// - If parentRepo exists OR upstream remote exists -> fork workflow
 	// - If no parentRepo AND no upstream remote -> single remote workflow
 	// Check if upstream remote exists
-	upstreamExists, err := gitcmds.HasRemote(wd, "upstream")
+	upstreamExists, err := gitcmds.HasRemote(rc, "upstream")
 	if err != nil {
 		return err
 	}
@@ -52,7 +53,7 @@ func Dev(cmd *cobra.Command, wd string, doDelete bool, ignoreHook bool, args []s
 	// 2. Upstream remote exists (indicating fork workflow was intended)
 	// This catches the edge case where someone manually added upstream but didn't fork
 	if len(parentRepo) == 0 && upstreamExists {
-		repo, org, err := gitcmds.GetRepoAndOrgName(wd)
+		repo, org, err := gitcmds.GetRepoAndOrgName(rc)
 		if err != nil {
 			return err
 		}
@@ -60,38 +61,48 @@ func Dev(cmd *cobra.Command, wd string, doDelete bool, ignoreHook bool, args []s
 		return fmt.Errorf("you are in %s/%s repo with upstream remote but no fork detected\nExecute 'qs fork' first", org, repo)
 	}
 
-	curBranch, mainBranch, isMain, err := gitcmds.GetCurrentBranchInfo(wd)
+	curBranch, mainBranch, isMain, err := gitcmds.GetCurrentBranchInfo(rc)
 	if err != nil {
 		return err
 	}
 	if !isMain {
 		fmt.Println("--------------------------------------------------------")
 		fmt.Println("You are in")
-		repo, org, err := gitcmds.GetRepoAndOrgName(wd)
+		repo, org, err := gitcmds.GetRepoAndOrgName(rc)
 		if err != nil {
 			return err
 		}
 
-		color.New(color.FgHiCyan).Println(org + "/" + repo + "/" + curBranch)
+		// color.Output is bound to the stdout at start, so the swapped os.Stdout is passed explicitly
+		_, _ = color.New(color.FgHiCyan).Fprintln(os.Stdout, org+"/"+repo+"/"+curBranch)
 
 		return fmt.Errorf("switch to main branch before running 'qs dev'. You are in %s branch ", curBranch)
 	}
 
 	// Stash current changes if needed
 	stashedUncommittedChanges := false
-	if ok, err := gitcmds.HaveUncommittedChanges(wd); ok {
+	if ok, err := gitcmds.HaveUncommittedChanges(rc); ok {
 		if err != nil {
 			return err
 		}
 
-		if err := gitcmds.Stash(wd); err != nil {
+		if err := gitcmds.Stash(rc); err != nil {
 			return fmt.Errorf("error stashing changes: %w", err)
 		}
 		stashedUncommittedChanges = true
 	}
+	var j *journal.Journal
+	defer func() {
+		// once the journal is started, the stashed changes are restored by qs recover
+		if stashedUncommittedChanges && j == nil && rc.Context().Err() != nil {
+			if err := gitcmds.Unstash(rc.WithoutCancel()); err != nil {
+				fmt.Println("Stashed changes are not restored, run 'git stash pop':", err)
+			}
+		}
+	}()
 
 	// sync local MainBranch to ensure it's up to date with origin and upstream remotes
-	if err := gitcmds.SyncMainBranch(wd, mainBranch, upstreamExists); err != nil {
+	if err := gitcmds.SyncMainBranch(rc, mainBranch, upstreamExists); err != nil {
 		return err
 	}
 
@@ -100,7 +111,7 @@ func Dev(cmd *cobra.Command, wd string, doDelete bool, ignoreHook bool, args []s
 		return err
 	}
 
-	devBranchName, notes, err = issue.BuildDevBranchName(issueInfo)
+	devBranchName, notes, err = issue.BuildDevBranchName(rc.Context(), tracker.Env{Hosting: rc.HostingFor(issueInfo.URL)}, issueInfo, rc.Settings().Branch)
 	if err != nil {
 		if errors.Is(err, jira.ErrJiraIssueNotFoundOrInsufficientPermission) {
 			fmt.Print(jira.NotFoundIssueOrInsufficientAccessRightSuggestion)
@@ -109,86 +120,170 @@ func Dev(cmd *cobra.Command, wd string, doDelete bool, ignoreHook bool, args []s
 		return err
 	}
 
-	exists, err := branchExists(wd, devBranchName)
+	exists, err := gitcmds.BranchExists(rc, devBranchName)
 	if err != nil {
 		return fmt.Errorf("error checking branch existence: %w", err)
 	}
 	if exists {
-		return fmt.Errorf("dev branch '%s' already exists", devBranchName)
+		return fmt.Errorf("%w: dev branch '%s'", gitcmds.ErrBranchExists, devBranchName)
 	}
 
 	cmd.SetContext(context.WithValue(cmd.Context(), utils.CtxKeyDevBranchName, devBranchName))
 
-	fmt.Print("Dev branch '" + devBranchName + "' will be created. Continue(y/n)? ")
-	_, _ = fmt.Scanln(&response)
-
-	switch response {
-	case pushYes:
-		if len(parentRepo) > 0 && !upstreamExists {
-			var upstreamResponse string
-			fmt.Print("Upstream not found.\nRepository " + parentRepo + " will be added as upstream. Agree[y/n]?")
-			_, _ = fmt.Scanln(&upstreamResponse)
-			if upstreamResponse != pushYes {
-				fmt.Print(msgOkSeeYou)
-				return nil
-			}
-			if err := gitcmds.MakeUpstreamForBranch(wd, parentRepo); err != nil {
-				return err
-			}
+	result := &DevResult{Branch: devBranchName, Notes: notes, IssueURL: issueInfo.URL}
+	rc.Result = result
+	createBranch, err := rc.Prompt().Confirm("Dev branch '" + devBranchName + "' will be created. Continue")
+	if err != nil {
+		return err
+	}
+	if !createBranch {
+		fmt.Print(msgOkSeeYou)
+		return nil
+	}
+
+	data := devJournalData{
+		Branch:     devBranchName,
+		MainBranch: mainBranch,
+		Notes:      notes,
+		IssueURL:   issueInfo.URL,
+		Stashed:    stashedUncommittedChanges,
+	}
+	if len(parentRepo) > 0 && !upstreamExists {
+		addUpstream, err := rc.Prompt().Confirm("Upstream not found.\nRepository " + parentRepo + " will be added as upstream. Agree")
+		if err != nil {
+			return err
+		}
+		if !addUpstream {
+			fmt.Print(msgOkSeeYou)
+			return nil
+		}
+		data.ParentRepo = parentRepo
+	}
+
+	j, err = gitcmds.BeginJournal(rc, journal.CommandDev)
+	if err != nil {
+		return err
+	}
+	if err := j.SetData(data); err != nil {
+		return err
+	}
+
+	err = continueDev(rc, j, !ignoreHook)
+	result.Created = err == nil || j.IsDone(journal.StepBranchCreated)
+
+	return err
+}
+
+// continueDev runs the steps of qs dev which are not completed yet
+func continueDev(rc *gitcmds.RepoContext, j *journal.Journal, installHooks bool) error {
+	var data devJournalData
+	if err := j.ReadData(&data); err != nil {
+		return err
+	}
+
+	if len(data.ParentRepo) > 0 && !j.IsDone(journal.StepUpstreamAdded) {
+		if err := gitcmds.MakeUpstreamForBranch(rc, data.ParentRepo); err != nil {
+			return err
+		}
+		if err := j.Done(journal.StepUpstreamAdded); err != nil {
+			return err
 		}
+	}
 
-		if err := gitcmds.CreateDevBranch(wd, devBranchName, mainBranch, notes); err != nil {
+	if !j.IsDone(journal.StepBranchCreated) {
+		if err := gitcmds.CreateDevBranch(rc, data.Branch, data.MainBranch, data.Notes); err != nil {
 			return err
 		}
+		if err := j.Done(journal.StepBranchCreated); err != nil {
+			return err
+		}
+	}
 
-		if issueInfo.Type == issue.GitHub {
-			notes, err = gitcmds.LinkBranchToGithubIssue(wd, parentRepo, issueInfo.Text, issueInfo.ID, devBranchName, args...)
-			if err != nil {
+	if len(data.IssueURL) > 0 && !j.IsDone(journal.StepIssueLinked) {
+		if t := issue.Trackers.Find(data.IssueURL); t != nil {
+			if err := gitcmds.LinkBranchToIssue(rc, t, data.IssueURL, data.Branch); err != nil {
 				return err
 			}
 		}
-	default:
-		fmt.Print(msgOkSeeYou)
-		return nil
+		if err := j.Done(journal.StepIssueLinked); err != nil {
+			return err
+		}
 	}
 
 	// Create pre-commit hook to control committing file size
-	if err := setPreCommitHook(wd); err != nil {
-		logger.Verbose("Error setting pre-commit hook:", err)
+	if installHooks {
+		if _, err := gitcmds.InstallHooks(rc, false, false); err != nil {
+			logger.Verbose("Error setting pre-commit hook:", err)
+		}
 	}
 
 	// Ensure large file hook content is up to date
-	if err := gitcmds.EnsureLargeFileHookUpToDate(wd); err != nil {
+	if err := gitcmds.EnsureLargeFileHookUpToDate(rc); err != nil {
 		logger.Verbose("Error updating large file hook content:", err)
 	}
 	// Unstash changes
-	if stashedUncommittedChanges {
-		if err := gitcmds.Unstash(wd); err != nil {
+	if data.Stashed && !j.IsDone(journal.StepUnstashed) {
+		if err := gitcmds.Unstash(rc); err != nil {
 			return fmt.Errorf("error unstashing changes: %w", err)
 		}
+		if err := j.Done(journal.StepUnstashed); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return j.Finish()
 }
 
-func branchExists(wd, branchName string) (bool, error) {
-	cmd := exec.Command("git", "branch", "--list", branchName)
-	cmd.Dir = wd
-	output, err := cmd.Output()
-	if err != nil {
-		return false, fmt.Errorf("failed to check local branches: %w", err)
+// resumeDev completes interrupted qs dev, the dev branch is created again if its creation is not completed
+func resumeDev(rc *gitcmds.RepoContext, j *journal.Journal) error {
+	if !j.IsDone(journal.StepBranchCreated) {
+		var data devJournalData
+		if err := j.ReadData(&data); err != nil {
+			return err
+		}
+		if err := gitcmds.CheckoutOnBranch(rc, data.MainBranch); err != nil {
+			return err
+		}
+		if err := gitcmds.DeleteBranchIfExists(rc, data.Branch); err != nil {
+			return err
+		}
 	}
-	if strings.TrimSpace(string(output)) != "" {
-		return true, nil
+
+	return continueDev(rc, j, false)
+}
+
+// rollbackDev deletes the dev branch created by interrupted qs dev and restores the stashed changes
+func rollbackDev(rc *gitcmds.RepoContext, j *journal.Journal) error {
+	var data devJournalData
+	if err := j.ReadData(&data); err != nil {
+		return err
 	}
 
-	cmd = exec.Command("git", "ls-remote", "--heads", "origin", branchName)
-	cmd.Dir = wd
-	output, err = cmd.Output()
-	if err != nil {
-		return false, fmt.Errorf("failed to check remote branches: %w", err)
+	original := j.Branch
+	if len(original) == 0 || original == data.Branch {
+		original = data.MainBranch
 	}
-	return strings.TrimSpace(string(output)) != "", nil
+	if err := gitcmds.CheckoutOnBranch(rc, original); err != nil {
+		return err
+	}
+	if err := gitcmds.DeleteBranchIfExists(rc, data.Branch); err != nil {
+		return err
+	}
+	if j.IsDone(journal.StepUpstreamAdded) {
+		if err := gitcmds.RemoveRemote(rc, "upstream"); err != nil {
+			return err
+		}
+	}
+	if data.Stashed && !j.IsDone(journal.StepUnstashed) {
+		if err := gitcmds.Unstash(rc); err != nil {
+			return fmt.Errorf("error unstashing changes: %w", err)
+		}
+	}
+	if j.IsDone(journal.StepIssueLinked) {
+		fmt.Println("The link to", data.Branch, "is kept in the issue", data.IssueURL)
+	}
+
+	return j.Finish()
 }
 
 // getArgStringFromClipboard retrieves a string from the clipboard, or uses the context value if available.
@@ -213,41 +308,35 @@ func getArgStringFromClipboard(ctx context.Context) string {
 	return newArg
 }
 
-func setPreCommitHook(wd string) error {
-	if ok, err := gitcmds.LocalPreCommitHookExist(wd); ok || err != nil {
-		return err
-	}
-
-	return gitcmds.SetLocalPreCommitHook(wd)
-}
-
-func deleteBranches(wd, parentRepo string) error {
+func deleteBranches(rc *gitcmds.RepoContext, parentRepo string) error {
 	// Step 1: qs d
-	if err := gitcmds.Download(wd); err != nil {
+	if err := gitcmds.Download(rc); err != nil {
 		return err
 	}
 
-	mainBranch, err := gitcmds.GetMainBranch(wd)
+	mainBranch, err := gitcmds.GetMainBranch(rc)
 	if err != nil {
 		return err
 	}
 
 	// Step 2: Checkout Main
-	if err := gitcmds.CheckoutOnBranch(wd, mainBranch); err != nil {
+	if err := gitcmds.CheckoutOnBranch(rc, mainBranch); err != nil {
 		return err
 	}
 
 	// Step 3: foreach branch that have origin remote tracking branch `git branch -vv | awk '$3 ~ /\[origin.*\]/ {print $1}'`
-	branchesToAnalyze, err := gitcmds.GetBranchesWithRemoteTracking(wd, "origin")
+	branchesToAnalyze, err := gitcmds.GetBranchesWithRemoteTracking(rc, "origin")
 	if err != nil {
 		return err
 	}
 
+	result := &DeleteBranchesResult{Deleted: []string{}}
+	rc.Result = result
 	branchesToBeDeleted := make([]string, 0, len(branchesToAnalyze))
 	// Iterate through branches
 	for _, branch := range branchesToAnalyze {
 		// Step 3.n: if pr is merged, then all related branches must be deleted
-		prInfo, _, _, err := gitcmds.DoesPrExist(wd, parentRepo, branch, gitcmds.PRStateMerged)
+		prInfo, err := gitcmds.DoesPrExist(rc, parentRepo, branch, gitcmds.PRStateMerged)
 		if err != nil {
 			return err
 		}
@@ -255,14 +344,14 @@ func deleteBranches(wd, parentRepo string) error {
 		if prInfo == nil {
 			skipBranch := true
 			// if dev branch then check if pull request is merged of the possible related pr branch
-			branchType := gitcmds.GetBranchTypeByName(branch)
+			branchType := gitcmds.GetBranchTypeByName(rc, branch)
 			if branchType == notes.BranchTypeDev {
 				// calculate possible related pr branch name
 				// e.g. if branch is "feature-123-dev" then related pr branch
 				// is "feature-123-pr"
-				prBranchName := strings.TrimSuffix(branch, "-dev") + "-pr"
+				prBranchName := gitcmds.PRBranchName(rc, branch)
 				// check if pull request is merged of the possible related pr branch
-				prInfo, _, _, err := gitcmds.DoesPrExist(wd, parentRepo, prBranchName, gitcmds.PRStateMerged)
+				prInfo, err := gitcmds.DoesPrExist(rc, parentRepo, prBranchName, gitcmds.PRStateMerged)
 				if err != nil {
 					return err
 				}
@@ -287,23 +376,26 @@ func deleteBranches(wd, parentRepo string) error {
 		}
 
 		// Step 5: ask for confirmation
-		var response string
 		fmt.Println()
-		fmt.Print("Proceed with deletion? [y/n]?")
-		_, _ = fmt.Scanln(&response)
-		if response != pushYes {
+		proceed, err := rc.Prompt().Confirm("Proceed with deletion")
+		if err != nil {
+			return err
+		}
+		if !proceed {
 			fmt.Print(msgOkSeeYou)
 			return nil
 		}
 
 		// Step 6: deletion branches
 		for _, branch := range branchesToBeDeleted {
-			if err := gitcmds.RemoveBranch(wd, branch); err != nil {
+			if err := gitcmds.RemoveBranch(rc, branch); err != nil {
 				return fmt.Errorf("error deleting branch '%s': %w", branch, err)
 			}
 
 			fmt.Printf("Branch '%s' deleted successfully.\n", branch)
+			result.Deleted = append(result.Deleted, branch)
 		}
+		fmt.Println(msgUndoHint)
 
 		return nil
 	}
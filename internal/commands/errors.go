@@ -4,5 +4,5 @@ import "errors"
 
 var (
 	ErrEmptyCommitMessage = errors.New("commit message is missing, use -m to specify")
-	ErrShortCommitMessage = errors.New("commit message is missing or too short (minimum 8 characters)")
+	ErrShortCommitMessage = errors.New("commit message is missing or too short")
 )
@@ -1,43 +1,118 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/untillpro/qs/gitcmds"
-	"github.com/voedger/voedger/pkg/goutils/logger"
+	"github.com/untillpro/qs/internal/journal"
 )
 
-func Fork(wd string) error {
-	if ok, err := notCommittedRefused(wd); ok || err != nil {
+func Fork(rc *gitcmds.RepoContext) error {
+	refused, err := notCommittedRefused(rc)
+	if err != nil {
+		return err
+	}
+	if refused {
 		return fmt.Errorf("git refused to commit")
 	}
 
-	repo, err := gitcmds.Fork(wd)
+	j, err := gitcmds.BeginJournal(rc, journal.CommandFork)
 	if err != nil {
 		return err
 	}
+	repo, err := gitcmds.Fork(rc, j)
+	if err != nil {
+		if !j.IsDone(journal.StepStashed) && !j.IsDone(journal.StepForked) {
+			// nothing is changed yet
+			return errors.Join(err, j.Finish())
+		}
+		return err
+	}
+	if err := j.SetData(forkJournalData{Repo: repo}); err != nil {
+		return err
+	}
+
+	return continueFork(rc, j)
+}
+
+// continueFork configures the remotes of the forked repository and restores the stashed changes
+func continueFork(rc *gitcmds.RepoContext, j *journal.Journal) error {
+	var data forkJournalData
+	if err := j.ReadData(&data); err != nil {
+		return err
+	}
+
+	if !j.IsDone(journal.StepRemotesConfigured) {
+		if err := gitcmds.MakeUpstream(rc, data.Repo); err != nil {
+			return fmt.Errorf("failed to set upstream: %w", err)
+		}
+		if err := j.Done(journal.StepRemotesConfigured); err != nil {
+			return err
+		}
+	}
 
-	if err := gitcmds.MakeUpstream(wd, repo); err != nil {
-		logger.Verbose(fmt.Sprintf("Failed to set upstream: %v", err))
+	if j.IsDone(journal.StepStashed) && !j.IsDone(journal.StepUnstashed) {
+		if err := gitcmds.Unstash(rc); err != nil {
+			return fmt.Errorf("failed to pop stashed files: %w", err)
+		}
+		if err := j.Done(journal.StepUnstashed); err != nil {
+			return err
+		}
 	}
+	rc.Result = &ForkResult{Repo: data.Repo, UpstreamURL: gitcmds.GetRemoteUpstreamURL(rc)}
+
+	return j.Finish()
+}
+
+// resumeFork completes interrupted qs fork, remotes configured partially are reverted and configured again
+func resumeFork(rc *gitcmds.RepoContext, j *journal.Journal) error {
+	if !j.IsDone(journal.StepForked) {
+		repo, err := gitcmds.Fork(rc, j)
+		if err != nil {
+			return err
+		}
+		if err := j.SetData(forkJournalData{Repo: repo}); err != nil {
+			return err
+		}
+	}
+	if !j.IsDone(journal.StepRemotesConfigured) {
+		if err := gitcmds.RestoreOrigin(rc); err != nil {
+			return err
+		}
+	}
+
+	return continueFork(rc, j)
+}
 
-	if err := gitcmds.Unstash(wd); err != nil {
-		logger.Verbose(fmt.Sprintf("Failed to pop stashed files: %v", err))
+// rollbackFork restores the remotes and the stashed changes, the fork itself is kept
+func rollbackFork(rc *gitcmds.RepoContext, j *journal.Journal) error {
+	if err := gitcmds.RestoreOrigin(rc); err != nil {
+		return err
+	}
+	if j.IsDone(journal.StepStashed) && !j.IsDone(journal.StepUnstashed) {
+		if err := gitcmds.Unstash(rc); err != nil {
+			return fmt.Errorf("failed to pop stashed files: %w", err)
+		}
+	}
+	if j.IsDone(journal.StepForked) {
+		fmt.Println("The fork created on", rc.Host, "is kept, delete it there if it is not needed")
 	}
 
-	return nil
+	return j.Finish()
 }
 
-func notCommittedRefused(wd string) (bool, error) {
-	s, fileExists, err := gitcmds.ChangedFilesExist(wd)
+func notCommittedRefused(rc *gitcmds.RepoContext) (bool, error) {
+	s, fileExists, err := gitcmds.ChangedFilesExist(rc)
 	if !fileExists {
 		return false, err
 	}
 	fmt.Println("You have modified files: ")
 	fmt.Println("----   " + s)
-	fmt.Print("All will be kept not commted. Continue(y/n)?")
-	var response string
-	_, _ = fmt.Scanln(&response)
+	if err != nil {
+		return false, err
+	}
+	proceed, err := rc.Prompt().Confirm("All will be kept not commted. Continue")
 
-	return response != pushYes, err
+	return !proceed, err
 }
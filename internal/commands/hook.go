@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package commands
+
+import "github.com/untillpro/qs/gitcmds"
+
+// HookPreCommit runs the checks of the pre-commit git hook installed by qs
+func HookPreCommit(rc *gitcmds.RepoContext) error {
+	return gitcmds.PreCommitCheck(rc)
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/untillpro/qs/gitcmds"
+)
+
+// HooksInstall adds the qs checks to the pre-commit hook of the repository or to the global one
+func HooksInstall(rc *gitcmds.RepoContext, global, force bool) error {
+	status, err := gitcmds.InstallHooks(rc, global, force)
+	if err != nil {
+		return err
+	}
+	rc.Result = &status
+	fmt.Println("qs checks are installed to", status.Dir)
+	printHooksStatus(status)
+
+	return nil
+}
+
+// HooksUninstall removes the qs checks from the pre-commit hook of the repository or from the global one
+func HooksUninstall(rc *gitcmds.RepoContext, global bool) error {
+	status, err := gitcmds.UninstallHooks(rc, global)
+	if err != nil {
+		return err
+	}
+	rc.Result = &status
+	fmt.Println("qs checks are removed from", status.Dir)
+	if len(status.HooksPath) > 0 {
+		fmt.Println("core.hooksPath is kept:", status.HooksPath)
+	}
+	if status.LargeFileHook == gitcmds.HookFileModified {
+		fmt.Println(gitcmds.LargeFileHookFilename, "is modified by the user and is kept")
+	}
+
+	return nil
+}
+
+// HooksStatus prints the state of the qs hooks of the repository or of the global ones
+func HooksStatus(rc *gitcmds.RepoContext, global bool) error {
+	status, err := gitcmds.GetHooksStatus(rc, global)
+	if err != nil {
+		return err
+	}
+	rc.Result = &status
+	printHooksStatus(status)
+
+	return nil
+}
+
+func printHooksStatus(status gitcmds.HooksStatus) {
+	fmt.Println("hooks directory:", status.Dir)
+	if len(status.HooksPath) > 0 {
+		fmt.Println("core.hooksPath:", status.HooksPath)
+	}
+	if len(status.Managers) > 0 {
+		fmt.Println("hook managers:", strings.Join(status.Managers, ", "))
+	}
+	fmt.Println("qs pre-commit checks installed:", status.Installed)
+	fmt.Println(gitcmds.LargeFileHookFilename+":", status.LargeFileHook)
+	if status.LargeFileHook == gitcmds.HookFileModified {
+		fmt.Println("Run 'qs hooks install --force' to overwrite the edits")
+	}
+}
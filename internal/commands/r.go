@@ -9,9 +9,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
-	"github.com/untillpro/goutils/exec"
+	"github.com/coreos/go-semver/semver"
+	"github.com/untillpro/qs/gitcmds"
+	"github.com/untillpro/qs/internal/journal"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
@@ -31,21 +34,14 @@ import (
 */
 
 // Release current branch. Remove PreRelease, tag, bump version, push
-func Release(wd string) error {
+func Release(rc *gitcmds.RepoContext) error {
 
 	// *************************************************
 	_, _ = fmt.Fprintln(os.Stdout, "Pulling")
-	stdout, stderr, err := new(exec.PipedExec).
-		Command("git", "pull").
-		WorkingDir(wd).
-		RunToStrings()
+	stdout, stderr, err := rc.Git("pull")
 	if err != nil {
 		logger.Verbose(stderr)
 
-		if len(stderr) > 0 {
-			return errors.New(stderr)
-		}
-
 		return fmt.Errorf("error pulling: %w", err)
 	}
 	logger.Verbose(stdout)
@@ -65,105 +61,102 @@ func Release(wd string) error {
 	targetVersion := currentVersion
 	targetVersion.PreRelease = ""
 
-	fmt.Printf("Version %v will be tagged, bumped and pushed, agree? [y]", targetVersion)
-	var response string
-	_, _ = fmt.Scanln(&response)
-	if response != "y" {
+	agree, err := rc.Prompt().Confirm(fmt.Sprintf("Version %v will be tagged, bumped and pushed, agree", targetVersion))
+	if err != nil {
+		return err
+	}
+	if !agree {
 		return errors.New("release aborted by user")
 	}
 
-	// *************************************************
-	_, _ = fmt.Fprintln(os.Stdout, "Updating 'version' file")
-	if err := targetVersion.Save(); err != nil {
-		return fmt.Errorf("error saving file 'version': %w", err)
+	newVersion := *targetVersion.Version
+	newVersion.Minor++
+	newVersion.PreRelease = "SNAPSHOT"
+
+	j, err := gitcmds.BeginJournal(rc, journal.CommandRelease, "HEAD")
+	if err != nil {
+		return err
+	}
+	err = j.SetData(releaseJournalData{
+		File:        currentVersion.Filename(),
+		Version:     targetVersion.String(),
+		NextVersion: newVersion.String(),
+	})
+	if err != nil {
+		return err
 	}
 
-	// *************************************************
-	_, _ = fmt.Fprintln(os.Stdout, "Committing target version")
-	{
-		params := []string{"commit", "-a", "-m", "#scm-ver " + targetVersion.String()}
-		stdout, stderr, err = new(exec.PipedExec).
-			Command("git", params...).
-			WorkingDir(wd).
-			RunToStrings()
-		if err != nil {
-			logger.Verbose(stderr)
+	return continueRelease(rc, j)
+}
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
+// continueRelease runs the steps of qs r which are not completed yet
+func continueRelease(rc *gitcmds.RepoContext, j *journal.Journal) error {
+	var data releaseJournalData
+	if err := j.ReadData(&data); err != nil {
+		return err
+	}
+	tagName := "v" + data.Version
 
+	if !j.IsDone(journal.StepTargetCommitted) {
+		// *************************************************
+		_, _ = fmt.Fprintln(os.Stdout, "Updating 'version' file")
+		if err := saveVersion(rc, data.Version); err != nil {
+			return err
+		}
+
+		// *************************************************
+		_, _ = fmt.Fprintln(os.Stdout, "Committing target version")
+		if err := commitVersion(rc, data.Version); err != nil {
 			return fmt.Errorf("error committing target version: %w", err)
 		}
-		logger.Verbose(stdout)
+		if err := j.Done(journal.StepTargetCommitted); err != nil {
+			return err
+		}
 	}
 
-	// *************************************************
-	_, _ = fmt.Fprintln(os.Stdout, "Tagging")
-	{
-		tagName := "v" + targetVersion.String()
+	if !j.IsDone(journal.StepTagged) {
+		// *************************************************
+		_, _ = fmt.Fprintln(os.Stdout, "Tagging")
 		n := time.Now()
 		params := []string{"tag", "-m", "Version " + tagName + " of " + n.Format("2006/01/02 15:04:05"), tagName}
-		stdout, stderr, err = new(exec.PipedExec).
-			Command("git", params...).
-			WorkingDir(wd).
-			RunToStrings()
+		stdout, stderr, err := rc.Git(params...)
 		if err != nil {
 			logger.Verbose(stderr)
 
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
-
 			return fmt.Errorf("error tagging version: %w", err)
 		}
 		logger.Verbose(stdout)
-	}
-
-	// *************************************************
-	_, _ = fmt.Fprintln(os.Stdout, "Bumping version")
-	newVersion := currentVersion
-	{
-		newVersion.Minor++
-		newVersion.PreRelease = "SNAPSHOT"
-		if err := newVersion.Save(); err != nil {
-			return fmt.Errorf("error saving file 'version': %w", err)
+		if err := j.Done(journal.StepTagged); err != nil {
+			return err
 		}
 	}
 
-	// *************************************************
-	_, _ = fmt.Fprintln(os.Stdout, "Committing new version")
-	{
-		params := []string{"commit", "-a", "-m", "#scm-ver " + newVersion.String()}
-		stdout, stderr, err = new(exec.PipedExec).
-			Command("git", params...).
-			WorkingDir(wd).
-			RunToStrings()
-		if err != nil {
-			logger.Verbose(stderr)
-
-			if len(stderr) > 0 {
-				return errors.New(stderr)
-			}
+	if !j.IsDone(journal.StepNextCommitted) {
+		// *************************************************
+		_, _ = fmt.Fprintln(os.Stdout, "Bumping version")
+		if err := saveVersion(rc, data.NextVersion); err != nil {
+			return err
+		}
 
+		// *************************************************
+		_, _ = fmt.Fprintln(os.Stdout, "Committing new version")
+		if err := commitVersion(rc, data.NextVersion); err != nil {
 			return fmt.Errorf("error committing new version: %w", err)
 		}
+		if err := j.Done(journal.StepNextCommitted); err != nil {
+			return err
+		}
 	}
 
 	// *************************************************
 	_, _ = fmt.Fprintln(os.Stdout, "Pushing to origin")
 	{
+		var stdout, stderr string
 		params := []string{"push", "--follow-tags", "origin"}
-		err = utils.Retry(func() error {
-			stdout, stderr, err = new(exec.PipedExec).
-				Command("git", params...).
-				WorkingDir(wd).
-				RunToStrings()
+		err := utils.Retry(rc.Context(), func() error {
+			var err error
+			stdout, stderr, err = rc.Git(params...)
 			if err != nil {
-				if len(stderr) > 0 {
-					return errors.New(stderr)
-				}
-
 				return fmt.Errorf("error pushing to origin: %w", err)
 			}
 
@@ -178,6 +171,85 @@ func Release(wd string) error {
 		}
 		logger.Verbose(stdout)
 	}
+	rc.Result = &ReleaseResult{
+		Tag:         tagName,
+		Version:     data.Version,
+		NextVersion: data.NextVersion,
+	}
+
+	return j.Finish()
+}
+
+// rollbackRelease removes the commits and the tag made by interrupted qs r keeping other changes staged.
+// Nothing is rolled back if the tag is already pushed.
+func rollbackRelease(rc *gitcmds.RepoContext, j *journal.Journal) error {
+	var data releaseJournalData
+	if err := j.ReadData(&data); err != nil {
+		return err
+	}
+	tagName := "v" + data.Version
+
+	if j.IsDone(journal.StepNextCommitted) {
+		stdout, stderr, err := rc.Git("ls-remote", "--tags", "origin", "refs/tags/"+tagName)
+		if err != nil {
+			logger.Verbose(stderr)
+			return fmt.Errorf("error checking tag on origin: %w", err)
+		}
+		if len(strings.TrimSpace(stdout)) > 0 {
+			return fmt.Errorf("%s is already pushed to origin, nothing to roll back", tagName)
+		}
+	}
+
+	if j.IsDone(journal.StepTagged) {
+		if _, stderr, err := rc.Git("tag", "-d", tagName); err != nil {
+			logger.Verbose(stderr)
+			return fmt.Errorf("error deleting tag %s: %w", tagName, err)
+		}
+	}
+
+	head := j.Refs["HEAD"]
+	if len(head) == 0 {
+		return errors.New("original commit is not recorded")
+	}
+	if _, stderr, err := rc.Git("reset", "--soft", head); err != nil {
+		logger.Verbose(stderr)
+		return fmt.Errorf("error resetting to %s: %w", head, err)
+	}
+	if _, stderr, err := rc.Git("checkout", head, "--", data.File); err != nil {
+		logger.Verbose(stderr)
+		return fmt.Errorf("error restoring file '%s': %w", data.File, err)
+	}
+
+	return j.Finish()
+}
+
+func saveVersion(rc *gitcmds.RepoContext, version string) error {
+	if rc.Planned("write version " + version) {
+		return nil
+	}
+	v, err := utils.ReadVersion()
+	if err != nil {
+		return fmt.Errorf("error reading file 'version': %w", err)
+	}
+	if v.Version, err = semver.NewVersion(version); err != nil {
+		return err
+	}
+	if err := v.Save(); err != nil {
+		return fmt.Errorf("error saving file 'version': %w", err)
+	}
+
+	return nil
+}
+
+func commitVersion(rc *gitcmds.RepoContext, version string) error {
+	params := []string{"commit", "-a", "-m", "#scm-ver " + version}
+	stdout, stderr, err := rc.Git(params...)
+	if err != nil {
+		logger.Verbose(stderr)
+
+		return err
+	}
+	logger.Verbose(stdout)
 
 	return nil
 }
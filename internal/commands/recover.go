@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/untillpro/qs/gitcmds"
+	"github.com/untillpro/qs/internal/journal"
+)
+
+// Recover resumes, rolls back or discards the interrupted qs dev, pr, fork or r.
+// The journal of the interrupted command is printed only if action is empty.
+func Recover(rc *gitcmds.RepoContext, action RecoverAction) error {
+	j, err := gitcmds.LoadJournal(rc)
+	if err != nil {
+		return err
+	}
+	result := &RecoverResult{Journal: j, Action: action}
+	rc.Result = result
+	if j == nil {
+		fmt.Println("No interrupted qs command found")
+		result.Action = RecoverActionNone
+		return nil
+	}
+
+	fmt.Printf("qs %s started at %s is not completed\n", j.Command, j.StartedAt.Format(time.DateTime))
+	if len(j.Branch) > 0 {
+		fmt.Println("branch:", j.Branch)
+	}
+	if len(j.Steps) > 0 {
+		fmt.Println("completed steps:", strings.Join(j.Steps, ", "))
+	}
+	if action == RecoverActionNone {
+		fmt.Println("Run 'qs recover --resume' to complete it or 'qs recover --rollback' to undo it")
+		return nil
+	}
+
+	agree, err := rc.Prompt().Confirm(fmt.Sprintf("qs %s will be %s", j.Command, recoverActionDone[action]))
+	if err != nil {
+		return err
+	}
+	if !agree {
+		fmt.Print(msgOkSeeYou)
+		result.Action = RecoverActionNone
+		return nil
+	}
+
+	if action == RecoverActionDiscard {
+		return j.Finish()
+	}
+	handlers, ok := recoverHandlers[j.Command]
+	if !ok {
+		return fmt.Errorf("unknown command in journal: %s", j.Command)
+	}
+	handler := handlers.resume
+	if action == RecoverActionRollback {
+		handler = handlers.rollback
+	}
+
+	err = handler(rc, j)
+	// the resumed command reports its own result
+	if rc.Result != result {
+		result.Result = rc.Result
+		rc.Result = result
+	}
+
+	return err
+}
+
+var recoverHandlers = map[string]struct {
+	resume   func(rc *gitcmds.RepoContext, j *journal.Journal) error
+	rollback func(rc *gitcmds.RepoContext, j *journal.Journal) error
+}{
+	journal.CommandDev:     {resume: resumeDev, rollback: rollbackDev},
+	journal.CommandPR:      {resume: gitcmds.ResumePR, rollback: gitcmds.RollbackPR},
+	journal.CommandFork:    {resume: resumeFork, rollback: rollbackFork},
+	journal.CommandRelease: {resume: continueRelease, rollback: rollbackRelease},
+}
+
+var recoverActionDone = map[RecoverAction]string{
+	RecoverActionResume:   "resumed",
+	RecoverActionRollback: "rolled back",
+	RecoverActionDiscard:  "forgotten, the repository is kept as is",
+}
+
+// InterruptedHint returns the hint to run qs recover if the failed command left the journal
+func InterruptedHint(rc *gitcmds.RepoContext, cmdErr error) string {
+	if cmdErr == nil || errors.Is(cmdErr, journal.ErrInterrupted) {
+		return ""
+	}
+	// the journal is read even if the command is interrupted
+	j, err := gitcmds.LoadJournal(rc.WithoutCancel())
+	if err != nil || j == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("qs %s is not completed, run 'qs recover' to resume or roll it back", j.Command)
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package commands
+
+import (
+	"github.com/untillpro/qs/gitcmds"
+	"github.com/untillpro/qs/internal/journal"
+)
+
+// DevResult is the result of qs dev
+type DevResult struct {
+	Branch   string   `json:"branch"`
+	Notes    []string `json:"notes"`
+	IssueURL string   `json:"issueUrl,omitempty"`
+	// Created is false if the user declined to create the branch
+	Created bool `json:"created"`
+}
+
+// DeleteBranchesResult is the result of qs dev -d
+type DeleteBranchesResult struct {
+	Deleted []string `json:"deleted"`
+}
+
+// ReleaseResult is the result of qs r
+type ReleaseResult struct {
+	Tag     string `json:"tag"`
+	Version string `json:"version"`
+	// NextVersion is the bumped version committed after the tag
+	NextVersion string `json:"nextVersion"`
+}
+
+// ForkResult is the result of qs fork
+type ForkResult struct {
+	// Repo is the name of the forked repository
+	Repo string `json:"repo"`
+	// UpstreamURL is the URL of the forked repository, origin is the fork then
+	UpstreamURL string `json:"upstreamUrl"`
+}
+
+// ConfigValue is the result of qs config get, an effective config setting
+type ConfigValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ConfigListResult is the result of qs config list
+type ConfigListResult struct {
+	Settings []ConfigValue `json:"settings"`
+}
+
+// VersionResult is the result of qs version
+type VersionResult struct {
+	Version string `json:"version"`
+}
+
+// devJournalData is the state of qs dev kept in the journal
+type devJournalData struct {
+	Branch     string   `json:"branch"`
+	MainBranch string   `json:"mainBranch"`
+	Notes      []string `json:"notes"`
+	IssueURL   string   `json:"issueUrl,omitempty"`
+	// ParentRepo is set if the upstream remote is to be added
+	ParentRepo string `json:"parentRepo,omitempty"`
+	// Stashed is true if uncommitted changes are stashed before the branch is created
+	Stashed bool `json:"stashed"`
+}
+
+// forkJournalData is the state of qs fork kept in the journal
+type forkJournalData struct {
+	Repo string `json:"repo"`
+}
+
+// releaseJournalData is the state of qs r kept in the journal
+type releaseJournalData struct {
+	// File is the version file, "version" or "version.go"
+	File        string `json:"file"`
+	Version     string `json:"version"`
+	NextVersion string `json:"nextVersion"`
+}
+
+// RecoverAction is what qs recover does with the interrupted command
+type RecoverAction string
+
+// RecoverResult is the result of qs recover
+type RecoverResult struct {
+	// Journal is the journal of the interrupted command, nil if there is none
+	Journal *journal.Journal `json:"journal"`
+	// Action is the action taken, empty if nothing is done
+	Action RecoverAction `json:"action,omitempty"`
+	// Result is the result of the resumed command
+	Result any `json:"result,omitempty"`
+}
+
+// BackupsResult is the result of qs backups and qs undo: listed, restored or deleted backups
+type BackupsResult struct {
+	Backups []gitcmds.Backup `json:"backups"`
+}
@@ -5,15 +5,14 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/untillpro/goutils/exec"
 	"github.com/untillpro/qs/gitcmds"
 	notesPkg "github.com/untillpro/qs/internal/notes"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
-func U(cmd *cobra.Command, commitMessage string, wd string) error {
-	currentBranch, _, isMain, err := gitcmds.GetCurrentBranchInfo(wd)
+func U(cmd *cobra.Command, commitMessage string, rc *gitcmds.RepoContext) error {
+	currentBranch, _, isMain, err := gitcmds.GetCurrentBranchInfo(rc)
 	if err != nil {
 		return err
 	}
@@ -22,39 +21,36 @@ func U(cmd *cobra.Command, commitMessage string, wd string) error {
 	}
 
 	// Fetch notes from origin
-	_, _, err = new(exec.PipedExec).
-		Command("git", "fetch", "origin", "--force", utils.RefsNotes).
-		WorkingDir(wd).
-		RunToStrings()
+	_, _, err = rc.Git("fetch", "origin", "--force", utils.RefsNotes)
 	if err != nil {
 		logger.Verbose(fmt.Sprintf("Failed to fetch notes: %v", err))
 		// Continue anyway, as notes might exist locally
 	}
 
-	files := gitcmds.GetFilesForCommit(wd)
+	files := gitcmds.GetFilesForCommit(rc)
 	neetToCommit := len(files) > 0
 	// If there are files to commit, set commit message
 	if neetToCommit {
-		if err := setCommitMessage(cmd, commitMessage, wd, isMain); err != nil {
+		if err := setCommitMessage(cmd, commitMessage, rc, isMain); err != nil {
 			return err
 		}
 
 		// Ensure large file hook content is up to date
-		if err := gitcmds.EnsureLargeFileHookUpToDate(wd); err != nil {
+		if err := gitcmds.EnsureLargeFileHookUpToDate(rc); err != nil {
 			logger.Verbose("Error updating large file hook content:", err)
 		}
 	}
 
-	return gitcmds.Upload(cmd, wd, currentBranch, neetToCommit)
+	return gitcmds.Upload(cmd, rc, currentBranch, neetToCommit)
 }
 
 func setCommitMessage(
 	cmd *cobra.Command,
 	commitMessage string,
-	wd string,
+	rc *gitcmds.RepoContext,
 	isMainBranch bool,
 ) error {
-	_, branchType, err := gitcmds.GetBranchType(wd)
+	_, branchType, err := gitcmds.GetBranchType(rc)
 	if err != nil {
 		return err
 	}
@@ -68,8 +64,8 @@ func setCommitMessage(
 		switch {
 		case commitMessage == "":
 			return ErrEmptyCommitMessage
-		case len(commitMessage) < minimumCommitMessageLen:
-			return ErrShortCommitMessage
+		case len(commitMessage) < rc.Settings().Commit.MinMessageLength:
+			return fmt.Errorf("%w (minimum %d characters)", ErrShortCommitMessage, rc.Settings().Commit.MinMessageLength)
 		}
 	default:
 		if commitMessage == "" {
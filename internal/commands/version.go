@@ -3,87 +3,82 @@ package commands
 import (
 	"errors"
 	"fmt"
-	"runtime"
+	"runtime/debug"
 	"strings"
 
 	"github.com/untillpro/goutils/exec"
+	"github.com/untillpro/qs/gitcmds"
+	"github.com/untillpro/qs/internal/prompt"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 	"golang.org/x/mod/semver"
 )
 
-func Version() error {
+func Version(rc *gitcmds.RepoContext) error {
 	ver, err := GetInstalledQSVersion()
 	if err != nil {
 		return err
 	}
 	fmt.Printf("qs version %s\n", ver)
+	rc.Result = &VersionResult{Version: ver}
 
 	return nil
 }
 
-func CheckQsVer() bool {
+// CheckQsVer offers to stop if the installed qs is older than the last published version.
+// The check is skipped if either version can not be determined, e.g. qs is built locally or go is not installed.
+func CheckQsVer(p prompt.Prompter) bool {
 	installedVer, err := GetInstalledQSVersion()
-	if err != nil {
-		logger.Verbose(fmt.Sprintf("Error getting installed qs version: %v", err))
+	if err != nil || !semver.IsValid(installedVer) {
+		logger.Verbose(fmt.Sprintf("qs version check is skipped, installed version is unknown: %s %v", installedVer, err))
 
-		return false
+		return true
 	}
 
 	lastQSVersion := getLastQSVersion()
+	if !semver.IsValid(lastQSVersion) {
+		logger.Verbose("qs version check is skipped, last version is unknown")
+
+		return true
+	}
 	if semver.Compare(installedVer, lastQSVersion) < 0 {
 		fmt.Printf("Installed qs version %s is too old (last version is %s)\n", installedVer, lastQSVersion)
 		fmt.Println("You can install last version with:")
 		fmt.Println("-----------------------------------------")
 		fmt.Println("go install github.com/untillpro/qs@latest")
 		fmt.Println("-----------------------------------------")
-		fmt.Print("Ignore it and continue with current version(y/n)?")
-		var response string
-		_, _ = fmt.Scanln(&response)
+		proceed, err := p.Confirm("Ignore it and continue with current version")
+		if err != nil {
+			fmt.Println(err)
+		}
 
-		return response == "y"
+		return proceed
 	}
 
 	return true
 }
 
+// GetInstalledQSVersion returns the module version the running qs binary is built from, "(devel)" if it is built locally
 func GetInstalledQSVersion() (string, error) {
-	stdout, stderr, err := new(exec.PipedExec).
-		Command("go", "env", "GOPATH").
-		RunToStrings()
-	if err != nil {
-		return "", fmt.Errorf("GetInstalledVersion error: %s", stderr)
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", errors.New("build info is not available")
 	}
 
-	gopath := strings.TrimSpace(stdout)
-	if len(gopath) == 0 {
-		return "", errors.New("GetInstalledVersion error: \"GOPATH is not defined\"")
-	}
-	qsExe := "qs"
-	if runtime.GOOS == "windows" {
-		qsExe = "qs.exe"
-	}
-
-	stdout, stderr, err = new(exec.PipedExec).
-		Command("go", "version", "-m", gopath+"/bin/"+qsExe).
-		Command("grep", "-i", "-h", "mod.*github.com/untillpro/qs").
-		Command("gawk", "{print $3}").
-		RunToStrings()
-	if err != nil {
-		return "", fmt.Errorf("GetInstalledQSVersion error: %s", stderr)
-	}
-
-	return strings.TrimSpace(stdout), nil
+	return info.Main.Version, nil
 }
 
+// getLastQSVersion returns the last published qs version, empty if go is not installed or the lookup fails
 func getLastQSVersion() string {
 	stdout, stderr, err := new(exec.PipedExec).
 		Command("go", "list", "-m", "-versions", "github.com/untillpro/qs").
 		RunToStrings()
 	if err != nil {
 		logger.Verbose(fmt.Sprintf("getLastQSVersion error: %v", stderr))
+
+		return ""
 	}
 
-	arr := strings.Split(strings.TrimSpace(stdout), " ")
+	arr := strings.Fields(stdout)
 	if len(arr) == 0 {
 		return ""
 	}
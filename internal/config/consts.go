@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package config
+
+const (
+	// RepoFileName is the name of the repository config file located in the repository root
+	RepoFileName = ".qs.yaml"
+	// userFilePath is the path of the user config file relative to the user config dir
+	userFilePath = "qs/config.yaml"
+
+	envXDGConfigHome = "XDG_CONFIG_HOME"
+
+	filePerm   = 0644
+	dirPerm    = 0755
+	yamlIndent = 2
+
+	// listSeparator separates items of list settings given as strings, e.g. "*.wasm,*.png"
+	listSeparator = ","
+)
@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package config
+
+import "errors"
+
+var (
+	ErrUnknownKey = errors.New("unknown config key")
+	ErrNotInRepo  = errors.New("not a git repository")
+	ErrEmptyValue = errors.New("config value must not be empty")
+)
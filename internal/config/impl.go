@@ -0,0 +1,245 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Keys returns the keys of all settings in declaration order
+func (c *Config) Keys() []string {
+	settings := c.settings()
+	keys := make([]string, 0, len(settings))
+	for _, s := range settings {
+		keys = append(keys, s.key)
+	}
+
+	return keys
+}
+
+// Get returns the value of the setting
+func (c *Config) Get(key string) (string, error) {
+	s, err := c.setting(key)
+	if err != nil {
+		return "", err
+	}
+
+	return s.String(), nil
+}
+
+// Set parses the value according to the setting type and sets it
+func (c *Config) Set(key, value string) error {
+	s, err := c.setting(key)
+	if err != nil {
+		return err
+	}
+
+	return s.set(value)
+}
+
+// List returns all settings as "key=value" lines in declaration order
+func (c *Config) List() []string {
+	settings := c.settings()
+	lines := make([]string, 0, len(settings))
+	for _, s := range settings {
+		lines = append(lines, s.key+"="+s.String())
+	}
+
+	return lines
+}
+
+// UserFile returns the path of the user config file: $XDG_CONFIG_HOME/qs/config.yaml or ~/.config/qs/config.yaml
+func UserFile() (string, error) {
+	configHome := os.Getenv(envXDGConfigHome)
+	if len(configHome) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get user home dir: %w", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configHome, userFilePath), nil
+}
+
+// RepoFile returns the path of .qs.yaml in the root of the repository containing dir
+func RepoFile(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return filepath.Join(dir, RepoFileName), nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNotInRepo
+		}
+		dir = parent
+	}
+}
+
+// SaveValue validates the value of the setting and writes it to the config file keeping other settings of the file
+func SaveValue(file, key, value string) error {
+	scratch := Default()
+	s, err := scratch.setting(key)
+	if err != nil {
+		return err
+	}
+	if err := s.set(value); err != nil {
+		return err
+	}
+
+	doc := map[string]map[string]any{}
+	data, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	section, name, _ := strings.Cut(key, ".")
+	if doc[section] == nil {
+		doc[section] = map[string]any{}
+	}
+	doc[section][name] = s.value.Interface()
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(yamlIndent)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), dirPerm); err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, buf.Bytes(), filePerm)
+}
+
+// loadFile applies the settings of the yaml file, missing file is skipped
+func (c *Config) loadFile(file string) error {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	// reject misspelled keys instead of silently ignoring them
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// loadEnv applies the settings given by environment variables
+func (c *Config) loadEnv() error {
+	for _, s := range c.settings() {
+		value, ok := os.LookupEnv(s.env)
+		if !ok || len(value) == 0 {
+			continue
+		}
+		if err := s.set(value); err != nil {
+			return fmt.Errorf("invalid %s: %w", s.env, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) setting(key string) (setting, error) {
+	for _, s := range c.settings() {
+		if s.key == key {
+			return s, nil
+		}
+	}
+
+	return setting{}, fmt.Errorf("%w: %s", ErrUnknownKey, key)
+}
+
+// settings returns the leaves of the config addressed by "section.name" keys
+func (c *Config) settings() []setting {
+	var settings []setting
+	sections := reflect.ValueOf(c).Elem()
+	for i := range sections.NumField() {
+		sectionKey := sections.Type().Field(i).Tag.Get("yaml")
+		section := sections.Field(i)
+		for j := range section.NumField() {
+			field := section.Type().Field(j)
+			settings = append(settings, setting{
+				key:      sectionKey + "." + field.Tag.Get("yaml"),
+				env:      field.Tag.Get("env"),
+				required: field.Tag.Get("required") == "true",
+				value:    section.Field(j),
+			})
+		}
+	}
+
+	return settings
+}
+
+// String returns the value of the setting, lists are comma-separated
+func (s setting) String() string {
+	if list, ok := s.value.Interface().([]string); ok {
+		return strings.Join(list, listSeparator)
+	}
+
+	return fmt.Sprint(s.value.Interface())
+}
+
+func (s setting) set(value string) error {
+	switch s.value.Kind() {
+	case reflect.Slice:
+		list := []string{}
+		for _, item := range strings.Split(value, listSeparator) {
+			if item = strings.TrimSpace(item); len(item) > 0 {
+				list = append(list, item)
+			}
+		}
+		s.value.Set(reflect.ValueOf(list))
+	case reflect.Int:
+		i, err := strconv.Atoi(value)
+		if err != nil || i < 0 {
+			return fmt.Errorf("%s: non-negative integer expected, got %q", s.key, value)
+		}
+		s.value.SetInt(int64(i))
+	default:
+		if s.required && len(strings.TrimSpace(value)) == 0 {
+			return fmt.Errorf("%w: %s", ErrEmptyValue, s.key)
+		}
+		s.value.SetString(value)
+	}
+
+	return nil
+}
+
+// validate checks the settings which are not set by set, i.e. loaded from yaml files
+func (c *Config) validate() error {
+	for _, s := range c.settings() {
+		if s.required && len(strings.TrimSpace(s.String())) == 0 {
+			return fmt.Errorf("%w: %s", ErrEmptyValue, s.key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepo returns a repo subdir and isolates the user config dir and the environment
+func newTestRepo(t *testing.T) (repoDir string, userFile string) {
+	configHome := t.TempDir()
+	t.Setenv(envXDGConfigHome, configHome)
+	for _, key := range Default().Keys() {
+		s, _ := Default().setting(key)
+		t.Setenv(s.env, "")
+	}
+
+	repoDir = t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(repoDir, ".git"), dirPerm))
+	subDir := filepath.Join(repoDir, "sub")
+	require.NoError(t, os.Mkdir(subDir, dirPerm))
+
+	return subDir, filepath.Join(configHome, userFilePath)
+}
+
+func writeFile(t *testing.T, file, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), dirPerm))
+	require.NoError(t, os.WriteFile(file, []byte(content), filePerm))
+}
+
+func TestLoad_Layers(t *testing.T) {
+	require := require.New(t)
+	dir, userFile := newTestRepo(t)
+
+	writeFile(t, userFile, "branch:\n  dev_suffix: -feature\n  pr_suffix: -review\ncommit:\n  min_message_length: 20\n")
+	writeFile(t, filepath.Join(dir, "..", RepoFileName), "branch:\n  pr_suffix: -merge\nretry:\n  max_retries: 5\nhook:\n  exclude: ['*.png']\n")
+	t.Setenv("QS_MAX_RETRIES", "1")
+
+	cfg, err := Load(dir, []string{"commit.min_message_length=12"})
+	require.NoError(err)
+	require.Equal("-feature", cfg.Branch.DevSuffix)
+	require.Equal("-merge", cfg.Branch.PRSuffix)
+	require.Equal(1, cfg.Retry.MaxRetries)
+	require.Equal(12, cfg.Commit.MinMessageLength)
+	require.Equal(Default().PR, cfg.PR)
+	require.Equal([]string{"*.png"}, cfg.Hook.Exclude)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Run("unknown key in file", func(t *testing.T) {
+		dir, userFile := newTestRepo(t)
+		writeFile(t, userFile, "branch:\n  dev_sufix: -feature\n")
+
+		_, err := Load(dir, nil)
+		require.ErrorContains(t, err, "dev_sufix")
+	})
+
+	t.Run("invalid env value", func(t *testing.T) {
+		dir, _ := newTestRepo(t)
+		t.Setenv("QS_MAX_RETRIES", "many")
+
+		_, err := Load(dir, nil)
+		require.ErrorContains(t, err, "QS_MAX_RETRIES")
+	})
+
+	t.Run("invalid override", func(t *testing.T) {
+		dir, _ := newTestRepo(t)
+
+		_, err := Load(dir, []string{"branch.dev_suffix"})
+		require.Error(t, err)
+		_, err = Load(dir, []string{"branch.unknown=1"})
+		require.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("empty branch suffix", func(t *testing.T) {
+		dir, userFile := newTestRepo(t)
+
+		_, err := Load(dir, []string{"branch.dev_suffix="})
+		require.ErrorIs(t, err, ErrEmptyValue)
+		_, err = Load(dir, []string{"branch.pr_suffix= "})
+		require.ErrorIs(t, err, ErrEmptyValue)
+		require.ErrorIs(t, SaveValue(userFile, "branch.pr_suffix", ""), ErrEmptyValue)
+		require.NoFileExists(t, userFile)
+
+		writeFile(t, userFile, "branch:\n  dev_suffix: \"\"\n")
+		_, err = Load(dir, nil)
+		require.ErrorIs(t, err, ErrEmptyValue)
+		require.ErrorContains(t, err, "branch.dev_suffix")
+	})
+}
+
+func TestGetSetList(t *testing.T) {
+	require := require.New(t)
+	cfg := Default()
+
+	require.NoError(cfg.Set("hook.max_files", "50"))
+	value, err := cfg.Get("hook.max_files")
+	require.NoError(err)
+	require.Equal("50", value)
+
+	require.Error(cfg.Set("hook.max_files", "-1"))
+	_, err = cfg.Get("hook.unknown")
+	require.ErrorIs(err, ErrUnknownKey)
+
+	require.NoError(cfg.Set("hook.exclude", "*.wasm, dist/*,"))
+	require.Equal([]string{"*.wasm", "dist/*"}, cfg.Hook.Exclude)
+
+	list := cfg.List()
+	require.Len(list, len(cfg.Keys()))
+	require.Equal("branch.dev_suffix=-dev", list[0])
+	require.Contains(list, "hook.max_files=50")
+	require.Contains(list, "hook.exclude=*.wasm,dist/*")
+}
+
+func TestSaveValue(t *testing.T) {
+	require := require.New(t)
+	dir, _ := newTestRepo(t)
+	repoFile, err := RepoFile(dir)
+	require.NoError(err)
+
+	require.NoError(SaveValue(repoFile, "branch.dev_suffix", "-feature"))
+	require.NoError(SaveValue(repoFile, "hook.max_files", "50"))
+	require.ErrorIs(SaveValue(repoFile, "hook.unknown", "1"), ErrUnknownKey)
+	require.Error(SaveValue(repoFile, "hook.max_files", "many"))
+
+	cfg, err := Load(dir, nil)
+	require.NoError(err)
+	require.Equal("-feature", cfg.Branch.DevSuffix)
+	require.Equal(50, cfg.Hook.MaxFiles)
+
+	_, err = RepoFile(t.TempDir())
+	require.ErrorIs(err, ErrNotInRepo)
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Default returns the built-in defaults
+func Default() *Config {
+	return &Config{
+		Branch: BranchConfig{
+			DevSuffix:     "-dev",
+			PRSuffix:      "-pr",
+			MaxNameLength: 100,
+		},
+		Commit: CommitConfig{MinMessageLength: 8},
+		PR:     PRConfig{MinTitleLength: 8},
+		Hook: HookConfig{
+			MaxTotalSize: 100000,
+			MaxFiles:     200,
+			Exclude:      []string{"*.wasm"},
+		},
+		Jira: JiraConfig{URL: "https://untill.atlassian.net"},
+		Retry: RetryConfig{
+			MaxRetries: 3,
+			DelayMs:    2000,
+			MaxDelayMs: 30000,
+		},
+		Status: StatusConfig{PRTimeoutMs: 3000},
+	}
+}
+
+// Load returns the config of the repository containing dir. Layers are applied in order:
+// built-in defaults, the user config file, the repository .qs.yaml, environment variables
+// and the overrides given as "key=value" (e.g. from command line flags).
+func Load(dir string, overrides []string) (*Config, error) {
+	cfg := Default()
+
+	userFile, err := UserFile()
+	if err != nil {
+		return nil, err
+	}
+	files := []string{userFile}
+	if repoFile, err := RepoFile(dir); err == nil {
+		files = append(files, repoFile)
+	}
+	for _, file := range files {
+		if err := cfg.loadFile(file); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	for _, override := range overrides {
+		key, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid config override %q, key=value expected", override)
+		}
+		if err := cfg.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
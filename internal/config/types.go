@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package config
+
+import "reflect"
+
+// Config holds the qs settings.
+// Every setting is addressed by the "section.name" key built from the yaml tags, e.g. "branch.dev_suffix",
+// and may be overridden by the environment variable from the env tag.
+// Settings tagged with required:"true" can not be empty.
+type Config struct {
+	Branch   BranchConfig   `yaml:"branch"`
+	Commit   CommitConfig   `yaml:"commit"`
+	PR       PRConfig       `yaml:"pr"`
+	Hook     HookConfig     `yaml:"hook"`
+	Jira     JiraConfig     `yaml:"jira"`
+	YouTrack YouTrackConfig `yaml:"youtrack"`
+	Retry    RetryConfig    `yaml:"retry"`
+	Cache    CacheConfig    `yaml:"cache"`
+	Hosting  HostingConfig  `yaml:"hosting"`
+	Status   StatusConfig   `yaml:"status"`
+}
+
+type BranchConfig struct {
+	// DevSuffix ends the names of dev branches, empty suffix would make every branch a dev one
+	DevSuffix string `yaml:"dev_suffix" env:"QS_DEV_SUFFIX" required:"true"`
+	// PRSuffix ends the names of PR branches
+	PRSuffix string `yaml:"pr_suffix" env:"QS_PR_SUFFIX" required:"true"`
+	// MaxNameLength limits the length of dev branch names built from issue titles, suffix excluded
+	MaxNameLength int `yaml:"max_name_length" env:"QS_MAX_BRANCH_NAME_LENGTH"`
+}
+
+type CommitConfig struct {
+	// MinMessageLength is the minimum length of commit messages on PR branches
+	MinMessageLength int `yaml:"min_message_length" env:"QS_MIN_COMMIT_MESSAGE_LENGTH"`
+}
+
+type PRConfig struct {
+	// MinTitleLength is the minimum length of pull request titles entered manually
+	MinTitleLength int `yaml:"min_title_length" env:"QS_MIN_PR_TITLE_LENGTH"`
+}
+
+type HookConfig struct {
+	// MaxTotalSize is the maximum total size in bytes of new files in a commit
+	MaxTotalSize int `yaml:"max_total_size" env:"QS_HOOK_MAX_TOTAL_SIZE"`
+	// MaxFiles is the maximum number of new files in a commit
+	MaxFiles int `yaml:"max_files" env:"QS_HOOK_MAX_FILES"`
+	// Exclude lists globs of files not counted by the check, matched against the path and the base name
+	Exclude []string `yaml:"exclude" env:"QS_HOOK_EXCLUDE"`
+}
+
+type JiraConfig struct {
+	// URL is the Jira site used when it can not be derived from the ticket URL
+	URL string `yaml:"url" env:"JIRA_URL"`
+}
+
+type YouTrackConfig struct {
+	// URL is the YouTrack site, e.g. "https://example.youtrack.cloud", issue URLs of other sites are not fetched
+	URL string `yaml:"url" env:"YOUTRACK_URL"`
+}
+
+type RetryConfig struct {
+	// MaxRetries is the number of retries of failed network operations
+	MaxRetries int `yaml:"max_retries" env:"QS_MAX_RETRIES"`
+	// DelayMs is the initial delay between retries
+	DelayMs int `yaml:"delay_ms" env:"QS_RETRY_DELAY_MS"`
+	// MaxDelayMs limits the exponentially growing delay between retries
+	MaxDelayMs int `yaml:"max_delay_ms" env:"QS_MAX_RETRY_DELAY_MS"`
+}
+
+type CacheConfig struct {
+	// TTLHours is how long the parent repo and the user login are kept in the local git config, 0 disables it
+	TTLHours int `yaml:"ttl_hours" env:"QS_CACHE_TTL_HOURS"`
+}
+
+type HostingConfig struct {
+	// Kind lists "host=kind" entries for self-hosted services on custom domains, e.g. "git.company.com=gitlab".
+	// An entry without host applies to all hosts not recognized by name. Kinds are github, gitlab, gitea and forgejo
+	Kind []string `yaml:"kind" env:"QS_HOSTING"`
+}
+
+type StatusConfig struct {
+	// PRTimeoutMs limits the lookup of the open pull request shown by qs and qs status, 0 disables the lookup
+	PRTimeoutMs int `yaml:"pr_timeout_ms" env:"QS_STATUS_PR_TIMEOUT_MS"`
+}
+
+// setting is a single leaf of Config
+type setting struct {
+	key      string
+	env      string
+	required bool
+	value    reflect.Value
+}
@@ -0,0 +1,23 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package hosting
+
+const (
+	PRStateOpen   PRState = "open"
+	PRStateMerged PRState = "merged"
+)
+
+const (
+	ReviewApproved         ReviewState = "approved"
+	ReviewChangesRequested ReviewState = "changes requested"
+	ReviewRequired         ReviewState = "review required"
+)
+
+const (
+	ChecksSuccess ChecksState = "success"
+	ChecksFailure ChecksState = "failure"
+	ChecksPending ChecksState = "pending"
+)
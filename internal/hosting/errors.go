@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package hosting
+
+import "errors"
+
+var (
+	ErrNotFound      = errors.New("not found on the hosting")
+	ErrTokenNotFound = errors.New("hosting API token not found")
+	ErrAuthFailed    = errors.New("authentication failed")
+)
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitea
+
+import (
+	"time"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+const (
+	// APIPath is the REST API root relative to the Gitea/Forgejo instance URL
+	APIPath = "/api/v1"
+
+	envGiteaToken   = "GITEA_TOKEN"
+	envForgejoToken = "FORGEJO_TOKEN"
+
+	httpTimeout    = 30 * time.Second
+	prListPageSize = 50
+
+	prStateOpen   = "open"
+	prStateClosed = "closed"
+
+	// draftTitlePrefix marks a pull request as work in progress, Gitea has no draft flag
+	draftTitlePrefix = "WIP: "
+
+	reviewStateApproved       = "APPROVED"
+	reviewStateRequestChanges = "REQUEST_CHANGES"
+	reviewStateRequestReview  = "REQUEST_REVIEW"
+)
+
+// commitStates maps the combined commit statuses
+var commitStates = map[string]hosting.ChecksState{
+	"success": hosting.ChecksSuccess,
+	"failure": hosting.ChecksFailure,
+	"error":   hosting.ChecksFailure,
+	"warning": hosting.ChecksFailure,
+	"pending": hosting.ChecksPending,
+}
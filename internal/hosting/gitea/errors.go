@@ -0,0 +1,10 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitea
+
+import "errors"
+
+var errAlreadyExists = errors.New("already exists")
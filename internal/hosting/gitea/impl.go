@@ -0,0 +1,272 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/utils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+func (c *Client) GetUserLogin(ctx context.Context) (string, error) {
+	var u user
+	if err := c.do(ctx, http.MethodGet, "/user", nil, &u); err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return u.Login, nil
+}
+
+func (c *Client) GetParentRepo(ctx context.Context, repoFullName string) (string, error) {
+	repo, err := c.getRepo(ctx, repoFullName)
+	if err != nil {
+		return "", err
+	}
+	if repo.Parent == nil {
+		return "", nil
+	}
+
+	return repo.Parent.FullName, nil
+}
+
+func (c *Client) VerifyRepoExists(ctx context.Context, repoFullName string) error {
+	if _, err := c.getRepo(ctx, repoFullName); err != nil {
+		return fmt.Errorf("repository %s not accessible: %w", repoFullName, err)
+	}
+
+	return nil
+}
+
+func (c *Client) ForkRepo(ctx context.Context, repoFullName string) error {
+	err := c.do(ctx, http.MethodPost, "/repos/"+repoFullName+"/forks", struct{}{}, nil)
+	if errors.Is(err, errAlreadyExists) {
+		logger.Verbose(fmt.Sprintf("Fork of %s already exists", repoFullName))
+
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fork repository %s: %w", repoFullName, err)
+	}
+
+	return nil
+}
+
+func (c *Client) FindPR(ctx context.Context, repoFullName, headOwner, headBranch string, state hosting.PRState) (*hosting.PRInfo, error) {
+	// merged pull requests are the closed ones with merged flag set
+	apiState := prStateOpen
+	if state == hosting.PRStateMerged {
+		apiState = prStateClosed
+	}
+
+	// Gitea can not filter pull requests by head, so all pages are scanned
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("state", apiState)
+		query.Set("page", strconv.Itoa(page))
+		query.Set("limit", strconv.Itoa(prListPageSize))
+
+		var prs []pullRequest
+		if err := c.do(ctx, http.MethodGet, "/repos/"+repoFullName+"/pulls?"+query.Encode(), nil, &prs); err != nil {
+			return nil, fmt.Errorf("failed to list PRs for branch %s: %w", headBranch, err)
+		}
+
+		for _, pr := range prs {
+			if pr.Head.Ref != headBranch || pr.Head.Repo == nil || !strings.EqualFold(pr.Head.Repo.Owner.Login, headOwner) {
+				continue
+			}
+			if state == hosting.PRStateMerged && !pr.Merged {
+				continue
+			}
+
+			return &hosting.PRInfo{
+				Number: pr.Number,
+				Title:  strings.TrimSpace(pr.Title),
+				URL:    strings.TrimSpace(pr.HTMLURL),
+			}, nil
+		}
+
+		if len(prs) < prListPageSize {
+			return nil, nil
+		}
+	}
+}
+
+func (c *Client) CreatePR(ctx context.Context, repoFullName, headOwner, headBranch, baseBranch, title, body string, draft bool) (*hosting.PRInfo, error) {
+	if draft {
+		title = draftTitlePrefix + title
+	}
+	req := createPullRequest{
+		Title: title,
+		Head:  headOwner + ":" + headBranch,
+		Base:  baseBranch,
+		Body:  body,
+	}
+
+	var pr pullRequest
+	if err := c.do(ctx, http.MethodPost, "/repos/"+repoFullName+"/pulls", req, &pr); err != nil {
+		return nil, fmt.Errorf("failed to create PR for branch %s: %w", headBranch, err)
+	}
+
+	return &hosting.PRInfo{
+		Number: pr.Number,
+		Title:  pr.Title,
+		URL:    pr.HTMLURL,
+	}, nil
+}
+
+func (c *Client) GetPRStatus(ctx context.Context, repoFullName string, number int) (*hosting.PRStatus, error) {
+	prPath := "/repos/" + repoFullName + "/pulls/" + strconv.Itoa(number)
+	var pr pullRequest
+	if err := c.do(ctx, http.MethodGet, prPath, nil, &pr); err != nil {
+		return nil, fmt.Errorf("failed to get PR #%d: %w", number, err)
+	}
+	var reviews []pullReview
+	if err := c.do(ctx, http.MethodGet, prPath+"/reviews", nil, &reviews); err != nil {
+		return nil, fmt.Errorf("failed to get reviews of PR #%d: %w", number, err)
+	}
+	var combined combinedStatus
+	if err := c.do(ctx, http.MethodGet, "/repos/"+repoFullName+"/commits/"+pr.Head.SHA+"/status", nil, &combined); err != nil {
+		return nil, fmt.Errorf("failed to get checks of PR #%d: %w", number, err)
+	}
+
+	status := &hosting.PRStatus{Review: reviewState(reviews)}
+	if combined.TotalCount > 0 {
+		status.Checks = commitStates[combined.State]
+	}
+
+	return status, nil
+}
+
+// reviewState returns the review decision by the latest reviews of each reviewer, the reviews are sorted by time
+func reviewState(reviews []pullReview) hosting.ReviewState {
+	latest := make(map[string]string)
+	for _, r := range reviews {
+		if r.Dismissed || r.Stale {
+			continue
+		}
+		switch r.State {
+		case reviewStateApproved, reviewStateRequestChanges, reviewStateRequestReview:
+			latest[r.User.Login] = r.State
+		}
+	}
+
+	var state hosting.ReviewState
+	for _, s := range latest {
+		switch s {
+		case reviewStateRequestChanges:
+			return hosting.ReviewChangesRequested
+		case reviewStateRequestReview:
+			state = hosting.ReviewRequired
+		case reviewStateApproved:
+			if state == "" {
+				state = hosting.ReviewApproved
+			}
+		}
+	}
+
+	return state
+}
+
+func (c *Client) GetIssueTitle(ctx context.Context, repoFullName, issueNumber string) (string, error) {
+	var i issue
+	if err := c.do(ctx, http.MethodGet, "/repos/"+repoFullName+"/issues/"+issueNumber, nil, &i); err != nil {
+		return "", fmt.Errorf("failed to retrieve issue data for %s#%s: %w", repoFullName, issueNumber, err)
+	}
+
+	return i.Title, nil
+}
+
+// LinkBranchToIssue does nothing: Gitea has no notion of branches linked to an issue,
+// the issue number the branch name starts with is the only relation.
+func (c *Client) LinkBranchToIssue(_ context.Context, issueRepoFullName, issueNumber, _, branchName string) error {
+	logger.Verbose(fmt.Sprintf("Branch %s is related to %s#%s by its name", branchName, issueRepoFullName, issueNumber))
+
+	return nil
+}
+
+func (c *Client) getRepo(ctx context.Context, repoFullName string) (*repository, error) {
+	var repo repository
+	if err := c.do(ctx, http.MethodGet, "/repos/"+repoFullName, nil, &repo); err != nil {
+		return nil, fmt.Errorf("failed to get repository %s: %w", repoFullName, err)
+	}
+
+	return &repo, nil
+}
+
+// do sends a request to the REST API path and decodes the JSON response into respBody if it is not nil
+func (c *Client) do(ctx context.Context, method, path string, reqBody any, respBody any) error {
+	token, err := c.token()
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if reqBody != nil {
+		bb, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(bb)
+	}
+
+	reqURL := c.apiURL + path
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+token)
+	req.Header.Set("Accept", "application/json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	logger.Verbose(method, reqURL)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var apiErr apiError
+		_ = json.Unmarshal(respBytes, &apiErr)
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return utils.HTTPStatusError(resp.StatusCode, fmt.Errorf("%s: %w", apiErr.Message, hosting.ErrNotFound))
+		case http.StatusConflict:
+			return utils.HTTPStatusError(resp.StatusCode, fmt.Errorf("%s: %w", apiErr.Message, errAlreadyExists))
+		}
+
+		return utils.HTTPStatusError(resp.StatusCode, errors.New(resp.Status+": "+apiErr.Message))
+	}
+
+	if respBody == nil || len(respBytes) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBytes, respBody); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
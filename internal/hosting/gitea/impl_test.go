@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL+APIPath, hosting.StaticToken("test-token"))
+}
+
+func TestGetParentRepo(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("token test-token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/api/v1/repos/fork-account/qs":
+			_, _ = w.Write([]byte(`{"full_name":"fork-account/qs","parent":{"full_name":"untillpro/qs"}}`))
+		case "/api/v1/repos/untillpro/qs":
+			_, _ = w.Write([]byte(`{"full_name":"untillpro/qs","parent":null}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"GetRepositoryByName"}`))
+		}
+	})
+
+	parent, err := client.GetParentRepo(context.Background(), "fork-account/qs")
+	require.NoError(err)
+	require.Equal("untillpro/qs", parent)
+
+	parent, err = client.GetParentRepo(context.Background(), "untillpro/qs")
+	require.NoError(err)
+	require.Empty(parent)
+
+	err = client.VerifyRepoExists(context.Background(), "untillpro/unknown")
+	require.ErrorIs(err, hosting.ErrNotFound)
+}
+
+func TestForkRepo_AlreadyExists(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/repos/untillpro/qs/forks", r.URL.Path)
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message":"repository is already forked by user"}`))
+	})
+
+	require.NoError(t, client.ForkRepo(context.Background(), "untillpro/qs"))
+}
+
+func TestFindPR(t *testing.T) {
+	require := require.New(t)
+
+	// first page is full of other pull requests, the merged one is on the second page
+	otherPRs := make([]map[string]any, prListPageSize)
+	for i := range otherPRs {
+		otherPRs[i] = map[string]any{
+			"number": i + 1,
+			"head":   map[string]any{"ref": "other-pr", "repo": map[string]any{"owner": map[string]any{"login": "fork-account"}}},
+		}
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("/api/v1/repos/untillpro/qs/pulls", r.URL.Path)
+		switch r.URL.Query().Get("state") + r.URL.Query().Get("page") {
+		case "open1":
+			_, _ = w.Write([]byte(`[]`))
+		case "closed1":
+			require.NoError(json.NewEncoder(w).Encode(otherPRs))
+		case "closed2":
+			_, _ = w.Write([]byte(`[
+				{"number":51,"title":"Closed","merged":false,"head":{"ref":"feature-pr","repo":{"owner":{"login":"fork-account"}}}},
+				{"number":52,"title":"Other fork","merged":true,"head":{"ref":"feature-pr","repo":{"owner":{"login":"someone"}}}},
+				{"number":53,"title":" Merged ","html_url":"https://gitea.example.com/untillpro/qs/pulls/53","merged":true,"head":{"ref":"feature-pr","repo":{"owner":{"login":"Fork-Account"}}}}
+			]`))
+		default:
+			require.Fail("unexpected request", r.URL.String())
+		}
+	})
+
+	pr, err := client.FindPR(context.Background(), "untillpro/qs", "fork-account", "feature-pr", hosting.PRStateOpen)
+	require.NoError(err)
+	require.Nil(pr)
+
+	pr, err = client.FindPR(context.Background(), "untillpro/qs", "fork-account", "feature-pr", hosting.PRStateMerged)
+	require.NoError(err)
+	require.Equal(&hosting.PRInfo{Number: 53, Title: "Merged", URL: "https://gitea.example.com/untillpro/qs/pulls/53"}, pr)
+}
+
+func TestCreatePR(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(http.MethodPost, r.Method)
+		require.Equal("/api/v1/repos/untillpro/qs/pulls", r.URL.Path)
+
+		var req createPullRequest
+		require.NoError(json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(createPullRequest{Title: "WIP: Fix bug", Head: "fork-account:fix-bug-pr", Base: "main", Body: "body"}, req)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number":7,"title":"WIP: Fix bug","html_url":"https://gitea.example.com/untillpro/qs/pulls/7"}`))
+	})
+
+	pr, err := client.CreatePR(context.Background(), "untillpro/qs", "fork-account", "fix-bug-pr", "main", "Fix bug", "body", true)
+	require.NoError(err)
+	require.Equal(7, pr.Number)
+	require.Equal("https://gitea.example.com/untillpro/qs/pulls/7", pr.URL)
+}
+
+func TestGetPRStatus(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/org/qs/pulls/7":
+			_, _ = w.Write([]byte(`{"number":7,"head":{"ref":"feature-pr","sha":"abc123"}}`))
+		case "/api/v1/repos/org/qs/pulls/7/reviews":
+			_, _ = w.Write([]byte(`[
+				{"state":"REQUEST_CHANGES","user":{"login":"alice"}},
+				{"state":"COMMENT","user":{"login":"bob"}},
+				{"state":"APPROVED","user":{"login":"alice"}},
+				{"state":"REQUEST_CHANGES","user":{"login":"carol"},"dismissed":true}
+			]`))
+		case "/api/v1/repos/org/qs/commits/abc123/status":
+			_, _ = w.Write([]byte(`{"state":"success","total_count":2}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	status, err := client.GetPRStatus(context.Background(), "org/qs", 7)
+	require.NoError(err)
+	require.Equal(&hosting.PRStatus{Review: hosting.ReviewApproved, Checks: hosting.ChecksSuccess}, status)
+}
+
+func TestGetIssueTitle(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/repos/untillpro/qs/issues/42", r.URL.Path)
+		_, _ = w.Write([]byte(`{"title":"Fix bug"}`))
+	})
+
+	title, err := client.GetIssueTitle(context.Background(), "untillpro/qs", "42")
+	require.NoError(t, err)
+	require.Equal(t, "Fix bug", title)
+}
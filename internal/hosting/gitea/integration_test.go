@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitea
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// TestIntegration runs against a real Gitea/Forgejo instance, e.g. a local container:
+//
+//	docker run -d -p 3000:3000 -e GITEA__security__INSTALL_LOCK=true gitea/gitea
+//
+// Set GITEA_TEST_URL (e.g. http://localhost:3000), GITEA_TEST_TOKEN and GITEA_TEST_REPO (owner/repo).
+// If GITEA_TEST_BRANCH is set, a pull request from that branch of the repo to its main branch is created.
+func TestIntegration(t *testing.T) {
+	require := require.New(t)
+
+	instanceURL := os.Getenv("GITEA_TEST_URL")
+	token := os.Getenv("GITEA_TEST_TOKEN")
+	repo := os.Getenv("GITEA_TEST_REPO")
+	if instanceURL == "" || token == "" || repo == "" {
+		t.Skip("Gitea instance not set, skipping test")
+	}
+
+	client := New(instanceURL+APIPath, hosting.StaticToken(token))
+
+	login, err := client.GetUserLogin(context.Background())
+	require.NoError(err)
+	require.NotEmpty(login)
+
+	require.NoError(client.VerifyRepoExists(context.Background(), repo))
+	require.ErrorIs(client.VerifyRepoExists(context.Background(), repo+"-unknown"), hosting.ErrNotFound)
+
+	_, err = client.GetIssueTitle(context.Background(), repo, "999999")
+	require.ErrorIs(err, hosting.ErrNotFound)
+
+	branch := os.Getenv("GITEA_TEST_BRANCH")
+	if branch == "" {
+		return
+	}
+	owner := strings.Split(repo, "/")[0]
+
+	pr, err := client.FindPR(context.Background(), repo, owner, branch, hosting.PRStateOpen)
+	require.NoError(err)
+	if pr == nil {
+		pr, err = client.CreatePR(context.Background(), repo, owner, branch, "main", "qs integration test", "", true)
+		require.NoError(err)
+	}
+
+	found, err := client.FindPR(context.Background(), repo, owner, branch, hosting.PRStateOpen)
+	require.NoError(err)
+	require.Equal(pr.Number, found.Number)
+}
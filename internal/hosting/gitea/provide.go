@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitea
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// New returns a client of the Gitea/Forgejo API located at apiURL, e.g. "https://codeberg.org/api/v1"
+func New(apiURL string, token hosting.TokenFunc) *Client {
+	return &Client{
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// DefaultToken returns a TokenFunc which takes the token from GITEA_TOKEN or FORGEJO_TOKEN
+func DefaultToken() hosting.TokenFunc {
+	return func() (string, error) {
+		for _, env := range []string{envGiteaToken, envForgejoToken} {
+			if token := strings.TrimSpace(os.Getenv(env)); len(token) > 0 {
+				return token, nil
+			}
+		}
+
+		return "", fmt.Errorf("%w: set %s environment variable", hosting.ErrTokenNotFound, envGiteaToken)
+	}
+}
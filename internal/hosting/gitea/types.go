@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitea
+
+import (
+	"net/http"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// Client is a Gitea/Forgejo REST API client implementing hosting.HostingProvider
+type Client struct {
+	apiURL     string
+	token      hosting.TokenFunc
+	httpClient *http.Client
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type repository struct {
+	FullName string `json:"full_name"`
+	Parent   *struct {
+		FullName string `json:"full_name"`
+	} `json:"parent"`
+}
+
+type pullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	Merged  bool   `json:"merged"`
+	Head    struct {
+		Ref  string `json:"ref"`
+		SHA  string `json:"sha"`
+		Repo *struct {
+			Owner struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+		} `json:"repo"`
+	} `json:"head"`
+}
+
+type pullReview struct {
+	State     string `json:"state"`
+	Dismissed bool   `json:"dismissed"`
+	Stale     bool   `json:"stale"`
+	User      user   `json:"user"`
+}
+
+type combinedStatus struct {
+	State      string `json:"state"`
+	TotalCount int    `json:"total_count"`
+}
+
+type createPullRequest struct {
+	Title string `json:"title"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Body  string `json:"body"`
+}
+
+type issue struct {
+	Title string `json:"title"`
+}
+
+type apiError struct {
+	Message string `json:"message"`
+}
+
+var _ hosting.HostingProvider = (*Client)(nil)
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package github
+
+import (
+	"time"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+const (
+	// DefaultAPIURL is the REST API root of github.com
+	DefaultAPIURL = "https://api.github.com"
+	// DefaultHost is the host of github.com, other hosts are GitHub Enterprise Server instances
+	DefaultHost = "github.com"
+
+	enterpriseAPIPath     = "/api/v3"
+	enterpriseGraphQLPath = "/api/graphql"
+
+	envGhToken               = "GH_TOKEN"
+	envGithubToken           = "GITHUB_TOKEN"
+	envGhEnterpriseToken     = "GH_ENTERPRISE_TOKEN"
+	envGithubEnterpriseToken = "GITHUB_ENTERPRISE_TOKEN"
+
+	httpTimeout    = 30 * time.Second
+	prListPageSize = 100
+	apiVersion     = "2022-11-28"
+
+	linkBranchMutation = `mutation($issueId: ID!, $repositoryId: ID!, $name: String!, $oid: GitObjectID!) {
+  createLinkedBranch(input: {issueId: $issueId, repositoryId: $repositoryId, name: $name, oid: $oid}) {
+    linkedBranch { id }
+  }
+}`
+
+	prStatusQuery = `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewDecision
+      commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+    }
+  }
+}`
+)
+
+// reviewDecisions maps the GraphQL review decisions of pull requests
+var reviewDecisions = map[string]hosting.ReviewState{
+	"APPROVED":          hosting.ReviewApproved,
+	"CHANGES_REQUESTED": hosting.ReviewChangesRequested,
+	"REVIEW_REQUIRED":   hosting.ReviewRequired,
+}
+
+// rollupStates maps the GraphQL states of the status check rollup
+var rollupStates = map[string]hosting.ChecksState{
+	"SUCCESS":  hosting.ChecksSuccess,
+	"FAILURE":  hosting.ChecksFailure,
+	"ERROR":    hosting.ChecksFailure,
+	"PENDING":  hosting.ChecksPending,
+	"EXPECTED": hosting.ChecksPending,
+}
@@ -0,0 +1,268 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/utils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+func (c *Client) GetUserLogin(ctx context.Context) (string, error) {
+	var u user
+	if err := c.do(ctx, http.MethodGet, "/user", nil, &u); err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return u.Login, nil
+}
+
+func (c *Client) GetParentRepo(ctx context.Context, repoFullName string) (string, error) {
+	repo, err := c.getRepo(ctx, repoFullName)
+	if err != nil {
+		return "", err
+	}
+	if repo.Parent == nil {
+		return "", nil
+	}
+
+	return repo.Parent.FullName, nil
+}
+
+func (c *Client) VerifyRepoExists(ctx context.Context, repoFullName string) error {
+	if _, err := c.getRepo(ctx, repoFullName); err != nil {
+		return fmt.Errorf("repository %s not accessible: %w", repoFullName, err)
+	}
+
+	return nil
+}
+
+func (c *Client) ForkRepo(ctx context.Context, repoFullName string) error {
+	if err := c.do(ctx, http.MethodPost, "/repos/"+repoFullName+"/forks", struct{}{}, nil); err != nil {
+		return fmt.Errorf("failed to fork repository %s: %w", repoFullName, err)
+	}
+
+	return nil
+}
+
+func (c *Client) FindPR(ctx context.Context, repoFullName, headOwner, headBranch string, state hosting.PRState) (*hosting.PRInfo, error) {
+	// merged pull requests are the closed ones with merge time set
+	apiState := string(state)
+	if state == hosting.PRStateMerged {
+		apiState = "closed"
+	}
+
+	query := url.Values{}
+	query.Set("head", headOwner+":"+headBranch)
+	query.Set("state", apiState)
+	query.Set("per_page", fmt.Sprint(prListPageSize))
+
+	var prs []pullRequest
+	if err := c.do(ctx, http.MethodGet, "/repos/"+repoFullName+"/pulls?"+query.Encode(), nil, &prs); err != nil {
+		return nil, fmt.Errorf("failed to list PRs for branch %s: %w", headBranch, err)
+	}
+
+	for _, pr := range prs {
+		if state == hosting.PRStateMerged && pr.MergedAt == nil {
+			continue
+		}
+
+		return &hosting.PRInfo{
+			Number: pr.Number,
+			Title:  strings.TrimSpace(pr.Title),
+			URL:    strings.TrimSpace(pr.HTMLURL),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (c *Client) CreatePR(ctx context.Context, repoFullName, headOwner, headBranch, baseBranch, title, body string, draft bool) (*hosting.PRInfo, error) {
+	req := createPullRequest{
+		Title: title,
+		Head:  headOwner + ":" + headBranch,
+		Base:  baseBranch,
+		Body:  body,
+		Draft: draft,
+	}
+
+	var pr pullRequest
+	if err := c.do(ctx, http.MethodPost, "/repos/"+repoFullName+"/pulls", req, &pr); err != nil {
+		return nil, fmt.Errorf("failed to create PR for branch %s: %w", headBranch, err)
+	}
+
+	return &hosting.PRInfo{
+		Number: pr.Number,
+		Title:  pr.Title,
+		URL:    pr.HTMLURL,
+	}, nil
+}
+
+func (c *Client) GetPRStatus(ctx context.Context, repoFullName string, number int) (*hosting.PRStatus, error) {
+	owner, name, _ := strings.Cut(repoFullName, "/")
+	req := graphqlRequest{
+		Query:     prStatusQuery,
+		Variables: map[string]any{"owner": owner, "name": name, "number": number},
+	}
+
+	var resp prStatusResponse
+	if err := c.doURL(ctx, http.MethodPost, c.graphqlURL, req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get status of PR #%d: %w", number, err)
+	}
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("failed to get status of PR #%d: %s", number, resp.Errors[0].Message)
+	}
+	pr := resp.Data.Repository.PullRequest
+	if pr == nil {
+		return nil, fmt.Errorf("PR #%d of %s: %w", number, repoFullName, hosting.ErrNotFound)
+	}
+
+	status := &hosting.PRStatus{Review: reviewDecisions[pr.ReviewDecision]}
+	if nodes := pr.Commits.Nodes; len(nodes) > 0 && nodes[0].Commit.StatusCheckRollup != nil {
+		status.Checks = rollupStates[nodes[0].Commit.StatusCheckRollup.State]
+	}
+
+	return status, nil
+}
+
+func (c *Client) GetIssueTitle(ctx context.Context, repoFullName, issueNumber string) (string, error) {
+	i, err := c.getIssue(ctx, repoFullName, issueNumber)
+	if err != nil {
+		return "", err
+	}
+
+	return i.Title, nil
+}
+
+func (c *Client) LinkBranchToIssue(ctx context.Context, issueRepoFullName, issueNumber, branchRepoFullName, branchName string) error {
+	i, err := c.getIssue(ctx, issueRepoFullName, issueNumber)
+	if err != nil {
+		return err
+	}
+
+	repo, err := c.getRepo(ctx, branchRepoFullName)
+	if err != nil {
+		return err
+	}
+
+	var ref gitRef
+	if err := c.do(ctx, http.MethodGet, "/repos/"+branchRepoFullName+"/git/ref/heads/"+branchName, nil, &ref); err != nil {
+		return fmt.Errorf("failed to get branch %s of %s: %w", branchName, branchRepoFullName, err)
+	}
+
+	req := graphqlRequest{
+		Query: linkBranchMutation,
+		Variables: map[string]any{
+			"issueId":      i.NodeID,
+			"repositoryId": repo.NodeID,
+			"name":         branchName,
+			"oid":          ref.Object.SHA,
+		},
+	}
+
+	var resp graphqlResponse
+	if err := c.doURL(ctx, http.MethodPost, c.graphqlURL, req, &resp); err != nil {
+		return fmt.Errorf("failed to link branch to issue: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("failed to link branch to issue: %s", resp.Errors[0].Message)
+	}
+
+	return nil
+}
+
+func (c *Client) getRepo(ctx context.Context, repoFullName string) (*repository, error) {
+	var repo repository
+	if err := c.do(ctx, http.MethodGet, "/repos/"+repoFullName, nil, &repo); err != nil {
+		return nil, fmt.Errorf("failed to get repository %s: %w", repoFullName, err)
+	}
+
+	return &repo, nil
+}
+
+func (c *Client) getIssue(ctx context.Context, repoFullName, issueNumber string) (*issue, error) {
+	var i issue
+	if err := c.do(ctx, http.MethodGet, "/repos/"+repoFullName+"/issues/"+issueNumber, nil, &i); err != nil {
+		return nil, fmt.Errorf("failed to retrieve issue data for %s#%s: %w", repoFullName, issueNumber, err)
+	}
+
+	return &i, nil
+}
+
+// do sends a request to the REST API path and decodes the JSON response into respBody if it is not nil
+func (c *Client) do(ctx context.Context, method, path string, reqBody any, respBody any) error {
+	return c.doURL(ctx, method, c.apiURL+path, reqBody, respBody)
+}
+
+func (c *Client) doURL(ctx context.Context, method, reqURL string, reqBody any, respBody any) error {
+	token, err := c.token()
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if reqBody != nil {
+		bb, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(bb)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	logger.Verbose(method, reqURL)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var apiErr apiError
+		_ = json.Unmarshal(respBytes, &apiErr)
+		if resp.StatusCode == http.StatusNotFound {
+			return utils.HTTPStatusError(resp.StatusCode, fmt.Errorf("%s: %w", apiErr.Message, hosting.ErrNotFound))
+		}
+
+		return utils.HTTPStatusError(resp.StatusCode, errors.New(resp.Status+": "+apiErr.Message))
+	}
+
+	if respBody == nil || len(respBytes) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBytes, respBody); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/runner"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL, hosting.StaticToken("test-token"))
+}
+
+func TestGetParentRepo(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("Bearer test-token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/repos/fork-account/qs":
+			_, _ = w.Write([]byte(`{"full_name":"fork-account/qs","parent":{"full_name":"untillpro/qs"}}`))
+		case "/repos/untillpro/qs":
+			_, _ = w.Write([]byte(`{"full_name":"untillpro/qs"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	})
+
+	parent, err := client.GetParentRepo(context.Background(), "fork-account/qs")
+	require.NoError(err)
+	require.Equal("untillpro/qs", parent)
+
+	parent, err = client.GetParentRepo(context.Background(), "untillpro/qs")
+	require.NoError(err)
+	require.Empty(parent)
+
+	err = client.VerifyRepoExists(context.Background(), "untillpro/unknown")
+	require.ErrorIs(err, hosting.ErrNotFound)
+}
+
+func TestRequestCancelled(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"full_name":"untillpro/qs"}`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.GetParentRepo(ctx, "untillpro/qs")
+	require.ErrorIs(t, err, context.Canceled)
+	require.Zero(t, requests)
+}
+
+func TestFindPR(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("/repos/untillpro/qs/pulls", r.URL.Path)
+		require.Equal("fork-account:feature-pr", r.URL.Query().Get("head"))
+		switch r.URL.Query().Get("state") {
+		case "open":
+			_, _ = w.Write([]byte(`[]`))
+		case "closed":
+			_, _ = w.Write([]byte(`[
+				{"number":1,"title":"Closed","html_url":"https://github.com/untillpro/qs/pull/1","merged_at":null},
+				{"number":2,"title":" Merged ","html_url":"https://github.com/untillpro/qs/pull/2","merged_at":"2026-01-01T00:00:00Z"}
+			]`))
+		}
+	})
+
+	pr, err := client.FindPR(context.Background(), "untillpro/qs", "fork-account", "feature-pr", hosting.PRStateOpen)
+	require.NoError(err)
+	require.Nil(pr)
+
+	pr, err = client.FindPR(context.Background(), "untillpro/qs", "fork-account", "feature-pr", hosting.PRStateMerged)
+	require.NoError(err)
+	require.Equal(&hosting.PRInfo{Number: 2, Title: "Merged", URL: "https://github.com/untillpro/qs/pull/2"}, pr)
+}
+
+func TestCreatePR(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(http.MethodPost, r.Method)
+		require.Equal("/repos/untillpro/qs/pulls", r.URL.Path)
+
+		var req createPullRequest
+		require.NoError(json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(createPullRequest{Title: "Fix bug", Head: "fork-account:fix-bug-pr", Base: "main", Body: "body", Draft: true}, req)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number":7,"title":"Fix bug","html_url":"https://github.com/untillpro/qs/pull/7"}`))
+	})
+
+	pr, err := client.CreatePR(context.Background(), "untillpro/qs", "fork-account", "fix-bug-pr", "main", "Fix bug", "body", true)
+	require.NoError(err)
+	require.Equal(7, pr.Number)
+	require.Equal("https://github.com/untillpro/qs/pull/7", pr.URL)
+}
+
+func TestCreatePR_ValidationFailed(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"Validation Failed"}`))
+	})
+
+	_, err := client.CreatePR(context.Background(), "untillpro/qs", "fork-account", "fix-bug-pr", "main", "Fix bug", "", false)
+	require.ErrorContains(t, err, "422 Unprocessable Entity: Validation Failed")
+}
+
+func TestGetPRStatus(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("/graphql", r.URL.Path)
+		var req graphqlRequest
+		require.NoError(json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(map[string]any{"owner": "untillpro", "name": "qs", "number": float64(7)}, req.Variables)
+		_, _ = w.Write([]byte(`{"data":{"repository":{"pullRequest":{"reviewDecision":"CHANGES_REQUESTED",` +
+			`"commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"PENDING"}}}]}}}}}`))
+	})
+
+	status, err := client.GetPRStatus(context.Background(), "untillpro/qs", 7)
+	require.NoError(err)
+	require.Equal(&hosting.PRStatus{Review: hosting.ReviewChangesRequested, Checks: hosting.ChecksPending}, status)
+}
+
+func TestLinkBranchToIssue(t *testing.T) {
+	require := require.New(t)
+
+	var gotVariables map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/untillpro/qs/issues/42":
+			_, _ = w.Write([]byte(`{"node_id":"I_42","title":"Fix bug"}`))
+		case "/repos/fork-account/qs":
+			_, _ = w.Write([]byte(`{"node_id":"R_1","full_name":"fork-account/qs"}`))
+		case "/repos/fork-account/qs/git/ref/heads/42-fix-bug-dev":
+			_, _ = w.Write([]byte(`{"object":{"sha":"abc123"}}`))
+		case "/graphql":
+			var req graphqlRequest
+			require.NoError(json.NewDecoder(r.Body).Decode(&req))
+			gotVariables = req.Variables
+			_, _ = w.Write([]byte(`{"data":{"createLinkedBranch":{"linkedBranch":{"id":"LB_1"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	err := client.LinkBranchToIssue(context.Background(), "untillpro/qs", "42", "fork-account/qs", "42-fix-bug-dev")
+	require.NoError(err)
+	require.Equal(map[string]any{
+		"issueId":      "I_42",
+		"repositoryId": "R_1",
+		"name":         "42-fix-bug-dev",
+		"oid":          "abc123",
+	}, gotVariables)
+}
+
+func TestDefaultToken(t *testing.T) {
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv(envGhToken, "env-token")
+		fake := runner.NewFake()
+
+		token, err := DefaultToken(fake, DefaultHost)()
+		require.NoError(t, err)
+		require.Equal(t, "env-token", token)
+		require.Empty(t, fake.Calls())
+	})
+
+	t.Run("from gh", func(t *testing.T) {
+		t.Setenv(envGhToken, "")
+		t.Setenv(envGithubToken, "")
+		fake := runner.NewFake()
+		fake.On("gh auth token").Return("gh-token\n", "", nil)
+
+		token, err := DefaultToken(fake, DefaultHost)()
+		require.NoError(t, err)
+		require.Equal(t, "gh-token", token)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Setenv(envGhToken, "")
+		t.Setenv(envGithubToken, "")
+		fake := runner.NewFake()
+
+		_, err := DefaultToken(fake, DefaultHost)()
+		require.ErrorIs(t, err, hosting.ErrTokenNotFound)
+	})
+}
+
+func TestEnterprise(t *testing.T) {
+	require := require.New(t)
+
+	var gotPaths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPaths = append(gotPaths, r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v3/user":
+			_, _ = w.Write([]byte(`{"login":"octocat"}`))
+		case "/api/graphql":
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		default:
+			_, _ = w.Write([]byte(`{"node_id":"N_1","object":{"sha":"abc123"}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewForWebURL(srv.URL+"/", hosting.StaticToken("test-token"))
+
+	login, err := client.GetUserLogin(context.Background())
+	require.NoError(err)
+	require.Equal("octocat", login)
+
+	require.NoError(client.LinkBranchToIssue(context.Background(), "org/qs", "42", "org/qs", "42-fix-dev"))
+	require.Contains(gotPaths, "/api/graphql")
+
+	require.Equal(DefaultAPIURL, APIURL("https://github.com"))
+	require.Equal("https://github.example.com/api/v3", APIURL("https://github.example.com"))
+}
+
+func TestDefaultToken_Enterprise(t *testing.T) {
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv(envGhToken, "github-com-token")
+		t.Setenv(envGhEnterpriseToken, "enterprise-token")
+
+		token, err := DefaultToken(runner.NewFake(), "github.example.com")()
+		require.NoError(t, err)
+		require.Equal(t, "enterprise-token", token)
+	})
+
+	t.Run("from gh", func(t *testing.T) {
+		t.Setenv(envGhEnterpriseToken, "")
+		t.Setenv(envGithubEnterpriseToken, "")
+		fake := runner.NewFake()
+		fake.On("gh auth token --hostname github.example.com").Return("gh-token\n", "", nil)
+
+		token, err := DefaultToken(fake, "github.example.com")()
+		require.NoError(t, err)
+		require.Equal(t, "gh-token", token)
+	})
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/runner"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+// New returns a client of the GitHub API located at apiURL
+func New(apiURL string, token hosting.TokenFunc) *Client {
+	apiURL = strings.TrimSuffix(apiURL, "/")
+
+	return &Client{
+		apiURL:     apiURL,
+		graphqlURL: apiURL + "/graphql",
+		token:      token,
+		httpClient: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// NewForWebURL returns a client of the GitHub instance at webURL, e.g. "https://github.com"
+// or "https://github.example.com" for GitHub Enterprise Server
+func NewForWebURL(webURL string, token hosting.TokenFunc) *Client {
+	webURL = strings.TrimSuffix(webURL, "/")
+	if isDefaultHost(webURL) {
+		return New(DefaultAPIURL, token)
+	}
+
+	c := New(APIURL(webURL), token)
+	c.graphqlURL = webURL + enterpriseGraphQLPath
+
+	return c
+}
+
+// APIURL returns the REST API root of the GitHub instance at webURL:
+// api.github.com for github.com and <webURL>/api/v3 for GitHub Enterprise Server
+func APIURL(webURL string) string {
+	webURL = strings.TrimSuffix(webURL, "/")
+	if isDefaultHost(webURL) {
+		return DefaultAPIURL
+	}
+
+	return webURL + enterpriseAPIPath
+}
+
+// DefaultToken returns a TokenFunc which takes the token for host the way gh does:
+// from GH_TOKEN or GITHUB_TOKEN for github.com, from GH_ENTERPRISE_TOKEN or GITHUB_ENTERPRISE_TOKEN
+// for GitHub Enterprise Server, or falls back to `gh auth token` if gh is logged in to the host.
+// The token is resolved once.
+func DefaultToken(r runner.CommandRunner, host string) hosting.TokenFunc {
+	var (
+		once  sync.Once
+		token string
+		err   error
+	)
+
+	return func() (string, error) {
+		once.Do(func() {
+			envs := []string{envGhToken, envGithubToken}
+			ghArgs := []string{"auth", "token"}
+			if len(host) > 0 && host != DefaultHost {
+				envs = []string{envGhEnterpriseToken, envGithubEnterpriseToken}
+				ghArgs = append(ghArgs, "--hostname", host)
+			}
+
+			for _, env := range envs {
+				if token = strings.TrimSpace(os.Getenv(env)); len(token) > 0 {
+					return
+				}
+			}
+
+			stdout, stderr, ghErr := r.Run(context.Background(), "", "gh", ghArgs...)
+			token = strings.TrimSpace(stdout)
+			if ghErr != nil || len(token) == 0 {
+				logger.Verbose(stderr)
+
+				err = fmt.Errorf("%w: set %s environment variable or log in with 'gh auth login'", hosting.ErrTokenNotFound, envs[0])
+			}
+		})
+
+		return token, err
+	}
+}
+
+func isDefaultHost(webURL string) bool {
+	u, err := url.Parse(webURL)
+
+	return err != nil || u.Host == "" || u.Host == DefaultHost
+}
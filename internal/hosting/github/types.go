@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package github
+
+import (
+	"net/http"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// Client is a GitHub REST/GraphQL API client implementing hosting.HostingProvider
+type Client struct {
+	apiURL     string
+	graphqlURL string
+	token      hosting.TokenFunc
+	httpClient *http.Client
+}
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type repository struct {
+	NodeID   string `json:"node_id"`
+	FullName string `json:"full_name"`
+	Parent   *struct {
+		FullName string `json:"full_name"`
+	} `json:"parent"`
+}
+
+type pullRequest struct {
+	Number   int     `json:"number"`
+	Title    string  `json:"title"`
+	HTMLURL  string  `json:"html_url"`
+	MergedAt *string `json:"merged_at"`
+}
+
+type createPullRequest struct {
+	Title string `json:"title"`
+	Head  string `json:"head"`
+	Base  string `json:"base"`
+	Body  string `json:"body"`
+	Draft bool   `json:"draft"`
+}
+
+type issue struct {
+	NodeID string `json:"node_id"`
+	Title  string `json:"title"`
+}
+
+type gitRef struct {
+	Object struct {
+		SHA string `json:"sha"`
+	} `json:"object"`
+}
+
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type graphqlResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type prStatusResponse struct {
+	graphqlResponse
+	Data struct {
+		Repository struct {
+			PullRequest *struct {
+				ReviewDecision string `json:"reviewDecision"`
+				Commits        struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup *struct {
+								State string `json:"state"`
+							} `json:"statusCheckRollup"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+}
+
+type apiError struct {
+	Message string `json:"message"`
+}
+
+var _ hosting.HostingProvider = (*Client)(nil)
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitlab
+
+import (
+	"time"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+const (
+	// APIPath is the REST API root relative to the GitLab instance URL
+	APIPath = "/api/v4"
+
+	envGitlabToken = "GITLAB_TOKEN"
+	envGlToken     = "GL_TOKEN"
+
+	httpTimeout    = 30 * time.Second
+	mrListPageSize = 100
+
+	mrStateOpened = "opened"
+	mrStateMerged = "merged"
+
+	draftTitlePrefix = "Draft: "
+)
+
+// pipelineStates maps the states of the head pipeline of merge requests, other states mean no checks
+var pipelineStates = map[string]hosting.ChecksState{
+	"success":              hosting.ChecksSuccess,
+	"failed":               hosting.ChecksFailure,
+	"canceled":             hosting.ChecksFailure,
+	"created":              hosting.ChecksPending,
+	"waiting_for_resource": hosting.ChecksPending,
+	"preparing":            hosting.ChecksPending,
+	"pending":              hosting.ChecksPending,
+	"running":              hosting.ChecksPending,
+	"scheduled":            hosting.ChecksPending,
+	"manual":               hosting.ChecksPending,
+}
@@ -0,0 +1,277 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/utils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+func (c *Client) GetUserLogin(ctx context.Context) (string, error) {
+	var u user
+	if err := c.do(ctx, http.MethodGet, "/user", nil, &u); err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return u.Username, nil
+}
+
+func (c *Client) GetParentRepo(ctx context.Context, repoFullName string) (string, error) {
+	p, err := c.getProject(ctx, repoFullName)
+	if err != nil {
+		return "", err
+	}
+	if p.ForkedFromProject == nil {
+		return "", nil
+	}
+
+	return p.ForkedFromProject.PathWithNamespace, nil
+}
+
+func (c *Client) VerifyRepoExists(ctx context.Context, repoFullName string) error {
+	if _, err := c.getProject(ctx, repoFullName); err != nil {
+		return fmt.Errorf("repository %s not accessible: %w", repoFullName, err)
+	}
+
+	return nil
+}
+
+func (c *Client) ForkRepo(ctx context.Context, repoFullName string) error {
+	err := c.do(ctx, http.MethodPost, projectPath(repoFullName)+"/fork", struct{}{}, nil)
+	if errors.Is(err, errAlreadyExists) {
+		logger.Verbose(fmt.Sprintf("Fork of %s already exists", repoFullName))
+
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fork repository %s: %w", repoFullName, err)
+	}
+
+	return nil
+}
+
+func (c *Client) FindPR(ctx context.Context, repoFullName, headOwner, headBranch string, state hosting.PRState) (*hosting.PRInfo, error) {
+	head, err := c.getProject(ctx, headProjectFullName(repoFullName, headOwner))
+	if err != nil {
+		return nil, err
+	}
+
+	apiState := mrStateOpened
+	if state == hosting.PRStateMerged {
+		apiState = mrStateMerged
+	}
+	query := url.Values{}
+	query.Set("source_branch", headBranch)
+	query.Set("state", apiState)
+	query.Set("per_page", strconv.Itoa(mrListPageSize))
+
+	var mrs []mergeRequest
+	if err := c.do(ctx, http.MethodGet, projectPath(repoFullName)+"/merge_requests?"+query.Encode(), nil, &mrs); err != nil {
+		return nil, fmt.Errorf("failed to list merge requests for branch %s: %w", headBranch, err)
+	}
+
+	for _, mr := range mrs {
+		// the same branch name may be used by other forks
+		if mr.SourceProjectID != head.ID {
+			continue
+		}
+
+		return &hosting.PRInfo{
+			Number: mr.IID,
+			Title:  strings.TrimSpace(mr.Title),
+			URL:    strings.TrimSpace(mr.WebURL),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (c *Client) CreatePR(ctx context.Context, repoFullName, headOwner, headBranch, baseBranch, title, body string, draft bool) (*hosting.PRInfo, error) {
+	target, err := c.getProject(ctx, repoFullName)
+	if err != nil {
+		return nil, err
+	}
+	source, err := c.getProject(ctx, headProjectFullName(repoFullName, headOwner))
+	if err != nil {
+		return nil, err
+	}
+
+	if draft {
+		title = draftTitlePrefix + title
+	}
+	req := createMergeRequest{
+		SourceBranch:    headBranch,
+		TargetBranch:    baseBranch,
+		TargetProjectID: target.ID,
+		Title:           title,
+		Description:     body,
+	}
+
+	// merge request is created in the source project and targets the target project
+	var mr mergeRequest
+	if err := c.do(ctx, http.MethodPost, "/projects/"+strconv.Itoa(source.ID)+"/merge_requests", req, &mr); err != nil {
+		return nil, fmt.Errorf("failed to create merge request for branch %s: %w", headBranch, err)
+	}
+
+	return &hosting.PRInfo{
+		Number: mr.IID,
+		Title:  mr.Title,
+		URL:    mr.WebURL,
+	}, nil
+}
+
+func (c *Client) GetPRStatus(ctx context.Context, repoFullName string, number int) (*hosting.PRStatus, error) {
+	mrPath := projectPath(repoFullName) + "/merge_requests/" + strconv.Itoa(number)
+	var mr mergeRequest
+	if err := c.do(ctx, http.MethodGet, mrPath, nil, &mr); err != nil {
+		return nil, fmt.Errorf("failed to get merge request !%d: %w", number, err)
+	}
+	var approvals mergeRequestApprovals
+	if err := c.do(ctx, http.MethodGet, mrPath+"/approvals", nil, &approvals); err != nil {
+		return nil, fmt.Errorf("failed to get approvals of merge request !%d: %w", number, err)
+	}
+
+	status := &hosting.PRStatus{}
+	switch {
+	case approvals.ApprovalsLeft > 0:
+		status.Review = hosting.ReviewRequired
+	case approvals.Approved && len(approvals.ApprovedBy) > 0:
+		status.Review = hosting.ReviewApproved
+	}
+	if mr.HeadPipeline != nil {
+		status.Checks = pipelineStates[mr.HeadPipeline.Status]
+	}
+
+	return status, nil
+}
+
+func (c *Client) GetIssueTitle(ctx context.Context, repoFullName, issueNumber string) (string, error) {
+	var i issue
+	if err := c.do(ctx, http.MethodGet, projectPath(repoFullName)+"/issues/"+issueNumber, nil, &i); err != nil {
+		return "", fmt.Errorf("failed to retrieve issue data for %s#%s: %w", repoFullName, issueNumber, err)
+	}
+
+	return i.Title, nil
+}
+
+// LinkBranchToIssue does nothing: GitLab relates a branch to an issue by the issue number
+// the branch name starts with, and qs dev branch names already start with it.
+func (c *Client) LinkBranchToIssue(_ context.Context, issueRepoFullName, issueNumber, _, branchName string) error {
+	logger.Verbose(fmt.Sprintf("Branch %s is related to %s#%s by its name", branchName, issueRepoFullName, issueNumber))
+
+	return nil
+}
+
+func (c *Client) getProject(ctx context.Context, repoFullName string) (*project, error) {
+	var p project
+	if err := c.do(ctx, http.MethodGet, projectPath(repoFullName), nil, &p); err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", repoFullName, err)
+	}
+
+	return &p, nil
+}
+
+// projectPath returns the API path of the project; the full name is URL-encoded as GitLab requires
+func projectPath(repoFullName string) string {
+	return "/projects/" + url.PathEscape(repoFullName)
+}
+
+// headProjectFullName returns the full name of the headOwner's project having the same name as the given one
+func headProjectFullName(repoFullName, headOwner string) string {
+	return headOwner + "/" + repoFullName[strings.LastIndex(repoFullName, "/")+1:]
+}
+
+// do sends a request to the REST API path and decodes the JSON response into respBody if it is not nil
+func (c *Client) do(ctx context.Context, method, path string, reqBody any, respBody any) error {
+	token, err := c.token()
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if reqBody != nil {
+		bb, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(bb)
+	}
+
+	reqURL := c.apiURL + path
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("PRIVATE-TOKEN", token)
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	logger.Verbose(method, reqURL)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg := errorMessage(respBytes)
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return utils.HTTPStatusError(resp.StatusCode, fmt.Errorf("%s: %w", msg, hosting.ErrNotFound))
+		case http.StatusConflict:
+			return utils.HTTPStatusError(resp.StatusCode, fmt.Errorf("%s: %w", msg, errAlreadyExists))
+		}
+
+		return utils.HTTPStatusError(resp.StatusCode, errors.New(resp.Status+": "+msg))
+	}
+
+	if respBody == nil || len(respBytes) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBytes, respBody); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
+
+// errorMessage extracts the message from the error response body
+func errorMessage(respBytes []byte) string {
+	var apiErr apiError
+	if err := json.Unmarshal(respBytes, &apiErr); err != nil {
+		return strings.TrimSpace(string(respBytes))
+	}
+
+	var msg string
+	if err := json.Unmarshal(apiErr.Message, &msg); err == nil {
+		return msg
+	}
+	if len(apiErr.Message) > 0 {
+		return string(apiErr.Message)
+	}
+
+	return apiErr.Error
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL+APIPath, hosting.StaticToken("test-token"))
+}
+
+// serveProjects serves the projects by their URL-encoded full names
+func serveProjects(w http.ResponseWriter, r *http.Request, projects map[string]string) bool {
+	if body, ok := projects[r.URL.EscapedPath()]; ok {
+		_, _ = w.Write([]byte(body))
+
+		return true
+	}
+
+	return false
+}
+
+var testProjects = map[string]string{
+	"/api/v4/projects/group%2Fqs":        `{"id":1,"path_with_namespace":"group/qs"}`,
+	"/api/v4/projects/fork-account%2Fqs": `{"id":2,"path_with_namespace":"fork-account/qs","forked_from_project":{"path_with_namespace":"group/qs"}}`,
+}
+
+func TestGetParentRepo(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("test-token", r.Header.Get("PRIVATE-TOKEN"))
+		if serveProjects(w, r, testProjects) {
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"404 Project Not Found"}`))
+	})
+
+	parent, err := client.GetParentRepo(context.Background(), "fork-account/qs")
+	require.NoError(err)
+	require.Equal("group/qs", parent)
+
+	parent, err = client.GetParentRepo(context.Background(), "group/qs")
+	require.NoError(err)
+	require.Empty(parent)
+
+	err = client.VerifyRepoExists(context.Background(), "group/unknown")
+	require.ErrorIs(err, hosting.ErrNotFound)
+	require.ErrorContains(err, "404 Project Not Found")
+}
+
+func TestForkRepo_AlreadyExists(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v4/projects/group%2Fqs/fork", r.URL.EscapedPath())
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"message":{"name":["has already been taken"]}}`))
+	})
+
+	require.NoError(t, client.ForkRepo(context.Background(), "group/qs"))
+}
+
+func TestFindPR(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if serveProjects(w, r, testProjects) {
+			return
+		}
+		require.Equal("/api/v4/projects/group%2Fqs/merge_requests", r.URL.EscapedPath())
+		require.Equal("feature-pr", r.URL.Query().Get("source_branch"))
+		switch r.URL.Query().Get("state") {
+		case mrStateOpened:
+			_, _ = w.Write([]byte(`[]`))
+		case mrStateMerged:
+			_, _ = w.Write([]byte(`[
+				{"iid":1,"title":"Other fork","web_url":"https://gitlab.com/group/qs/-/merge_requests/1","source_project_id":3},
+				{"iid":2,"title":" Merged ","web_url":"https://gitlab.com/group/qs/-/merge_requests/2","source_project_id":2}
+			]`))
+		}
+	})
+
+	pr, err := client.FindPR(context.Background(), "group/qs", "fork-account", "feature-pr", hosting.PRStateOpen)
+	require.NoError(err)
+	require.Nil(pr)
+
+	pr, err = client.FindPR(context.Background(), "group/qs", "fork-account", "feature-pr", hosting.PRStateMerged)
+	require.NoError(err)
+	require.Equal(&hosting.PRInfo{Number: 2, Title: "Merged", URL: "https://gitlab.com/group/qs/-/merge_requests/2"}, pr)
+}
+
+func TestCreatePR(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && serveProjects(w, r, testProjects) {
+			return
+		}
+		require.Equal(http.MethodPost, r.Method)
+		require.Equal("/api/v4/projects/2/merge_requests", r.URL.EscapedPath())
+
+		var req createMergeRequest
+		require.NoError(json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(createMergeRequest{
+			SourceBranch:    "fix-bug-pr",
+			TargetBranch:    "main",
+			TargetProjectID: 1,
+			Title:           "Draft: Fix bug",
+			Description:     "body",
+		}, req)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"iid":7,"title":"Draft: Fix bug","web_url":"https://gitlab.com/group/qs/-/merge_requests/7"}`))
+	})
+
+	pr, err := client.CreatePR(context.Background(), "group/qs", "fork-account", "fix-bug-pr", "main", "Fix bug", "body", true)
+	require.NoError(err)
+	require.Equal(7, pr.Number)
+	require.Equal("https://gitlab.com/group/qs/-/merge_requests/7", pr.URL)
+}
+
+func TestGetPRStatus(t *testing.T) {
+	require := require.New(t)
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fqs/merge_requests/7":
+			_, _ = w.Write([]byte(`{"iid":7,"head_pipeline":{"status":"failed"}}`))
+		case "/api/v4/projects/group%2Fqs/merge_requests/7/approvals":
+			_, _ = w.Write([]byte(`{"approved":true,"approvals_left":0,"approved_by":[{"user":{"username":"reviewer"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	status, err := client.GetPRStatus(context.Background(), "group/qs", 7)
+	require.NoError(err)
+	require.Equal(&hosting.PRStatus{Review: hosting.ReviewApproved, Checks: hosting.ChecksFailure}, status)
+}
+
+func TestGetIssueTitle(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v4/projects/group%2Fsub%2Fqs/issues/7", r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{"title":"Fix bug"}`))
+	})
+
+	title, err := client.GetIssueTitle(context.Background(), "group/sub/qs", "7")
+	require.NoError(t, err)
+	require.Equal(t, "Fix bug", title)
+}
+
+func TestDefaultToken(t *testing.T) {
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv(envGitlabToken, "")
+		t.Setenv(envGlToken, "env-token")
+
+		token, err := DefaultToken()()
+		require.NoError(t, err)
+		require.Equal(t, "env-token", token)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Setenv(envGitlabToken, "")
+		t.Setenv(envGlToken, "")
+
+		_, err := DefaultToken()()
+		require.ErrorIs(t, err, hosting.ErrTokenNotFound)
+	})
+}
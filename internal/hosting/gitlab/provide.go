@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// New returns a client of the GitLab API located at apiURL, e.g. "https://gitlab.com/api/v4"
+func New(apiURL string, token hosting.TokenFunc) *Client {
+	return &Client{
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// DefaultToken returns a TokenFunc which takes the token from GITLAB_TOKEN or GL_TOKEN
+func DefaultToken() hosting.TokenFunc {
+	return func() (string, error) {
+		for _, env := range []string{envGitlabToken, envGlToken} {
+			if token := strings.TrimSpace(os.Getenv(env)); len(token) > 0 {
+				return token, nil
+			}
+		}
+
+		return "", fmt.Errorf("%w: set %s environment variable", hosting.ErrTokenNotFound, envGitlabToken)
+	}
+}
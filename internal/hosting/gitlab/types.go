@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// Client is a GitLab REST API client implementing hosting.HostingProvider.
+// Merge requests are exposed as pull requests.
+type Client struct {
+	apiURL     string
+	token      hosting.TokenFunc
+	httpClient *http.Client
+}
+
+type user struct {
+	Username string `json:"username"`
+}
+
+type project struct {
+	ID                int    `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	ForkedFromProject *struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"forked_from_project"`
+}
+
+type mergeRequest struct {
+	IID             int    `json:"iid"`
+	Title           string `json:"title"`
+	WebURL          string `json:"web_url"`
+	SourceProjectID int    `json:"source_project_id"`
+	HeadPipeline    *struct {
+		Status string `json:"status"`
+	} `json:"head_pipeline"`
+}
+
+type mergeRequestApprovals struct {
+	Approved      bool `json:"approved"`
+	ApprovalsLeft int  `json:"approvals_left"`
+	ApprovedBy    []struct {
+		User user `json:"user"`
+	} `json:"approved_by"`
+}
+
+type createMergeRequest struct {
+	SourceBranch    string `json:"source_branch"`
+	TargetBranch    string `json:"target_branch"`
+	TargetProjectID int    `json:"target_project_id"`
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+}
+
+type issue struct {
+	Title string `json:"title"`
+}
+
+// apiError is an error response; message is either a string or an object with per-field errors
+type apiError struct {
+	Message json.RawMessage `json:"message"`
+	Error   string          `json:"error"`
+}
+
+var _ hosting.HostingProvider = (*Client)(nil)
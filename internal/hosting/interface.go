@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package hosting
+
+import "context"
+
+// HostingProvider is an API of a git hosting service (GitHub etc.) used by qs.
+// Repositories are referred to by their full name, e.g. "untillpro/qs".
+type HostingProvider interface {
+	// GetUserLogin returns the login of the authenticated user
+	GetUserLogin(ctx context.Context) (string, error)
+	// GetParentRepo returns the full name of the repo the given repo is forked from.
+	// Returns empty string if the repo is not a fork.
+	GetParentRepo(ctx context.Context, repoFullName string) (string, error)
+	// VerifyRepoExists returns error if the repo does not exist or is not accessible
+	VerifyRepoExists(ctx context.Context, repoFullName string) error
+	// ForkRepo forks the repo into the account of the authenticated user
+	ForkRepo(ctx context.Context, repoFullName string) error
+	// FindPR returns the pull request from headOwner:headBranch to the repo in the given state.
+	// Returns nil if there is no such pull request.
+	FindPR(ctx context.Context, repoFullName, headOwner, headBranch string, state PRState) (*PRInfo, error)
+	// CreatePR creates a pull request from headOwner:headBranch to baseBranch of the repo
+	CreatePR(ctx context.Context, repoFullName, headOwner, headBranch, baseBranch, title, body string, draft bool) (*PRInfo, error)
+	// GetPRStatus returns the review and checks state of the pull request of the repo
+	GetPRStatus(ctx context.Context, repoFullName string, number int) (*PRStatus, error)
+	// GetIssueTitle returns the title of the issue
+	GetIssueTitle(ctx context.Context, repoFullName, issueNumber string) (string, error)
+	// LinkBranchToIssue links the existing branch of branchRepoFullName to the issue of issueRepoFullName
+	LinkBranchToIssue(ctx context.Context, issueRepoFullName, issueNumber, branchRepoFullName, branchName string) error
+}
@@ -0,0 +1,17 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package hosting
+
+// StaticToken returns a TokenFunc which always returns the given token
+func StaticToken(token string) TokenFunc {
+	return func() (string, error) {
+		if len(token) == 0 {
+			return "", ErrTokenNotFound
+		}
+
+		return token, nil
+	}
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package hosting
+
+type PRState string
+
+// TokenFunc returns the token used to authenticate API requests
+type TokenFunc func() (string, error)
+
+// PRInfo describes a pull request
+type PRInfo struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+}
+
+// ReviewState is the review decision of a pull request, empty if no review is required
+type ReviewState string
+
+// ChecksState is the combined state of the CI checks of a pull request, empty if there are no checks
+type ChecksState string
+
+// PRStatus is the review and checks state of a pull request
+type PRStatus struct {
+	Review ReviewState `json:"review,omitempty"`
+	Checks ChecksState `json:"checks,omitempty"`
+}
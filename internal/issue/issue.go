@@ -1,39 +1,37 @@
 package issue
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 
-	"github.com/untillpro/goutils/exec"
+	"github.com/untillpro/qs/internal/config"
 	"github.com/untillpro/qs/internal/jira"
 	"github.com/untillpro/qs/internal/notes"
+	"github.com/untillpro/qs/internal/tracker"
+	"github.com/untillpro/qs/internal/tracker/linear"
+	"github.com/untillpro/qs/internal/tracker/youtrack"
 	"github.com/untillpro/qs/utils"
-	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
-type IssueType int
-
-const (
-	FreeForm IssueType = iota
-	GitHub
-	Jira
-)
-
-const (
-	maximumBranchNameLength = 100
+// Trackers is the registry of issue trackers qs dev fetches issue titles from
+var Trackers = tracker.NewRegistry(
+	tracker.NewGitLab(), // before GitHub: GitLab issue URLs contain "/issues/" too
+	tracker.NewGitHub(),
+	jira.NewTracker(),
+	linear.New(linear.DefaultAPIURL, linear.DefaultToken()), // before YouTrack: Linear issue URLs contain "/issue/" too
+	youtrack.New(youtrack.DefaultSite(), youtrack.DefaultToken()),
 )
 
 // IssueInfo holds parsed issue metadata.
-// ID format depends on Type:
-//   - GitHub: issue number (e.g. "42")
-//   - Jira: ticket key (e.g. "AIR-270")
-//   - FreeForm: empty
+// ID is the issue ID extracted by the tracker (e.g. "42" or "AIR-270"),
+// for free-form input with non-fetchable URL it is the last URL segment.
 type IssueInfo struct {
-	Type IssueType
-	ID   string
+	// Tracker is the tracker of the issue URL, nil for free-form input
+	Tracker tracker.IssueTracker
+	ID      string
 	// URL holds the URL extracted from the input (fetchable or non-fetchable).
 	URL string
 	// Text holds the user-provided description text (URL excluded).
@@ -62,23 +60,19 @@ func ExtractIDFromURL(rawURL string) string {
 	return strings.TrimLeft(segments[len(segments)-1], "#!")
 }
 
-func BuildDevBranchName(info IssueInfo) (devBranchName string, comments []string, err error) {
-	var title string
-
-	switch info.Type {
-	case GitHub:
-		title, err = fetchGithubIssueTitle(info)
-	case Jira:
-		title, _, err = jira.GetJiraIssueTitle("", info.ID)
-	default:
-		title = info.Text
+// BuildDevBranchName builds the dev branch name and notes from the issue.
+// The title is fetched from the issue tracker, free-form input uses the text as the title.
+func BuildDevBranchName(ctx context.Context, env tracker.Env, info IssueInfo, cfg config.BranchConfig) (devBranchName string, comments []string, err error) {
+	title := info.Text
+	if info.Tracker != nil {
+		title, err = info.Tracker.FetchTitle(ctx, env, info.URL)
 	}
 	if err != nil {
 		return "", nil, err
 	}
 
 	// Convert issue title to kebab-style branch name
-	devBranchName = titleToKebabWithPrefix(info.ID, title)
+	devBranchName = titleToKebabWithPrefix(info.ID, title, cfg.MaxNameLength)
 	devBranchName = utils.CleanArgFromSpecSymbols(devBranchName)
 
 	// Build notes with unified IssueURL for all issue types.
@@ -89,78 +83,37 @@ func BuildDevBranchName(info IssueInfo) (devBranchName string, comments []string
 
 	comments = []string{notesObj}
 
-	devBranchName += "-dev"
+	devBranchName += cfg.DevSuffix
 
 	return devBranchName, comments, nil
 }
 
 // titleToKebabWithPrefix converts an issue title into a kebab-case branch name prefixed with the issue ID.
-func titleToKebabWithPrefix(id, title string) string {
+func titleToKebabWithPrefix(id, title string, maxLength int) string {
 	kebabTitle := strings.ToLower(title)
 	kebabTitle = regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(kebabTitle, "-")
 	kebabTitle = strings.Trim(kebabTitle, "-")
 
 	branchName := fmt.Sprintf("%s-%s", id, kebabTitle)
-	if len(branchName) > maximumBranchNameLength {
-		branchName = branchName[:maximumBranchNameLength]
+	if len(branchName) > maxLength {
+		branchName = branchName[:maxLength]
 	}
 	return branchName
 }
 
-// fetchGithubIssueTitle fetches the issue title from GitHub.
-func fetchGithubIssueTitle(info IssueInfo) (string, error) {
-	parts := strings.Split(info.URL, "/")
-	if len(parts) < 2 {
-		return "", fmt.Errorf("invalid issue URL format: %s", info.URL)
-	}
-
-	repoURL := strings.Split(info.URL, "/issues/")[0]
-	urlParts := strings.Split(repoURL, "/")
-	if len(urlParts) < 5 { //nolint:revive
-		return "", fmt.Errorf("invalid GitHub URL format: %s", repoURL)
-	}
-	owner := urlParts[3] //nolint:revive
-	repo := urlParts[4]  //nolint:revive
-
-	stdout, stderr, err := new(exec.PipedExec).
-		Command("gh", "issue", "view", info.ID, "--repo", fmt.Sprintf("%s/%s", owner, repo), "--json", "title").
-		RunToStrings()
-	if err != nil {
-		logger.Verbose(stderr)
-		if len(stderr) > 0 {
-			return "", errors.New(stderr)
-		}
-		return "", fmt.Errorf("failed to get issue title: %w", err)
-	}
-	logger.Verbose(stdout)
-
-	var issueData struct {
-		Title string `json:"title"`
-	}
-	if err := json.Unmarshal([]byte(stdout), &issueData); err != nil {
-		return "", fmt.Errorf("failed to parse issue data: %w", err)
-	}
-
-	return issueData.Title, nil
-}
-
 func ParseIssueFromArgs(args ...string) (IssueInfo, error) {
 	text := args[0] // protected by caller side
 	url, remainingText := extractURLFromText(text)
 
 	if url != "" {
-		if strings.Contains(url, "/issues/") {
-			segments := strings.Split(url, "/")
-			return IssueInfo{Type: GitHub, URL: url, Text: remainingText, ID: segments[len(segments)-1]}, nil
-		}
-		if id, ok := jira.GetJiraTicketIDFromArgs(url); ok {
-			return IssueInfo{Type: Jira, URL: url, Text: remainingText, ID: id}, nil
+		if t := Trackers.Find(url); t != nil {
+			return IssueInfo{Tracker: t, URL: url, Text: remainingText, ID: t.ExtractID(url)}, nil
 		}
 		// Non-fetchable URL: text is required for branch naming
 		if remainingText == "" {
 			return IssueInfo{}, errors.New("text is required when URL is non-fetchable")
 		}
-		return IssueInfo{Type: FreeForm, URL: url, Text: remainingText, ID: ExtractIDFromURL(url)}, nil
+		return IssueInfo{URL: url, Text: remainingText, ID: ExtractIDFromURL(url)}, nil
 	}
-	return IssueInfo{Type: FreeForm, Text: text}, nil
+	return IssueInfo{Text: text}, nil
 }
@@ -1,9 +1,13 @@
 package issue
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/config"
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/tracker"
 )
 
 func TestExtractURLFromText(t *testing.T) {
@@ -102,58 +106,88 @@ func TestExtractIDFromURL(t *testing.T) {
 }
 
 func TestParseIssueFromArgs(t *testing.T) {
+	t.Setenv("YOUTRACK_URL", "https://example.youtrack.cloud")
 	tests := []struct {
-		name     string
-		args     []string
-		wantType IssueType
-		wantID   string
-		wantURL  string
-		wantText string
-		wantErr  bool
+		name        string
+		args        []string
+		wantTracker string
+		wantID      string
+		wantURL     string
+		wantText    string
+		wantErr     bool
 	}{
 		{
-			name:     "Fetchable URL only - GitHub",
-			args:     []string{"https://github.com/untillpro/qs/issues/123"},
-			wantType: GitHub,
-			wantID:   "123",
-			wantURL:  "https://github.com/untillpro/qs/issues/123",
-			wantText: "",
+			name:        "Fetchable URL only - GitHub",
+			args:        []string{"https://github.com/untillpro/qs/issues/123"},
+			wantTracker: "GitHub",
+			wantID:      "123",
+			wantURL:     "https://github.com/untillpro/qs/issues/123",
+			wantText:    "",
 		},
 		{
-			name:     "Fetchable URL only - Jira",
-			args:     []string{"https://untill.atlassian.net/browse/AIR-270"},
-			wantType: Jira,
-			wantID:   "AIR-270",
-			wantURL:  "https://untill.atlassian.net/browse/AIR-270",
-			wantText: "",
+			name:        "Fetchable URL only - Jira",
+			args:        []string{"https://untill.atlassian.net/browse/AIR-270"},
+			wantTracker: "Jira",
+			wantID:      "AIR-270",
+			wantURL:     "https://untill.atlassian.net/browse/AIR-270",
+			wantText:    "",
+		},
+		{
+			name:        "Fetchable URL only - GitLab",
+			args:        []string{"https://gitlab.com/group/project/-/issues/7"},
+			wantTracker: "GitLab",
+			wantID:      "7",
+			wantURL:     "https://gitlab.com/group/project/-/issues/7",
+			wantText:    "",
+		},
+		{
+			name:        "Fetchable URL only - Linear",
+			args:        []string{"https://linear.app/untill/issue/ENG-12/fix-bug"},
+			wantTracker: "Linear",
+			wantID:      "ENG-12",
+			wantURL:     "https://linear.app/untill/issue/ENG-12/fix-bug",
+			wantText:    "",
+		},
+		{
+			name:        "Fetchable URL only - YouTrack",
+			args:        []string{"https://example.youtrack.cloud/issue/QS-12/fix-bug"},
+			wantTracker: "YouTrack",
+			wantID:      "QS-12",
+			wantURL:     "https://example.youtrack.cloud/issue/QS-12/fix-bug",
+			wantText:    "",
+		},
+		{
+			name:     "YouTrack URL of other site",
+			args:     []string{"Fix bug https://other.youtrack.cloud/issue/QS-12"},
+			wantID:   "QS-12",
+			wantURL:  "https://other.youtrack.cloud/issue/QS-12",
+			wantText: "Fix bug",
 		},
 		{
 			name:     "Text only",
 			args:     []string{"some-feature-branch"},
-			wantType: FreeForm,
 			wantURL:  "",
 			wantText: "some-feature-branch",
 		},
 		{
-			name:     "Text + GitHub URL",
-			args:     []string{"Fix bug https://github.com/untillpro/qs/issues/123"},
-			wantType: GitHub,
-			wantID:   "123",
-			wantURL:  "https://github.com/untillpro/qs/issues/123",
-			wantText: "Fix bug",
+			name:        "Text + GitHub URL",
+			args:        []string{"Fix bug https://github.com/untillpro/qs/issues/123"},
+			wantTracker: "GitHub",
+			wantID:      "123",
+			wantURL:     "https://github.com/untillpro/qs/issues/123",
+			wantText:    "Fix bug",
 		},
 		{
-			name:     "Text + Jira URL",
-			args:     []string{"Fix bug https://untill.atlassian.net/browse/AIR-270"},
-			wantType: Jira,
-			wantID:   "AIR-270",
-			wantURL:  "https://untill.atlassian.net/browse/AIR-270",
-			wantText: "Fix bug",
+			name:        "Text + Jira URL",
+			args:        []string{"Fix bug https://untill.atlassian.net/browse/AIR-270"},
+			wantTracker: "Jira",
+			wantID:      "AIR-270",
+			wantURL:     "https://untill.atlassian.net/browse/AIR-270",
+			wantText:    "Fix bug",
 		},
 		{
 			name:     "Text + non-fetchable URL",
 			args:     []string{"Show must go on https://dev.untill.com/projects/#!763090"},
-			wantType: FreeForm,
 			wantID:   "763090",
 			wantURL:  "https://dev.untill.com/projects/#!763090",
 			wantText: "Show must go on",
@@ -174,7 +208,11 @@ func TestParseIssueFromArgs(t *testing.T) {
 				return
 			}
 			require.NoError(err)
-			require.Equal(tt.wantType, info.Type)
+			if tt.wantTracker == "" {
+				require.Nil(info.Tracker)
+			} else {
+				require.Equal(tt.wantTracker, info.Tracker.Name())
+			}
 			require.Equal(tt.wantURL, info.URL)
 			require.Equal(tt.wantText, info.Text)
 			if tt.wantID != "" {
@@ -192,42 +230,42 @@ func TestBuildDevBranchName(t *testing.T) {
 	}{
 		{
 			name:       "Single word",
-			info:       IssueInfo{Type: FreeForm, Text: "Show"},
+			info:       IssueInfo{Text: "Show"},
 			wantBranch: "show-dev",
 		},
 		{
 			name:       "Multiple words",
-			info:       IssueInfo{Type: FreeForm, Text: "Show must go on"},
+			info:       IssueInfo{Text: "Show must go on"},
 			wantBranch: "show-must-go-on-dev",
 		},
 		{
 			name:       "Words with special chars",
-			info:       IssueInfo{Type: FreeForm, Text: "Show   ivv? must    go on---"},
+			info:       IssueInfo{Text: "Show   ivv? must    go on---"},
 			wantBranch: "show-ivv-must-go-on-dev",
 		},
 		{
 			name:       "Text with non-fetchable URL - uses Text for branch name",
-			info:       IssueInfo{Type: FreeForm, Text: "Show must go on", URL: "https://dev.heeus.io/launchpad/#!13427"},
+			info:       IssueInfo{Text: "Show must go on", URL: "https://dev.heeus.io/launchpad/#!13427"},
 			wantBranch: "show-must-go-on-dev",
 		},
 		{
 			name:       "Text with special chars and non-fetchable URL",
-			info:       IssueInfo{Type: FreeForm, Text: "Show   ivv? must $   go on---", URL: "https://dev.heeus.io/launchpad/#!13427"},
+			info:       IssueInfo{Text: "Show   ivv? must $   go on---", URL: "https://dev.heeus.io/launchpad/#!13427"},
 			wantBranch: "show-ivv-must-go-on-dev",
 		},
 		{
 			name:       "Long text with non-fetchable URL - truncated at 50 chars",
-			info:       IssueInfo{Type: FreeForm, Text: "Show me this  very long string more than fifty symbols in lenth with long task number 11111111111111", URL: "https://dev.heeus.io/launchpad/#!13427"},
+			info:       IssueInfo{Text: "Show me this  very long string more than fifty symbols in lenth with long task number 11111111111111", URL: "https://dev.heeus.io/launchpad/#!13427"},
 			wantBranch: "show-me-this-very-long-string-more-than-fifty-symb-dev",
 		},
 		{
 			name:       "Short text with non-fetchable URL",
-			info:       IssueInfo{Type: FreeForm, Text: "q dev", URL: "https://dev.heeus.io/launchpad/#!13427"},
+			info:       IssueInfo{Text: "q dev", URL: "https://dev.heeus.io/launchpad/#!13427"},
 			wantBranch: "q-dev-dev",
 		},
 		{
 			name:       "Long description with non-fetchable URL - truncated at 50 chars",
-			info:       IssueInfo{Type: FreeForm, Text: "qs: add Kaiser task link to generated commit message", URL: "https://dev.heeus.io/launchpad/#!25947"},
+			info:       IssueInfo{Text: "qs: add Kaiser task link to generated commit message", URL: "https://dev.heeus.io/launchpad/#!25947"},
 			wantBranch: "qs-add-kaiser-task-link-to-generated-commit-messag-dev",
 		},
 	}
@@ -235,7 +273,53 @@ func TestBuildDevBranchName(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			require := require.New(t)
-			branch, _, err := BuildDevBranchName(tt.info)
+			branch, _, err := BuildDevBranchName(context.Background(), tracker.Env{}, tt.info, config.Default().Branch)
+			require.NoError(err)
+			require.Equal(tt.wantBranch, branch)
+		})
+	}
+}
+
+// issueTitles is a hosting provider stub returning issue titles by "repo#number"
+type issueTitles struct {
+	hosting.HostingProvider
+	titles map[string]string
+}
+
+func (s issueTitles) GetIssueTitle(_ context.Context, repoFullName, issueNumber string) (string, error) {
+	return s.titles[repoFullName+"#"+issueNumber], nil
+}
+
+func TestBuildDevBranchName_HostedIssues(t *testing.T) {
+	hp := issueTitles{titles: map[string]string{
+		"untillpro/qs#42": "Fix GitHub bug",
+		"group/sub/qs#7":  "Fix GitLab bug",
+	}}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantBranch string
+	}{
+		{
+			name:       "GitHub issue",
+			url:        "https://github.com/untillpro/qs/issues/42",
+			wantBranch: "42-fix-github-bug-dev",
+		},
+		{
+			name:       "GitLab issue in subgroup",
+			url:        "https://gitlab.example.com/group/sub/qs/-/issues/7",
+			wantBranch: "7-fix-gitlab-bug-dev",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			info, err := ParseIssueFromArgs(tt.url)
+			require.NoError(err)
+
+			branch, _, err := BuildDevBranchName(context.Background(), tracker.Env{Hosting: hp}, info, config.Default().Branch)
 			require.NoError(err)
 			require.Equal(tt.wantBranch, branch)
 		})
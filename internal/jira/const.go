@@ -1,17 +1,29 @@
 package jira
 
 const (
-	jiraDomain = "https://untill.atlassian.net"
+	// EnvJiraURL overrides the default Jira site, e.g. https://jira.example.com
+	EnvJiraURL = "JIRA_URL"
+	// EnvJiraEmail and EnvJiraAPIToken are used for basic auth on Jira Cloud
+	EnvJiraEmail    = "JIRA_EMAIL"
+	EnvJiraAPIToken = "JIRA_API_TOKEN"
+	// EnvJiraPAT is a personal access token used for Bearer auth on Jira Server/Data Center
+	EnvJiraPAT = "JIRA_PAT"
+
+	cloudHostSuffix = ".atlassian.net"
+	cloudAPIVersion = "3"
+	// serverAPIVersion is the latest REST API version of Jira Server/Data Center
+	serverAPIVersion = "2"
+	browsePathPart   = "/browse/"
 
 	NotFoundIssueOrInsufficientAccessRightSuggestion = `
 Issue does not exist or you do not have permission to see it. This could mean:
 1. The Jira ticket doesn't exist
 2. You don't have permission to view it
-3. Your JIRA_API_TOKEN is invalid or expired
+3. Your JIRA_API_TOKEN (or JIRA_PAT) is invalid or expired
 
 Please verify:
 - The Jira ticket URL is correct
-- Your JIRA_API_TOKEN environment variable is set and valid
+- Your JIRA_API_TOKEN (Jira Cloud) or JIRA_PAT (Jira Server/Data Center) environment variable is set and valid
 - You have access permissions for this ticket in Jira
 `
 )
@@ -1,15 +1,43 @@
 package jira
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
+	"strings"
+
+	"github.com/untillpro/qs/internal/tracker"
+	"github.com/untillpro/qs/utils"
+)
+
+// ticketURLRegexp matches Jira ticket URLs like "https://<site>/browse/<ISSUE-KEY>",
+// where site may contain a context path, e.g. "https://jira.example.com/jira".
+// Group 1 is the site, group 2 is the issue key.
+var ticketURLRegexp = regexp.MustCompile(`(https?://[^\s/]+(?:/[^\s]*?)?)` + browsePathPart + `([A-Z][A-Z0-9_]*-[A-Z0-9-]+)`)
+
+var (
+	// httpClient sends the requests to Jira
+	httpClient = &http.Client{}
+	// defaultSiteURL is the Jira site used when the site can not be derived from the ticket URL, see SetDefaultSiteURL
+	defaultSiteURL = "https://untill.atlassian.net"
+	// siteURLConfigured is true if the site is resolved by the layered config, JIRA_URL is not read then
+	siteURLConfigured = false
 )
 
+// SetDefaultSiteURL sets the Jira site resolved by the layered config, it is used when the site can not be derived from the ticket URL.
+// JIRA_URL is not read afterwards since the config has already applied it below the flags.
+func SetDefaultSiteURL(siteURL string) {
+	defaultSiteURL = strings.TrimSuffix(siteURL, "/")
+	siteURLConfigured = true
+}
+
 // GetJiraTicketIDFromArgs retrieves a JIRA ticket ID from the provided arguments.
 // parameters:
 // - args: A variable number of string arguments that may contain JIRA issue URLs.
@@ -17,14 +45,11 @@ import (
 // - jiraTicketID: The JIRA ticket ID if found.
 // - ok: A boolean indicating whether a JIRA ticket ID was found.
 func GetJiraTicketIDFromArgs(args ...string) (jiraTicketID string, ok bool) {
-	// Define a regular expression to match JIRA issue URLs
-	// The regex matches URLs like "https://<subdomain>.atlassian.net/browse/<ISSUE-KEY>"
-	re := regexp.MustCompile(`https://[a-zA-Z0-9-]+\.atlassian\.net/browse/([A-Z]+-[A-Z0-9-]+)`)
 	for _, arg := range args {
 		// Check if the argument matches the pattern
-		if matches := re.FindStringSubmatch(arg); matches != nil {
-			// Return the issue key (group 1) and true
-			return matches[1], true
+		if matches := ticketURLRegexp.FindStringSubmatch(arg); matches != nil {
+			// Return the issue key and true
+			return matches[2], true
 		}
 	}
 
@@ -32,11 +57,51 @@ func GetJiraTicketIDFromArgs(args ...string) (jiraTicketID string, ok bool) {
 	return "", false
 }
 
+// SiteURL returns the Jira site of the ticket URL, e.g. "https://untill.atlassian.net"
+// or "https://jira.example.com/jira" for sites under a context path.
+// If the ticket URL is not a Jira one, the site is taken from JIRA_URL or the configured one is used.
+func SiteURL(ticketURL string) string {
+	if matches := ticketURLRegexp.FindStringSubmatch(ticketURL); matches != nil {
+		return matches[1]
+	}
+
+	return configuredSiteURL()
+}
+
+// configuredSiteURL returns the site from JIRA_URL or the configured one
+func configuredSiteURL() string {
+	if site := strings.TrimSuffix(os.Getenv(EnvJiraURL), "/"); len(site) > 0 && !siteURLConfigured {
+		return site
+	}
+
+	return defaultSiteURL
+}
+
+// checkTrustedSite returns tracker.ErrUntrustedSite unless the site is served over https
+// and is either a Jira Cloud site or the configured one, credentials are sent to trusted sites only
+func checkTrustedSite(site string) error {
+	u, err := url.Parse(site)
+	if err != nil || u.Scheme != "https" {
+		return fmt.Errorf("%w: %s is not served over https", tracker.ErrUntrustedSite, site)
+	}
+	if strings.HasSuffix(strings.ToLower(u.Hostname()), cloudHostSuffix) {
+		return nil
+	}
+	if configured, err := url.Parse(configuredSiteURL()); err == nil && strings.EqualFold(configured.Host, u.Host) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s is neither a Jira Cloud site nor the configured jira.url", tracker.ErrUntrustedSite, site)
+}
+
 // GetJiraIssueTitle retrieves the name of a JIRA issue based on its ticket ID or URL.
+// The site is derived from the ticket URL, see SiteURL.
+// Jira Cloud is accessed with JIRA_EMAIL and JIRA_API_TOKEN (basic auth),
+// Jira Server/Data Center is accessed with JIRA_PAT personal access token (Bearer auth).
 // parameters:
 // - ticketURL: The URL of the JIRA ticket (optional).
 // - ticketID: The ID of the JIRA ticket (optional).
-func GetJiraIssueTitle(ticketURL, ticketID string) (string, string, error) {
+func GetJiraIssueTitle(ctx context.Context, ticketURL, ticketID string) (string, string, error) {
 	// Validate the issue key
 	if ticketID == "" {
 		var ok bool
@@ -46,42 +111,57 @@ func GetJiraIssueTitle(ticketURL, ticketID string) (string, string, error) {
 		}
 	}
 
-	// Retrieve API token and email from environment variables
-	apiToken := os.Getenv("JIRA_API_TOKEN")
-	if apiToken == "" {
-		fmt.Println("--------------------------------------------------------------------------------")
-		fmt.Println("Error: JIRA API token not found. Please set environment variable JIRA_API_TOKEN.")
-		fmt.Println("            Jira API token can generate on this page:")
-		fmt.Println("          https://id.atlassian.com/manage-profile/security/api-tokens           ")
-		fmt.Println("--------------------------------------------------------------------------------")
-
-		return "", ticketID, errors.New("error: JIRA API token not found")
+	var result struct {
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	}
+	if err := doRequest(ctx, http.MethodGet, ticketURL, "/issue/"+ticketID, nil, &result); err != nil {
+		return "", ticketID, err
 	}
-	var (
-		email string
-		err   error
-	)
 
-	email = os.Getenv("JIRA_EMAIL")
-	if email == "" {
-		return "", ticketID, errors.New("error: please export JIRA_EMAIL")
+	return result.Fields.Summary, ticketID, nil
+}
+
+// apiURL returns the REST API URL of the Jira site of the ticket URL.
+// Jira Cloud is served by REST API v3, Jira Server/Data Center by v2.
+func apiURL(ticketURL string) string {
+	site := SiteURL(ticketURL)
+	apiVersion := serverAPIVersion
+	if u, err := url.Parse(site); err == nil && strings.HasSuffix(u.Hostname(), cloudHostSuffix) {
+		apiVersion = cloudAPIVersion
 	}
 
-	// Build the request URL
-	url := fmt.Sprintf("%s/rest/api/3/issue/%s", jiraDomain, ticketID)
+	return fmt.Sprintf("%s/rest/api/%s", site, apiVersion)
+}
 
-	// Create HTTP client and request
-	req, err := http.NewRequest("GET", url, nil)
+// doRequest sends the request to the REST API of the Jira site of the ticket URL.
+// reqBody and result are marshaled to and unmarshaled from JSON if not nil.
+func doRequest(ctx context.Context, method, ticketURL, path string, reqBody, result any) error {
+	var bodyReader io.Reader
+	if reqBody != nil {
+		data, err := json.Marshal(reqBody)
+		if err != nil {
+			return err
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+
+	if err := checkTrustedSite(SiteURL(ticketURL)); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, apiURL(ticketURL)+path, bodyReader)
 	if err != nil {
-		return "", ticketID, err
+		return err
+	}
+	if err := setAuth(req); err != nil {
+		return err
 	}
-	req.SetBasicAuth(email, apiToken)
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return "", ticketID, err
+		return err
 	}
 	defer func() {
 		_ = resp.Body.Close()
@@ -90,31 +170,54 @@ func GetJiraIssueTitle(ticketURL, ticketID string) (string, string, error) {
 	// Read and parse the response
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", ticketID, err
+		return err
 	}
-	// Issue does not exist or you do not have permission to see it.
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		// Issue does not exist or you do not have permission to see it.
 		if resp.StatusCode == http.StatusNotFound {
-			return "", ticketID, ErrJiraIssueNotFoundOrInsufficientPermission
+			return utils.HTTPStatusError(resp.StatusCode, ErrJiraIssueNotFoundOrInsufficientPermission)
 		}
 
-		return "", ticketID, err
+		return utils.HTTPStatusError(resp.StatusCode, fmt.Errorf("jira request %s %s failed: %s", method, path, resp.Status))
 	}
 
-	var result struct {
-		Fields struct {
-			Summary string `json:"summary"`
-		} `json:"fields"`
+	if result == nil {
+		return nil
 	}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return "", ticketID, fmt.Errorf("error parsing JSON response: %w", err)
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("error parsing JSON response: %w", err)
 	}
 
-	// Check if the summary field exists
-	if result.Fields.Summary == "" {
-		return "", ticketID, nil
+	return nil
+}
+
+// setAuth sets Bearer auth if JIRA_PAT is set, otherwise basic auth with JIRA_EMAIL and JIRA_API_TOKEN
+func setAuth(req *http.Request) error {
+	if pat := os.Getenv(EnvJiraPAT); pat != "" {
+		req.Header.Set("Authorization", "Bearer "+pat)
+
+		return nil
 	}
 
-	return result.Fields.Summary, ticketID, nil
+	// Retrieve API token and email from environment variables
+	apiToken := os.Getenv(EnvJiraAPIToken)
+	if apiToken == "" {
+		fmt.Println("--------------------------------------------------------------------------------")
+		fmt.Println("Error: JIRA API token not found. Please set environment variable JIRA_API_TOKEN.")
+		fmt.Println("            Jira API token can generate on this page:")
+		fmt.Println("          https://id.atlassian.com/manage-profile/security/api-tokens           ")
+		fmt.Println("  For Jira Server/Data Center set personal access token to JIRA_PAT instead.   ")
+		fmt.Println("--------------------------------------------------------------------------------")
+
+		return errors.New("error: JIRA API token not found")
+	}
+
+	email := os.Getenv(EnvJiraEmail)
+	if email == "" {
+		return errors.New("error: please export JIRA_EMAIL")
+	}
+	req.SetBasicAuth(email, apiToken)
+
+	return nil
 }
@@ -1,9 +1,30 @@
 package jira
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/tracker"
 )
 
+// newTestSite starts the https Jira site configured by JIRA_URL
+func newTestSite(t *testing.T, handler http.Handler) *httptest.Server {
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+	client := httpClient
+	httpClient = srv.Client()
+	t.Cleanup(func() {
+		httpClient = client
+	})
+	t.Setenv(EnvJiraURL, srv.URL)
+
+	return srv
+}
+
 func TestContainsJiraName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -29,6 +50,12 @@ func TestContainsJiraName(t *testing.T) {
 			expected: "AIR-270",
 			ok:       true,
 		},
+		{
+			name:     "Jira Data Center URL with context path",
+			args:     []string{"https://jira.example.com/jira/browse/PROJ_2-15"},
+			expected: "PROJ_2-15",
+			ok:       true,
+		},
 		{
 			name:     "No JIRA issue URL",
 			args:     []string{"random-text", "another-arg"},
@@ -52,3 +79,120 @@ func TestContainsJiraName(t *testing.T) {
 		})
 	}
 }
+
+func TestSiteURL(t *testing.T) {
+	t.Setenv(EnvJiraURL, "")
+	require.Equal(t, "https://voedger.atlassian.net", SiteURL("https://voedger.atlassian.net/browse/AIR-270"))
+	require.Equal(t, "https://jira.example.com/jira", SiteURL("https://jira.example.com/jira/browse/PROJ-15"))
+	require.Equal(t, defaultSiteURL, SiteURL(""))
+
+	t.Setenv(EnvJiraURL, "https://jira.example.com/")
+	require.Equal(t, "https://jira.example.com", SiteURL(""))
+}
+
+func TestSetDefaultSiteURL_FlagBeatsEnv(t *testing.T) {
+	siteURL, configured := defaultSiteURL, siteURLConfigured
+	t.Cleanup(func() {
+		defaultSiteURL, siteURLConfigured = siteURL, configured
+	})
+	t.Setenv(EnvJiraURL, "https://jira.example.com")
+
+	// the config resolves -c jira.url above JIRA_URL
+	SetDefaultSiteURL("https://flag.atlassian.net/")
+	require.Equal(t, "https://flag.atlassian.net", SiteURL(""))
+}
+
+func TestGetJiraIssueTitle(t *testing.T) {
+	t.Run("Bearer personal access token", func(t *testing.T) {
+		srv := newTestSite(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/jira/rest/api/2/issue/PROJ-15", r.URL.Path)
+			require.Equal(t, "Bearer test-pat", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"fields":{"summary":"Fix bug"}}`))
+		}))
+		t.Setenv(EnvJiraPAT, "test-pat")
+
+		title, id, err := GetJiraIssueTitle(context.Background(), srv.URL+"/jira/browse/PROJ-15", "")
+		require.NoError(t, err)
+		require.Equal(t, "Fix bug", title)
+		require.Equal(t, "PROJ-15", id)
+	})
+
+	t.Run("basic auth", func(t *testing.T) {
+		srv := newTestSite(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			email, token, ok := r.BasicAuth()
+			require.True(t, ok)
+			require.Equal(t, "me@example.com", email)
+			require.Equal(t, "api-token", token)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		t.Setenv(EnvJiraPAT, "")
+		t.Setenv(EnvJiraEmail, "me@example.com")
+		t.Setenv(EnvJiraAPIToken, "api-token")
+
+		_, _, err := GetJiraIssueTitle(context.Background(), srv.URL+"/browse/PROJ-15", "")
+		require.ErrorIs(t, err, ErrJiraIssueNotFoundOrInsufficientPermission)
+	})
+
+	t.Run("unexpected status", func(t *testing.T) {
+		srv := newTestSite(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		t.Setenv(EnvJiraPAT, "expired")
+
+		_, _, err := GetJiraIssueTitle(context.Background(), srv.URL+"/browse/PROJ-15", "")
+		require.ErrorContains(t, err, "401")
+	})
+}
+
+func TestTracker_Transition(t *testing.T) {
+	require := require.New(t)
+	t.Setenv(EnvJiraPAT, "test-pat")
+
+	transitioned := false
+	srv := newTestSite(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("/rest/api/2/issue/PROJ-15/transitions", r.URL.Path)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"transitions":[{"id":"11","to":{"name":"To Do"}},{"id":"21","to":{"name":"In Progress"}}]}`))
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+		require.JSONEq(`{"transition":{"id":"21"}}`, string(body))
+		transitioned = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	jt := NewTracker()
+	issueURL := srv.URL + "/browse/PROJ-15"
+	require.True(jt.Match(issueURL))
+	require.Equal("PROJ-15", jt.ExtractID(issueURL))
+
+	require.NoError(jt.Transition(context.Background(), tracker.Env{}, issueURL, "in progress"))
+	require.True(transitioned)
+
+	require.Error(jt.Transition(context.Background(), tracker.Env{}, issueURL, "Done"))
+	require.ErrorIs(jt.Link(context.Background(), tracker.Env{}, issueURL, tracker.Branch{}), tracker.ErrNotSupported)
+}
+
+func TestCheckTrustedSite(t *testing.T) {
+	t.Setenv(EnvJiraURL, "https://jira.example.com/jira")
+	require.NoError(t, checkTrustedSite("https://voedger.atlassian.net"))
+	require.NoError(t, checkTrustedSite("https://jira.example.com/jira"))
+	require.ErrorIs(t, checkTrustedSite("http://jira.example.com/jira"), tracker.ErrUntrustedSite)
+	require.ErrorIs(t, checkTrustedSite("http://voedger.atlassian.net"), tracker.ErrUntrustedSite)
+	require.ErrorIs(t, checkTrustedSite("https://evil.example.com"), tracker.ErrUntrustedSite)
+	require.ErrorIs(t, checkTrustedSite("https://atlassian.net.evil.com"), tracker.ErrUntrustedSite)
+
+	t.Run("credentials are not sent", func(t *testing.T) {
+		requests := 0
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests++
+		}))
+		defer srv.Close()
+		t.Setenv(EnvJiraPAT, "test-pat")
+
+		_, _, err := GetJiraIssueTitle(context.Background(), srv.URL+"/browse/PROJ-15", "")
+		require.ErrorIs(t, err, tracker.ErrUntrustedSite)
+		require.Zero(t, requests)
+	})
+}
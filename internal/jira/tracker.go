@@ -0,0 +1,73 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/untillpro/qs/internal/tracker"
+)
+
+// jiraTracker handles Jira tickets, e.g. https://untill.atlassian.net/browse/AIR-270
+type jiraTracker struct{}
+
+// NewTracker returns the tracker of Jira tickets
+func NewTracker() tracker.IssueTracker {
+	return jiraTracker{}
+}
+
+func (jiraTracker) Name() string {
+	return "Jira"
+}
+
+func (jiraTracker) Match(issueURL string) bool {
+	_, ok := GetJiraTicketIDFromArgs(issueURL)
+
+	return ok
+}
+
+func (jiraTracker) ExtractID(issueURL string) string {
+	id, _ := GetJiraTicketIDFromArgs(issueURL)
+
+	return id
+}
+
+func (jiraTracker) FetchTitle(ctx context.Context, _ tracker.Env, issueURL string) (string, error) {
+	title, _, err := GetJiraIssueTitle(ctx, issueURL, "")
+
+	return title, err
+}
+
+// Link is not supported: Jira links branches by the ticket key in the branch name
+func (jiraTracker) Link(context.Context, tracker.Env, string, tracker.Branch) error {
+	return tracker.ErrNotSupported
+}
+
+// Transition performs the workflow transition leading to the given status
+func (t jiraTracker) Transition(ctx context.Context, _ tracker.Env, issueURL, status string) error {
+	id := t.ExtractID(issueURL)
+	path := "/issue/" + id + "/transitions"
+
+	var result struct {
+		Transitions []struct {
+			ID string `json:"id"`
+			To struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := doRequest(ctx, http.MethodGet, issueURL, path, nil, &result); err != nil {
+		return err
+	}
+
+	for _, transition := range result.Transitions {
+		if strings.EqualFold(transition.To.Name, status) {
+			reqBody := map[string]any{"transition": map[string]string{"id": transition.ID}}
+
+			return doRequest(ctx, http.MethodPost, issueURL, path, reqBody, nil)
+		}
+	}
+
+	return fmt.Errorf("no transition of %s to status %q", id, status)
+}
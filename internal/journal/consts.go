@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package journal
+
+// Commands recorded in the journal
+const (
+	CommandDev     = "dev"
+	CommandPR      = "pr"
+	CommandFork    = "fork"
+	CommandRelease = "r"
+)
+
+// Steps of the commands recorded in the journal
+const (
+	StepStashed           = "stashed"
+	StepUpstreamAdded     = "upstream-added"
+	StepBranchCreated     = "branch-created"
+	StepIssueLinked       = "issue-linked"
+	StepUnstashed         = "unstashed"
+	StepPRBranchCreated   = "pr-branch-created"
+	StepDevBranchRemoved  = "dev-branch-removed"
+	StepPushed            = "pushed"
+	StepForked            = "forked"
+	StepRemotesConfigured = "remotes-configured"
+	StepTargetCommitted   = "target-version-committed"
+	StepTagged            = "tagged"
+	StepNextCommitted     = "next-version-committed"
+)
+
+const (
+	// DirName is the directory of qs in the git directory
+	DirName  = "qs"
+	fileName = "journal.json"
+	tmpExt   = ".tmp"
+	dirPerm  = 0755
+	filePerm = 0644
+)
@@ -0,0 +1,10 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package journal
+
+import "errors"
+
+var ErrInterrupted = errors.New("previous qs command is not completed, run 'qs recover' to resume or roll it back")
@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Begin writes the journal of the command to dir.
+// The journal is kept in memory only if dir is empty.
+// ErrInterrupted is returned if the journal of another command exists in dir.
+func Begin(dir, command, branch string, refs map[string]string) (*Journal, error) {
+	j := &Journal{
+		Command:   command,
+		StartedAt: time.Now(),
+		Branch:    branch,
+		Refs:      refs,
+		Steps:     []string{},
+	}
+	if len(dir) == 0 {
+		return j, nil
+	}
+
+	existing, err := Load(dir)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: qs %s started at %s", ErrInterrupted, existing.Command, existing.StartedAt.Format(time.DateTime))
+	}
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	j.path = filepath.Join(dir, fileName)
+
+	return j, j.save()
+}
+
+// Load returns the journal kept in dir, nil if there is none
+func Load(dir string) (*Journal, error) {
+	path := filepath.Join(dir, fileName)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	j := &Journal{}
+	if err := json.Unmarshal(content, j); err != nil {
+		return nil, fmt.Errorf("failed to parse journal %s: %w", path, err)
+	}
+	j.path = path
+
+	return j, nil
+}
+
+// Detach keeps further changes of the journal in memory only
+func (j *Journal) Detach() {
+	if j != nil {
+		j.path = ""
+	}
+}
+
+// SetData stores the command specific state
+func (j *Journal) SetData(data any) error {
+	if j == nil {
+		return nil
+	}
+	content, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal data: %w", err)
+	}
+	j.Data = content
+
+	return j.save()
+}
+
+// ReadData reads the command specific state into data
+func (j *Journal) ReadData(data any) error {
+	if j == nil || len(j.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(j.Data, data); err != nil {
+		return fmt.Errorf("failed to parse journal data: %w", err)
+	}
+
+	return nil
+}
+
+// Done records the completed step
+func (j *Journal) Done(step string) error {
+	if j == nil || j.IsDone(step) {
+		return nil
+	}
+	j.Steps = append(j.Steps, step)
+
+	return j.save()
+}
+
+// IsDone returns true if the step is completed
+func (j *Journal) IsDone(step string) bool {
+	return j != nil && slices.Contains(j.Steps, step)
+}
+
+// Finish removes the journal of the completed command
+func (j *Journal) Finish() error {
+	if j == nil || len(j.path) == 0 {
+		return nil
+	}
+	if err := os.Remove(j.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove journal: %w", err)
+	}
+	j.path = ""
+
+	return nil
+}
+
+// save writes the journal to a temporary file and renames it, so the journal is never left half-written
+func (j *Journal) save() error {
+	if len(j.path) == 0 {
+		return nil
+	}
+	content, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal: %w", err)
+	}
+	tmp := j.path + tmpExt
+	if err := os.WriteFile(tmp, content, filePerm); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+
+	return nil
+}
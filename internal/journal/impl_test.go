@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testData struct {
+	Branch string `json:"branch"`
+}
+
+func TestJournal(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), DirName)
+
+	j, err := Begin(dir, "pr", "feature-dev", map[string]string{"refs/heads/feature-dev": "abc"})
+	require.NoError(t, err)
+	require.NoError(t, j.SetData(testData{Branch: "feature-pr"}))
+	require.NoError(t, j.Done(StepPRBranchCreated))
+	require.NoError(t, j.Done(StepPRBranchCreated))
+
+	t.Run("journal survives the process", func(t *testing.T) {
+		loaded, err := Load(dir)
+		require.NoError(t, err)
+		require.Equal(t, "pr", loaded.Command)
+		require.Equal(t, "feature-dev", loaded.Branch)
+		require.Equal(t, "abc", loaded.Refs["refs/heads/feature-dev"])
+		require.Equal(t, []string{StepPRBranchCreated}, loaded.Steps)
+		require.True(t, loaded.IsDone(StepPRBranchCreated))
+		require.False(t, loaded.IsDone(StepPushed))
+
+		var data testData
+		require.NoError(t, loaded.ReadData(&data))
+		require.Equal(t, "feature-pr", data.Branch)
+	})
+
+	t.Run("another command can not begin", func(t *testing.T) {
+		_, err := Begin(dir, "dev", "main", nil)
+		require.ErrorIs(t, err, ErrInterrupted)
+	})
+
+	t.Run("detached journal is not written", func(t *testing.T) {
+		loaded, err := Load(dir)
+		require.NoError(t, err)
+		loaded.Detach()
+		require.NoError(t, loaded.Done(StepPushed))
+		require.NoError(t, loaded.Finish())
+
+		again, err := Load(dir)
+		require.NoError(t, err)
+		require.False(t, again.IsDone(StepPushed))
+	})
+
+	t.Run("finished journal is removed", func(t *testing.T) {
+		require.NoError(t, j.Finish())
+		loaded, err := Load(dir)
+		require.NoError(t, err)
+		require.Nil(t, loaded)
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+}
+
+func TestJournal_InMemory(t *testing.T) {
+	j, err := Begin("", "r", "main", nil)
+	require.NoError(t, err)
+	require.NoError(t, j.Done(StepTagged))
+	require.True(t, j.IsDone(StepTagged))
+	require.NoError(t, j.Finish())
+
+	var nilJournal *Journal
+	require.NoError(t, nilJournal.Done(StepTagged))
+	require.False(t, nilJournal.IsDone(StepTagged))
+	require.NoError(t, nilJournal.Finish())
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package journal
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Journal is the record of a multi-step command kept in the git directory until the command is completed
+type Journal struct {
+	// Command is the name of the command, e.g. "pr"
+	Command   string    `json:"command"`
+	StartedAt time.Time `json:"startedAt"`
+	// Branch is the branch checked out when the command started, empty if HEAD was detached
+	Branch string `json:"branch,omitempty"`
+	// Refs are the original SHAs of the refs changed by the command
+	Refs map[string]string `json:"refs,omitempty"`
+	// Steps lists the completed steps in order
+	Steps []string `json:"steps"`
+	// Data is the command specific state needed to resume the command
+	Data json.RawMessage `json:"data,omitempty"`
+
+	// path is the journal file, the journal is kept in memory only if it is empty
+	path string
+}
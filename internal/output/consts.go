@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package output
+
+const (
+	FormatText Format = "text"
+	FormatJSON Format = "json"
+
+	jsonIndent = "  "
+)
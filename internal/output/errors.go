@@ -0,0 +1,10 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package output
+
+import "errors"
+
+var ErrUnknownFormat = errors.New("unknown output format, use text or json")
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ParseFormat validates the value of the --output flag
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatText, FormatJSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+	}
+}
+
+// Write prints the document as indented JSON
+func Write(w io.Writer, doc Document) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", jsonIndent)
+
+	return enc.Encode(doc)
+}
@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("json")
+	require.NoError(t, err)
+	require.Equal(t, FormatJSON, f)
+
+	_, err = ParseFormat("yaml")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestWrite(t *testing.T) {
+	var sb strings.Builder
+	require.NoError(t, Write(&sb, Document{Command: "qs pr", Result: map[string]int{"number": 7}}))
+	require.JSONEq(t, `{"command":"qs pr","result":{"number":7}}`, sb.String())
+
+	sb.Reset()
+	require.NoError(t, Write(&sb, Document{Command: "qs dev", Error: "failed"}))
+	require.JSONEq(t, `{"command":"qs dev","error":"failed"}`, sb.String())
+}
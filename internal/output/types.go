@@ -0,0 +1,20 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package output
+
+// Format is the format of the command output
+type Format string
+
+// Document is the single structured document printed by a command in JSON format
+type Document struct {
+	// Command is the command path, e.g. "qs dev"
+	Command string `json:"command"`
+	// Error is the error message, empty if the command succeeded
+	Error  string `json:"error,omitempty"`
+	Result any    `json:"result,omitempty"`
+	// DryRun lists the mutating operations skipped by --dry-run in order
+	DryRun []string `json:"dryRun,omitempty"`
+}
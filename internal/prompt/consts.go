@@ -0,0 +1,15 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package prompt
+
+const (
+	// EnvAssumeYes answers yes to all confirmations if set to true
+	EnvAssumeYes = "QS_ASSUME_YES"
+
+	confirmSuffix = " (y/n)? "
+	answerYes     = "y"
+	answerYesLong = "yes"
+)
@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package prompt
+
+import "errors"
+
+var (
+	ErrAnswerRequired = errors.New("answer is required but input is not available")
+	ErrNoTerminal     = errors.New("stdin is not a terminal")
+)
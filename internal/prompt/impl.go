@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+func (p *prompter) Confirm(question string) (bool, error) {
+	_, _ = fmt.Fprint(p.out, question+confirmSuffix)
+	if p.mode == ModeAssumeYes {
+		_, _ = fmt.Fprintln(p.out, answerYes)
+		return true, nil
+	}
+
+	answer, err := p.answer(question)
+	if err != nil {
+		return false, fmt.Errorf("%w, use --yes or %s=true to confirm", err, EnvAssumeYes)
+	}
+	answer = strings.ToLower(answer)
+
+	return answer == answerYes || answer == answerYesLong, nil
+}
+
+func (p *prompter) Input(question string) (string, error) {
+	_, _ = fmt.Fprint(p.out, question+": ")
+
+	return p.answer(question)
+}
+
+func (p *prompter) answer(question string) (string, error) {
+	if p.mode != ModeInteractive {
+		_, _ = fmt.Fprintln(p.out)
+		return "", fmt.Errorf("%w: %q", ErrAnswerRequired, question)
+	}
+	// a pipe held open by the caller would block forever, so answers are read from a terminal only
+	if !p.isTerminal() {
+		_, _ = fmt.Fprintln(p.out)
+		return "", fmt.Errorf("%w: %q: %w", ErrAnswerRequired, question, ErrNoTerminal)
+	}
+
+	answer, err := readLine(p.in())
+	if errors.Is(err, io.EOF) && len(answer) == 0 {
+		_, _ = fmt.Fprintln(p.out)
+		return "", fmt.Errorf("%w: %q: %w", ErrAnswerRequired, question, err)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+
+	return strings.TrimSpace(answer), nil
+}
+
+// readLine reads bytes up to the newline one by one, so the rest of the input is left for the next question
+func readLine(r io.Reader) (string, error) {
+	var sb strings.Builder
+	b := make([]byte, 1)
+	for {
+		n, err := r.Read(b)
+		if n > 0 {
+			if b[0] == '\n' {
+				return sb.String(), nil
+			}
+			sb.WriteByte(b[0])
+		}
+		if err != nil {
+			return sb.String(), err
+		}
+	}
+}
+
+func stdinIsTerminal() bool {
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
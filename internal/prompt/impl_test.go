@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package prompt
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    Mode
+		input   string
+		want    bool
+		wantErr error
+	}{
+		{"yes", ModeInteractive, "y\n", true, nil},
+		{"yes long", ModeInteractive, " Yes \n", true, nil},
+		{"no", ModeInteractive, "n\n", false, nil},
+		{"empty line", ModeInteractive, "\n", false, nil},
+		{"last line without newline", ModeInteractive, "y", true, nil},
+		{"no input available", ModeInteractive, "", false, io.EOF},
+		{"assume yes", ModeAssumeYes, "", true, nil},
+		{"no input mode", ModeNoInput, "y\n", false, ErrAnswerRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewWithIO(strings.NewReader(tt.input), io.Discard, tt.mode)
+			got, err := p.Confirm("Continue")
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, ErrAnswerRequired)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestInput(t *testing.T) {
+	var out strings.Builder
+	p := NewWithIO(strings.NewReader("first title \ny\n"), &out, ModeInteractive)
+
+	title, err := p.Input("Enter pull request title")
+	require.NoError(t, err)
+	require.Equal(t, "first title", title)
+	require.Equal(t, "Enter pull request title: ", out.String())
+
+	// the rest of the input is left for the next question
+	ok, err := p.Confirm("Continue")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = NewWithIO(strings.NewReader("title\n"), io.Discard, ModeAssumeYes).Input("Enter pull request title")
+	require.ErrorIs(t, err, ErrAnswerRequired)
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv(EnvAssumeYes, "true")
+	require.Equal(t, ModeAssumeYes, New(false, true).(*prompter).mode)
+
+	t.Setenv(EnvAssumeYes, "")
+	require.Equal(t, ModeNoInput, New(false, true).(*prompter).mode)
+	require.Equal(t, ModeInteractive, New(false, false).(*prompter).mode)
+}
+
+func TestConfirm_NotTerminal(t *testing.T) {
+	p := &prompter{
+		mode: ModeInteractive,
+		in: func() io.Reader {
+			require.Fail(t, "stdin which is not a terminal is not read")
+			return nil
+		},
+		out:        io.Discard,
+		isTerminal: func() bool { return false },
+	}
+
+	ok, err := p.Confirm("Continue")
+	require.ErrorIs(t, err, ErrAnswerRequired)
+	require.ErrorIs(t, err, ErrNoTerminal)
+	require.False(t, ok)
+}
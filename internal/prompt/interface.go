@@ -0,0 +1,14 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package prompt
+
+// Prompter asks the user questions
+type Prompter interface {
+	// Confirm asks a yes/no question, returns true if the answer is yes
+	Confirm(question string) (bool, error)
+	// Input asks for a line of text, returns the trimmed answer
+	Input(question string) (string, error)
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package prompt
+
+import (
+	"io"
+	"os"
+	"strconv"
+)
+
+// New returns a Prompter which reads answers from stdin.
+// assumeYes or QS_ASSUME_YES=true answers yes to all confirmations, noInput fails on any question.
+// Questions fail at once if stdin is not a terminal.
+func New(assumeYes, noInput bool) Prompter {
+	mode := ModeInteractive
+	envAssumeYes, _ := strconv.ParseBool(os.Getenv(EnvAssumeYes))
+	switch {
+	case assumeYes || envAssumeYes:
+		mode = ModeAssumeYes
+	case noInput:
+		mode = ModeNoInput
+	}
+
+	return &prompter{
+		mode:       mode,
+		in:         func() io.Reader { return os.Stdin },
+		out:        os.Stdout,
+		isTerminal: stdinIsTerminal,
+	}
+}
+
+// NewWithIO returns a Prompter which reads answers from in as if they are typed in a terminal and writes questions to out
+func NewWithIO(in io.Reader, out io.Writer, mode Mode) Prompter {
+	return &prompter{
+		mode:       mode,
+		in:         func() io.Reader { return in },
+		out:        out,
+		isTerminal: func() bool { return true },
+	}
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package prompt
+
+import "io"
+
+// Mode defines how questions are answered
+type Mode int
+
+const (
+	// ModeInteractive reads answers from stdin
+	ModeInteractive Mode = iota
+	// ModeAssumeYes answers yes to all confirmations without reading stdin
+	ModeAssumeYes
+	// ModeNoInput fails on any question
+	ModeNoInput
+)
+
+type prompter struct {
+	mode Mode
+	// in is called on each question since stdin can be replaced, e.g. by system tests
+	in  func() io.Reader
+	out io.Writer
+	// isTerminal reports whether answers are typed by the user
+	isTerminal func() bool
+}
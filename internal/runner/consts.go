@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package runner
+
+import "time"
+
+const (
+	git          = "git"
+	dryRunPrefix = "[dry-run] "
+	// cLocaleEnv overrides LANG and LC_* of the user
+	cLocaleEnv = "LC_ALL=C"
+	// killDelay is the time given to the interrupted command to exit before it is killed
+	killDelay = 5 * time.Second
+)
+
+// readOnlyGitCommands never change the repository
+var readOnlyGitCommands = map[string]bool{
+	"status":       true,
+	"log":          true,
+	"show":         true,
+	"diff":         true,
+	"rev-parse":    true,
+	"rev-list":     true,
+	"cat-file":     true,
+	"ls-files":     true,
+	"ls-tree":      true,
+	"ls-remote":    true,
+	"merge-base":   true,
+	"for-each-ref": true,
+	"show-ref":     true,
+	"describe":     true,
+	"version":      true,
+}
+
+// listFlags make branch, remote, stash and notes commands read-only
+var listFlags = map[string]bool{
+	"-r":             true,
+	"-a":             true,
+	"-v":             true,
+	"-vv":            true,
+	"-l":             true,
+	"--list":         true,
+	"--show-current": true,
+	"--contains":     true,
+	"--merged":       true,
+	"list":           true,
+	"show":           true,
+	"get-url":        true,
+}
+
+// branchChangeFlags make branch commands mutating even if list flags are given, e.g. "git branch -d -r origin/x"
+var branchChangeFlags = map[string]bool{
+	"-d":       true,
+	"-D":       true,
+	"--delete": true,
+	"-m":       true,
+	"-M":       true,
+	"--move":   true,
+	"-c":       true,
+	"-C":       true,
+	"--copy":   true,
+}
+
+// configGetFlags make config commands read-only
+var configGetFlags = map[string]bool{
+	"--get":        true,
+	"--get-all":    true,
+	"--get-regexp": true,
+	"--list":       true,
+	"-l":           true,
+}
+
+// configSetFlags make config commands mutating regardless of the number of values
+var configSetFlags = map[string]bool{
+	"--unset":          true,
+	"--unset-all":      true,
+	"--add":            true,
+	"--replace-all":    true,
+	"--remove-section": true,
+	"--rename-section": true,
+}
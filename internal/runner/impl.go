@@ -0,0 +1,225 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+func (execRunner) Run(ctx context.Context, wd string, name string, args ...string) (stdout string, stderr string, err error) {
+	var outBuf, errBuf bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = wd
+	if name == git {
+		// messages of git are parsed, so they must not be translated
+		cmd.Env = append(os.Environ(), cLocaleEnv)
+	}
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+	// the command is asked to stop first so that git removes its lock files, it is killed if it does not exit in time
+	cmd.Cancel = func() error {
+		if runtime.GOOS == "windows" {
+			return cmd.Process.Kill()
+		}
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = killDelay
+
+	err = cmd.Run()
+	if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+		err = fmt.Errorf("%s is interrupted: %w", name, ctxErr)
+	}
+
+	return outBuf.String(), errBuf.String(), err
+}
+
+func (d *DryRun) Run(ctx context.Context, wd string, name string, args ...string) (stdout string, stderr string, err error) {
+	if name != git || IsReadOnlyGit(args) {
+		return d.inner.Run(ctx, wd, name, args...)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", "", err
+	}
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if len(arg) == 0 || strings.ContainsAny(arg, " \t\n\"'") {
+			arg = strconv.Quote(arg)
+		}
+		quoted = append(quoted, arg)
+	}
+	d.Plan(Call{Name: name, Args: quoted}.String())
+
+	return "", "", nil
+}
+
+// Plan prints and records the mutating operation which is skipped, e.g. an API call
+func (d *DryRun) Plan(operation string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.planned = append(d.planned, operation)
+	_, _ = fmt.Fprintln(d.out, dryRunPrefix+operation)
+}
+
+// Planned returns the skipped mutating operations in order
+func (d *DryRun) Planned() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]string(nil), d.planned...)
+}
+
+// GitSubcommand returns the subcommand of git with its params, global options are skipped, e.g. "-c color.status=always"
+func GitSubcommand(args []string) (subcommand string, params []string) {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		if args[0] == "-c" || args[0] == "-C" {
+			args = args[1:]
+		}
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return "", nil
+	}
+
+	return args[0], args[1:]
+}
+
+// IsReadOnlyGit returns true if the git command with the given args does not change the repository
+func IsReadOnlyGit(args []string) bool {
+	subcommand, params := GitSubcommand(args)
+	if len(subcommand) == 0 {
+		return true
+	}
+
+	switch subcommand {
+	case "branch", "remote", "stash", "notes":
+		if subcommand != "stash" && len(params) == 0 {
+			return true
+		}
+		for _, p := range params {
+			if branchChangeFlags[p] {
+				return false
+			}
+		}
+		for _, p := range params {
+			if listFlags[p] {
+				return true
+			}
+		}
+		return false
+	case "config":
+		values := 0
+		for _, p := range params {
+			if configSetFlags[p] {
+				return false
+			}
+			if configGetFlags[p] {
+				return true
+			}
+			if !strings.HasPrefix(p, "-") {
+				values++
+			}
+		}
+		// "git config key" reads the value, "git config key value" sets it
+		return values == 1
+	default:
+		return readOnlyGitCommands[subcommand]
+	}
+}
+
+// String returns the command line of the call, e.g. "git checkout main"
+func (c Call) String() string {
+	return strings.Join(append([]string{c.Name}, c.Args...), " ")
+}
+
+// Return sets the result of the commands matched by the reply
+func (r *Reply) Return(stdout, stderr string, err error) *Reply {
+	r.stdout = stdout
+	r.stderr = stderr
+	r.err = err
+
+	return r
+}
+
+// Once makes the reply match only the first suitable call, so the next reply for the same pattern is used afterwards
+func (r *Reply) Once() *Reply {
+	r.once = true
+
+	return r
+}
+
+func (r *Reply) matches(cmdLine string) bool {
+	if r.once && r.used {
+		return false
+	}
+
+	return cmdLine == r.pattern || strings.HasPrefix(cmdLine, r.pattern+" ")
+}
+
+// On registers a reply for commands whose command line equals the pattern or starts with the pattern followed by a space.
+// Replies are matched in order of registration.
+func (f *Fake) On(pattern string) *Reply {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r := &Reply{pattern: pattern}
+	f.replies = append(f.replies, r)
+
+	return r
+}
+
+func (f *Fake) Run(ctx context.Context, wd string, name string, args ...string) (stdout string, stderr string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	call := Call{Wd: wd, Name: name, Args: append([]string(nil), args...)}
+	f.calls = append(f.calls, call)
+	if err := ctx.Err(); err != nil {
+		return "", "", err
+	}
+
+	cmdLine := call.String()
+	for _, r := range f.replies {
+		if r.matches(cmdLine) {
+			r.used = true
+			return r.stdout, r.stderr, r.err
+		}
+	}
+
+	return "", "", nil
+}
+
+// Calls returns command lines of all recorded calls in order
+func (f *Fake) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	res := make([]string, 0, len(f.calls))
+	for _, c := range f.calls {
+		res = append(res, c.String())
+	}
+
+	return res
+}
+
+// Ran reports whether a command matching the pattern was called
+func (f *Fake) Ran(pattern string) bool {
+	r := Reply{pattern: pattern}
+	for _, cmdLine := range f.Calls() {
+		if r.matches(cmdLine) {
+			return true
+		}
+	}
+
+	return false
+}
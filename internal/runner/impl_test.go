@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package runner
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	fake := NewFake()
+	fake.On("git rev-parse --abbrev-ref HEAD").Return("main\n", "", nil)
+	var out strings.Builder
+	d := NewDryRun(fake, &out)
+
+	cmds := [][]string{
+		{"git", "rev-parse", "--abbrev-ref", "HEAD"},
+		{"git", "-c", "color.status=always", "status", "-s"},
+		{"git", "branch", "-vv"},
+		{"git", "remote"},
+		{"git", "config", "--get", "core.hooksPath"},
+		{"git", "config", "--local", "remote.origin.url"},
+		{"git", "notes", "show", "abc"},
+		{"git", "stash", "list"},
+		{"gh", "auth", "token"},
+		{"git", "fetch", "origin"},
+		{"git", "checkout", "-b", "dev"},
+		{"git", "branch", "-D", "old"},
+		{"git", "remote", "add", "upstream", "url"},
+		{"git", "config", "--global", "core.hooksPath", "/hooks"},
+		{"git", "config", "--global", "--unset", "core.hooksPath"},
+		{"git", "notes", "append", "-m", "two words"},
+		{"git", "stash"},
+		{"git", "push", "origin", "dev"},
+	}
+	for _, c := range cmds {
+		_, _, err := d.Run(context.Background(), "", c[0], c[1:]...)
+		require.NoError(t, err)
+	}
+	d.Plan("create pull request")
+
+	planned := []string{
+		"git fetch origin",
+		"git checkout -b dev",
+		"git branch -D old",
+		"git remote add upstream url",
+		"git config --global core.hooksPath /hooks",
+		"git config --global --unset core.hooksPath",
+		`git notes append -m "two words"`,
+		"git stash",
+		"git push origin dev",
+		"create pull request",
+	}
+	require.Equal(t, planned, d.Planned())
+	require.Len(t, fake.Calls(), 9)
+	require.Equal(t, dryRunPrefix+strings.Join(planned, "\n"+dryRunPrefix)+"\n", out.String())
+}
+
+func TestExecRunner_Cancel(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep is not available")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err := New().Run(ctx, "", "sleep", "10")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), killDelay)
+}
+
+func TestFake_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fake := NewFake()
+	_, _, err := fake.Run(ctx, "", "git", "push")
+	require.ErrorIs(t, err, context.Canceled)
+	require.True(t, fake.Ran("git push"))
+}
+
+func TestExecRunner_CLocale(t *testing.T) {
+	if _, err := exec.LookPath(git); err != nil {
+		t.Skip("git is not available")
+	}
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+
+	stdout, _, err := New().Run(context.Background(), t.TempDir(), git, "-c", "alias.locale=!printenv LC_ALL", "locale")
+	require.NoError(t, err)
+	require.Equal(t, "C", strings.TrimSpace(stdout))
+}
+
+func TestIsReadOnlyGit(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"branch"}, true},
+		{[]string{"branch", "-r"}, true},
+		{[]string{"branch", "-a", "--contains", "abc"}, true},
+		{[]string{"branch", "-D", "old"}, false},
+		{[]string{"branch", "-d", "-r", "origin/x"}, false},
+		{[]string{"branch", "-D", "-a", "x"}, false},
+		{[]string{"branch", "--delete", "--list", "x"}, false},
+		{[]string{"branch", "-m", "-v", "old", "new"}, false},
+		{[]string{"branch", "-C", "-l", "old", "new"}, false},
+		{[]string{"remote", "-v"}, true},
+		{[]string{"remote", "remove", "origin"}, false},
+		{[]string{"stash", "list"}, true},
+		{[]string{"stash"}, false},
+		{[]string{"config", "--get", "core.hooksPath"}, true},
+		{[]string{"config", "--unset", "core.hooksPath"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			require.Equal(t, tt.want, IsReadOnlyGit(tt.args))
+		})
+	}
+}
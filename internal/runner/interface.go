@@ -0,0 +1,16 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package runner
+
+import "context"
+
+// CommandRunner runs external commands (git, gh) on behalf of qs
+type CommandRunner interface {
+	// Run executes the command in the given working directory and returns its captured output.
+	// Empty wd means the current process directory.
+	// The command is interrupted when ctx is cancelled, the returned error wraps ctx.Err() then.
+	Run(ctx context.Context, wd string, name string, args ...string) (stdout string, stderr string, err error)
+}
@@ -0,0 +1,23 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package runner
+
+import "io"
+
+// New returns a CommandRunner which spawns real processes
+func New() CommandRunner {
+	return execRunner{}
+}
+
+// NewDryRun returns a CommandRunner which runs read-only git commands by inner and prints mutating ones to out
+func NewDryRun(inner CommandRunner, out io.Writer) *DryRun {
+	return &DryRun{inner: inner, out: out}
+}
+
+// NewFake returns a recording CommandRunner for unit tests
+func NewFake() *Fake {
+	return &Fake{}
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package runner
+
+import (
+	"io"
+	"sync"
+)
+
+// execRunner spawns real processes
+type execRunner struct{}
+
+// Call is a single command invocation recorded by Fake
+type Call struct {
+	Wd   string
+	Name string
+	Args []string
+}
+
+// Reply is a scripted result of a command matched by a pattern
+type Reply struct {
+	pattern string
+	stdout  string
+	stderr  string
+	err     error
+	once    bool
+	used    bool
+}
+
+// Fake is a CommandRunner that records all calls and replies with scripted results.
+// Commands without a matching reply succeed with empty output.
+type Fake struct {
+	mu      sync.Mutex
+	replies []*Reply
+	calls   []Call
+}
+
+// DryRun is a CommandRunner which runs read-only git commands and only prints and records mutating ones.
+// Commands other than git are run as is.
+type DryRun struct {
+	mu      sync.Mutex
+	inner   CommandRunner
+	out     io.Writer
+	planned []string
+}
@@ -2,13 +2,11 @@ package systrun
 
 const (
 	TestDataDir                 = ".testdata"
-	GithubURL                   = "https://github.com"
-	remoteGithubRepoURLTemplate = GithubURL + "/%s/%s.git"
+	remoteGithubRepoURLTemplate = "%s/%s/%s.git"
 	issueBody                   = "Automated test issue created by QS system test framework"
 	origin                      = "origin"
 	upstream                    = "upstream"
 	git                         = "git"
-	formatGithubTokenEnv        = "GITHUB_TOKEN=%s"
 	cloneRepoDirPerm            = 0755
 	commitFilePerm              = 0644
 	readmeMDFileName            = "README.md"
@@ -23,6 +21,8 @@ const (
 	EnvForkGithubToken       = "FORK_GH_TOKEN"
 	EnvUpstreamGithubAccount = "UPSTREAM_GH_ACCOUNT"
 	EnvUpstreamGithubToken   = "UPSTREAM_GH_TOKEN"
+	// EnvGithubHost is the host of the GitHub instance to run system tests against, github.com by default
+	EnvGithubHost = "GH_HOST"
 )
 
 type RemoteState int
@@ -41,7 +41,7 @@ func findBranchNameWithPrefix(repoPath, prefix string) (string, error) {
 
 func parseGithubIssueURL(issueURL string) (string, string, string, error) {
 	// Extract repo owner, repo name, and issue number from the URL
-	regExp := regexp.MustCompile(`https://github\.com/([^/]+)/([^/]+)/issues/(\d+)`)
+	regExp := regexp.MustCompile(`https://[^/]+/([^/]+)/([^/]+)/issues/(\d+)`)
 	matches := regExp.FindStringSubmatch(issueURL)
 	if matches == nil {
 		return "", "", "", fmt.Errorf("invalid GitHub issue URL format: %s", issueURL)
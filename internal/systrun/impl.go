@@ -25,6 +25,7 @@ import (
 	goUtilsExec "github.com/untillpro/goutils/exec"
 	"github.com/untillpro/qs/gitcmds"
 	"github.com/untillpro/qs/internal/commands"
+	"github.com/untillpro/qs/internal/hosting/github"
 	"github.com/untillpro/qs/internal/jira"
 	"github.com/untillpro/qs/utils"
 	"github.com/voedger/voedger/pkg/goutils/logger"
@@ -106,11 +107,11 @@ func (st *SystemTest) createTestEnvironment() error {
 	switch {
 	case st.cfg.ForkState != RemoteStateNull:
 		// Clone from fork if it exists
-		cloneURL = fmt.Sprintf(remoteGithubRepoURLTemplate, st.cfg.GHConfig.ForkAccount, st.repoName)
+		cloneURL = fmt.Sprintf(remoteGithubRepoURLTemplate, githubWebURL(), st.cfg.GHConfig.ForkAccount, st.repoName)
 		authToken = st.cfg.GHConfig.ForkToken
 	case st.cfg.UpstreamState != RemoteStateNull:
 		// Otherwise clone from upstream
-		cloneURL = fmt.Sprintf(remoteGithubRepoURLTemplate, st.cfg.GHConfig.UpstreamAccount, st.repoName)
+		cloneURL = fmt.Sprintf(remoteGithubRepoURLTemplate, githubWebURL(), st.cfg.GHConfig.UpstreamAccount, st.repoName)
 		authToken = st.cfg.GHConfig.UpstreamToken
 	default:
 		return fmt.Errorf("cannot create test environment: both upstream and fork repos are null")
@@ -159,16 +160,17 @@ func (st *SystemTest) createAnotherClone() error {
 	}
 
 	// get remotes from main clone
-	remoteOriginURL, err := gitcmds.GetRemoteUrlByName(st.cloneRepoPath, origin)
+	remoteOriginURL, err := gitcmds.GetRemoteUrlByName(gitcmds.NewRepoContext(st.cloneRepoPath), origin)
 	if err != nil {
 		return fmt.Errorf("failed to get oririn remote URL: %w", err)
 	}
 
 	// extract account, repo and token from remote url
-	forkAccount, repo, forkToken, err := gitcmds.ParseGitRemoteURL(remoteOriginURL)
+	originURL, err := gitcmds.ParseGitRemoteURL(remoteOriginURL)
 	if err != nil {
 		return err
 	}
+	forkAccount, repo, forkToken := originURL.Account, originURL.Repo, originURL.Token
 
 	// create temp dir for another clone
 	tempPath, err := os.MkdirTemp("", "qs-test-clone-*")
@@ -182,7 +184,7 @@ func (st *SystemTest) createAnotherClone() error {
 
 	// clone  repo to the temp dir
 	cloneCmd := exec.Command(git, "clone", remoteOriginURL)
-	cloneCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, forkToken))
+	cloneCmd.Env = append(os.Environ(), githubTokenEnv(forkToken))
 	cloneCmd.Dir = tempPath
 
 	if output, err := cloneCmd.CombinedOutput(); err != nil {
@@ -191,7 +193,7 @@ func (st *SystemTest) createAnotherClone() error {
 
 	// Step 3.1: Configure upstream remote in temp clone if needed
 	if !st.cfg.NoUpstream() {
-		remoteUpstreamURL, err := gitcmds.GetRemoteUrlByName(st.cloneRepoPath, upstream)
+		remoteUpstreamURL, err := gitcmds.GetRemoteUrlByName(gitcmds.NewRepoContext(st.cloneRepoPath), upstream)
 		if err != nil {
 			if !strings.Contains(err.Error(), "not found") {
 				remoteUpstreamURL = ""
@@ -201,10 +203,11 @@ func (st *SystemTest) createAnotherClone() error {
 		}
 
 		// extract account, repo and token from remote url
-		upstreamAccount, repo, upstreamToken, err := gitcmds.ParseGitRemoteURL(remoteUpstreamURL)
+		upstreamURL, err := gitcmds.ParseGitRemoteURL(remoteUpstreamURL)
 		if err != nil {
 			return err
 		}
+		upstreamAccount, repo, upstreamToken := upstreamURL.Account, upstreamURL.Repo, upstreamURL.Token
 
 		if err := CreateRemote(
 			st.anotherCloneRepoPath,
@@ -261,7 +264,7 @@ func (st *SystemTest) configureCollaboration() error {
 }
 
 func inviteCollaborator(owner, repo, username, token string) error {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/collaborators/%s", owner, repo, username)
+	url := fmt.Sprintf("%s/repos/%s/%s/collaborators/%s", github.APIURL(githubWebURL()), owner, repo, username)
 
 	// Request body
 	body := map[string]string{
@@ -278,7 +281,7 @@ func inviteCollaborator(owner, repo, username, token string) error {
 	req.Header.Set("Content-Type", "application/json")
 
 	var resp *netHttp.Response
-	err = utils.Retry(func() error {
+	err = utils.Retry(context.Background(), func() error {
 		resp, err = netHttp.DefaultClient.Do(req)
 
 		return err
@@ -297,7 +300,7 @@ func inviteCollaborator(owner, repo, username, token string) error {
 }
 
 func acceptPendingInvitations(token string) error {
-	url := "https://api.github.com/user/repository_invitations"
+	url := github.APIURL(githubWebURL()) + "/user/repository_invitations"
 
 	req, _ := netHttp.NewRequest("GET", url, nil)
 	req.Header.Set("Authorization", "token "+token)
@@ -318,13 +321,13 @@ func acceptPendingInvitations(token string) error {
 	}
 
 	for _, invite := range invitations {
-		acceptURL := fmt.Sprintf("https://api.github.com/user/repository_invitations/%d", invite.ID)
+		acceptURL := fmt.Sprintf("%s/user/repository_invitations/%d", github.APIURL(githubWebURL()), invite.ID)
 		acceptReq, _ := netHttp.NewRequest("PATCH", acceptURL, nil)
 		acceptReq.Header.Set("Authorization", "token "+token)
 		acceptReq.Header.Set("Accept", "application/vnd.github+json")
 
 		var acceptResp *netHttp.Response
-		err = utils.Retry(func() error {
+		err = utils.Retry(context.Background(), func() error {
 			var err error
 			acceptResp, err = netHttp.DefaultClient.Do(acceptReq)
 
@@ -363,7 +366,8 @@ func (st *SystemTest) processClipboardContent() error {
 
 		st.ctx = context.WithValue(st.ctx, utils.CtxKeyCustomBranchName, clipboardContent+"-dev")
 	case ClipboardContentUnavailableGithubIssue:
-		clipboardContent = fmt.Sprintf("https://github.com/%s/%s/issues/abc",
+		clipboardContent = fmt.Sprintf("%s/%s/%s/issues/abc",
+			githubWebURL(),
 			st.cfg.GHConfig.UpstreamAccount,
 			uuid.New().String(),
 		)
@@ -408,15 +412,15 @@ func (st *SystemTest) createGitHubIssue() (string, error) {
 
 	var output []byte
 	// Run gh issue create command
-	err := utils.Retry(func() error {
+	err := utils.Retry(st.ctx, func() error {
 		var err error
 		cmd := exec.Command("gh", "issue", "create",
 			"--title", issueTitle,
 			"--body", issueBody,
-			"--repo", fmt.Sprintf("https://github.com/%s/%s", st.cfg.GHConfig.UpstreamAccount, st.repoName))
+			"--repo", fmt.Sprintf("%s/%s/%s", githubWebURL(), st.cfg.GHConfig.UpstreamAccount, st.repoName))
 
 		cmd.Env = append(os.Environ(),
-			fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.UpstreamToken))
+			githubTokenEnv(st.cfg.GHConfig.UpstreamToken))
 
 		output, err = cmd.Output()
 
@@ -448,7 +452,7 @@ func checkoutOnBranch(wd, branchName string) error {
 // createUpstreamRepo creates the upstream repository
 func (st *SystemTest) createUpstreamRepo(repoName, repoURL string) error {
 	// GitHub Authentication and repo creation with retry
-	err := utils.Retry(func() error {
+	err := utils.Retry(st.ctx, func() error {
 		//nolint:gosec
 		cmd := exec.Command(
 			"gh",
@@ -458,7 +462,7 @@ func (st *SystemTest) createUpstreamRepo(repoName, repoURL string) error {
 			"--public",
 		)
 
-		cmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.UpstreamToken))
+		cmd.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.UpstreamToken))
 
 		if output, createErr := cmd.CombinedOutput(); createErr != nil {
 			return fmt.Errorf("failed to create upstream repo: %w\nOutput: %s", createErr, output)
@@ -470,8 +474,8 @@ func (st *SystemTest) createUpstreamRepo(repoName, repoURL string) error {
 	}
 
 	// Verify repository was created and is accessible with retry
-	err = utils.Retry(func() error {
-		return utils.VerifyGitHubRepoExists(st.cfg.GHConfig.UpstreamAccount, repoName, st.cfg.GHConfig.UpstreamToken)
+	err = utils.Retry(st.ctx, func() error {
+		return verifyGitHubRepoExists(st.cfg.GHConfig.UpstreamAccount, repoName, st.cfg.GHConfig.UpstreamToken)
 	}) // Retry up to 5 times for verification (GitHub eventual consistency)
 	if err != nil {
 		return fmt.Errorf("upstream repository verification failed: %w", err)
@@ -537,7 +541,7 @@ func (st *SystemTest) createUpstreamRepo(repoName, repoURL string) error {
 		}
 
 		// Push changes with retry
-		err = utils.Retry(func() error {
+		err = utils.Retry(st.ctx, func() error {
 			return repo.Push(&gitPkg.PushOptions{
 				RemoteName: origin,
 				Auth: &http.BasicAuth{
@@ -558,7 +562,7 @@ func (st *SystemTest) createUpstreamRepo(repoName, repoURL string) error {
 func (st *SystemTest) createForkRepo(repoName, repoURL string) error {
 	if st.cfg.UpstreamState != RemoteStateNull {
 		// Fork the upstream repo with retry
-		err := utils.Retry(func() error {
+		err := utils.Retry(st.ctx, func() error {
 			//nolint:gosec
 			cmd := exec.Command(
 				"gh",
@@ -568,7 +572,7 @@ func (st *SystemTest) createForkRepo(repoName, repoURL string) error {
 				"--clone=false",
 			)
 
-			cmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.ForkToken))
+			cmd.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.ForkToken))
 
 			if output, forkErr := cmd.CombinedOutput(); forkErr != nil {
 				return fmt.Errorf("failed to fork upstream repo: %w\nOutput: %s", forkErr, output)
@@ -580,15 +584,15 @@ func (st *SystemTest) createForkRepo(repoName, repoURL string) error {
 		}
 
 		// Verify fork was created and is accessible with retry
-		err = utils.Retry(func() error {
-			return utils.VerifyGitHubRepoExists(st.cfg.GHConfig.ForkAccount, repoName, st.cfg.GHConfig.ForkToken)
+		err = utils.Retry(st.ctx, func() error {
+			return verifyGitHubRepoExists(st.cfg.GHConfig.ForkAccount, repoName, st.cfg.GHConfig.ForkToken)
 		})
 		if err != nil {
 			return fmt.Errorf("fork repository verification failed: %w", err)
 		}
 	} else {
 		// Create an independent repo with retry
-		err := utils.Retry(func() error {
+		err := utils.Retry(st.ctx, func() error {
 			//nolint:gosec
 			cmd := exec.Command(
 				"gh",
@@ -598,7 +602,7 @@ func (st *SystemTest) createForkRepo(repoName, repoURL string) error {
 				"--public",
 			)
 
-			cmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.ForkToken))
+			cmd.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.ForkToken))
 
 			if output, createErr := cmd.CombinedOutput(); createErr != nil {
 				return fmt.Errorf("failed to create fork repo: %w\nOutput: %s", createErr, output)
@@ -610,8 +614,8 @@ func (st *SystemTest) createForkRepo(repoName, repoURL string) error {
 		}
 
 		// Verify repository was created and is accessible with retry
-		err = utils.Retry(func() error {
-			return utils.VerifyGitHubRepoExists(st.cfg.GHConfig.ForkAccount, repoName, st.cfg.GHConfig.ForkToken)
+		err = utils.Retry(st.ctx, func() error {
+			return verifyGitHubRepoExists(st.cfg.GHConfig.ForkAccount, repoName, st.cfg.GHConfig.ForkToken)
 		})
 		if err != nil {
 			return fmt.Errorf("fork repository verification failed: %w", err)
@@ -677,7 +681,7 @@ func (st *SystemTest) createForkRepo(repoName, repoURL string) error {
 			}
 
 			// Push changes with retry
-			err = utils.Retry(func() error {
+			err = utils.Retry(st.ctx, func() error {
 				return repo.Push(&gitPkg.PushOptions{
 					RemoteName: origin,
 					Auth: &http.BasicAuth{
@@ -711,7 +715,7 @@ func (st *SystemTest) cloneRepo(repoURL, clonePath, token string) error {
 		},
 	}
 
-	err := utils.Retry(func() error {
+	err := utils.Retry(st.ctx, func() error {
 		_, err := gitPkg.PlainClone(clonePath, false, cloneOpts)
 
 		return err
@@ -787,25 +791,9 @@ func (st *SystemTest) runCommand(cmdCfg *CommandConfig) (stdout string, stderr s
 		return
 	}
 
-	// Create pipe for stdin if needed
-	var stdinReader, stdinWriter *os.File
-	if cmdCfg.Stdin != "" {
-		stdinReader, stdinWriter, err = os.Pipe()
-		if err != nil {
-			return "", "", fmt.Errorf("failed to create stdin pipe: %w", err)
-		}
-	}
-
-	origStdin := os.Stdin
 	origStdout := os.Stdout
 	origStderr := os.Stderr
 
-	// Set up new stdin if needed
-	if stdinReader != nil {
-		os.Stdin = stdinReader
-		defer func() { os.Stdin = origStdin }()
-	}
-
 	os.Stdout = stdoutWriter
 	defer func() { os.Stdout = origStdout }()
 
@@ -829,18 +817,13 @@ func (st *SystemTest) runCommand(cmdCfg *CommandConfig) (stdout string, stderr s
 		stderr = b.String()
 	}()
 
-	// Handle stdin if needed
-	if cmdCfg.Stdin != "" {
-		go func() {
-			_, _ = stdinWriter.WriteString(cmdCfg.Stdin)
-			_ = stdinWriter.Close()
-		}()
-	}
-
 	// Prepare the qs command arguments
 	qsArgs := make([]string, 0, len(cmdCfg.Args)+2) // +2 for "qs" and "-v"
 	qsArgs = append(qsArgs, "qs")
 	qsArgs = append(qsArgs, "-v")
+	if cmdCfg.AssumeYes {
+		qsArgs = append(qsArgs, "--yes")
+	}
 	if cmdCfg.Command != "" {
 		qsArgs = append(qsArgs, cmdCfg.Command)
 	}
@@ -1013,9 +996,9 @@ func (st *SystemTest) setSyncState(
 
 	// No need to pass --no-fork flag anymore - auto-detection handles it
 	stdout, stderr, err := st.runCommand(&CommandConfig{
-		Command: "dev",
-		Args:    []string{},
-		Stdin:   "y",
+		Command:   "dev",
+		Args:      []string{},
+		AssumeYes: true,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to run qs dev command: %w, stderr: %s", err, stderr)
@@ -1033,15 +1016,15 @@ func (st *SystemTest) setSyncState(
 	}
 
 	if needSync {
-		devBranchName, err := gitcmds.GetCurrentBranchName(st.cloneRepoPath)
+		devBranchName, err := gitcmds.GetCurrentBranchName(gitcmds.NewRepoContext(st.cloneRepoPath))
 		if err != nil {
 			return err
 		}
 		// Push the dev branch to the remote with retry logic
-		err = utils.Retry(func() error {
+		err = utils.Retry(st.ctx, func() error {
 			//nolint:gosec
 			pushCmd := exec.Command(git, changeDirFlag, st.cloneRepoPath, "push", "-u", origin, devBranchName)
-			pushCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.ForkToken))
+			pushCmd.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.ForkToken))
 			pushOutput, pushErr := pushCmd.CombinedOutput()
 			if pushErr != nil {
 				return fmt.Errorf("failed to push dev branch: %w, output: %s", pushErr, pushOutput)
@@ -1067,7 +1050,7 @@ func (st *SystemTest) setSyncState(
 	}
 
 	if needChangeFork {
-		remoteURL, err := gitcmds.GetRemoteUrlByName(st.cloneRepoPath, origin)
+		remoteURL, err := gitcmds.GetRemoteUrlByName(gitcmds.NewRepoContext(st.cloneRepoPath), origin)
 		if err != nil {
 			return fmt.Errorf("failed to get remote URL: %w", err)
 		}
@@ -1092,7 +1075,7 @@ func (st *SystemTest) setSyncState(
 // This simulates the case where the fork's main branch has diverged from upstream/main
 // and cannot be rebased cleanly (AIR-1959)
 func (st *SystemTest) setMainBranchConflict() error {
-	mainBranch, err := gitcmds.GetMainBranch(st.cloneRepoPath)
+	mainBranch, err := gitcmds.GetMainBranch(gitcmds.NewRepoContext(st.cloneRepoPath))
 	if err != nil {
 		return fmt.Errorf("failed to get main branch: %w", err)
 	}
@@ -1113,7 +1096,7 @@ func (st *SystemTest) setMainBranchConflict() error {
 		true,
 	)
 	cloneCmd := exec.Command(git, "clone", upstreamURL, upstreamRepoPath)
-	cloneCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.UpstreamToken))
+	cloneCmd.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.UpstreamToken))
 	if output, err := cloneCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to clone upstream repo: %w, output: %s", err, output)
 	}
@@ -1135,7 +1118,7 @@ func (st *SystemTest) setMainBranchConflict() error {
 	}
 
 	pushCmd := exec.Command(git, "-C", upstreamRepoPath, "push", "origin", mainBranch)
-	pushCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.UpstreamToken))
+	pushCmd.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.UpstreamToken))
 	if output, err := pushCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to push to upstream: %w, output: %s", err, output)
 	}
@@ -1164,14 +1147,14 @@ func (st *SystemTest) setMainBranchConflict() error {
 
 	// Push to fork/main
 	pushCmdFork := exec.Command(git, "-C", st.cloneRepoPath, "push", "origin", mainBranch)
-	pushCmdFork.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.ForkToken))
+	pushCmdFork.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.ForkToken))
 	if output, err := pushCmdFork.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to push to fork: %w, output: %s", err, output)
 	}
 
 	// Step 3: Fetch upstream to ensure the conflict will be detected
 	fetchCmd := exec.Command(git, "-C", st.cloneRepoPath, "fetch", "upstream")
-	fetchCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.ForkToken))
+	fetchCmd.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.ForkToken))
 	if output, err := fetchCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to fetch upstream: %w, output: %s", err, output)
 	}
@@ -1183,7 +1166,7 @@ func (st *SystemTest) setMainBranchConflict() error {
 // but without conflicts (different files changed), so fast-forward merge will fail but rebase would work.
 // This tests the fast-forward only merge failure in Download() function (AIR-2783)
 func (st *SystemTest) setMainBranchDiverged() error {
-	mainBranch, err := gitcmds.GetMainBranch(st.cloneRepoPath)
+	mainBranch, err := gitcmds.GetMainBranch(gitcmds.NewRepoContext(st.cloneRepoPath))
 	if err != nil {
 		return fmt.Errorf("failed to get main branch: %w", err)
 	}
@@ -1203,7 +1186,7 @@ func (st *SystemTest) setMainBranchDiverged() error {
 		true,
 	)
 	cloneCmd := exec.Command(git, "clone", upstreamURL, upstreamRepoPath)
-	cloneCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.UpstreamToken))
+	cloneCmd.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.UpstreamToken))
 	if output, err := cloneCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to clone upstream repo: %w, output: %s", err, output)
 	}
@@ -1225,7 +1208,7 @@ func (st *SystemTest) setMainBranchDiverged() error {
 	}
 
 	pushCmd := exec.Command(git, "-C", upstreamRepoPath, "push", "origin", mainBranch)
-	pushCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.UpstreamToken))
+	pushCmd.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.UpstreamToken))
 	if output, err := pushCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to push to upstream: %w, output: %s", err, output)
 	}
@@ -1254,14 +1237,14 @@ func (st *SystemTest) setMainBranchDiverged() error {
 
 	// Push to fork/main
 	pushCmdFork := exec.Command(git, "-C", st.cloneRepoPath, "push", "origin", mainBranch)
-	pushCmdFork.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.ForkToken))
+	pushCmdFork.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.ForkToken))
 	if output, err := pushCmdFork.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to push to fork: %w, output: %s", err, output)
 	}
 
 	// Step 3: Fetch upstream to ensure the divergence will be detected
 	fetchCmd := exec.Command(git, "-C", st.cloneRepoPath, "fetch", "upstream")
-	fetchCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, st.cfg.GHConfig.ForkToken))
+	fetchCmd.Env = append(os.Environ(), githubTokenEnv(st.cfg.GHConfig.ForkToken))
 	if output, err := fetchCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to fetch upstream: %w, output: %s", err, output)
 	}
@@ -1333,10 +1316,11 @@ func (st *SystemTest) pushFromAnotherClone(originRemoteURL, branchName, headOfFi
 	// 4. Push the branch to the remote
 
 	// Step 1: Get account and repo name from originRemoteURL
-	account, repo, token, err := gitcmds.ParseGitRemoteURL(originRemoteURL)
+	originURL, err := gitcmds.ParseGitRemoteURL(originRemoteURL)
 	if err != nil {
 		return err
 	}
+	account, repo, token := originURL.Account, originURL.Repo, originURL.Token
 
 	// Step 2: Create temp path for the clone
 	tempPath, err := os.MkdirTemp("", "qs-test-clone-*")
@@ -1349,7 +1333,7 @@ func (st *SystemTest) pushFromAnotherClone(originRemoteURL, branchName, headOfFi
 	// Step 3: Clone the repository in the temp path
 	tempClonePath := filepath.Join(tempPath, repo)
 	cloneCmd := exec.Command(git, "clone", originRemoteURL)
-	cloneCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, token))
+	cloneCmd.Env = append(os.Environ(), githubTokenEnv(token))
 	cloneCmd.Dir = tempPath
 
 	if output, err := cloneCmd.CombinedOutput(); err != nil {
@@ -1371,7 +1355,7 @@ func (st *SystemTest) pushFromAnotherClone(originRemoteURL, branchName, headOfFi
 	// Step 4.1: Fetch the dev branch from origin
 	fetchCmd := exec.Command(git, "fetch", origin, branchName)
 	fetchCmd.Dir = tempClonePath
-	fetchCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, token))
+	fetchCmd.Env = append(os.Environ(), githubTokenEnv(token))
 	if output, err := fetchCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to fetch %s branch from origin: %w, output: %s", branchName, err, output)
 	}
@@ -1388,7 +1372,7 @@ func (st *SystemTest) pushFromAnotherClone(originRemoteURL, branchName, headOfFi
 
 	// Step 7: Push the branch to the remote
 	pushCmd := exec.Command(git, changeDirFlag, tempClonePath, "push", origin, branchName)
-	pushCmd.Env = append(os.Environ(), fmt.Sprintf(formatGithubTokenEnv, token))
+	pushCmd.Env = append(os.Environ(), githubTokenEnv(token))
 	if output, err := pushCmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to push branch %s: %w, output: %s", branchName, err, output)
 	}
@@ -1482,7 +1466,7 @@ func (st *SystemTest) removeRepo(repoName, account, token string) error {
 		"--yes")
 
 	cmd.Env = append(os.Environ(),
-		fmt.Sprintf(formatGithubTokenEnv, token))
+		githubTokenEnv(token))
 
 	if output, err := cmd.CombinedOutput(); err != nil {
 		return fmt.Errorf("failed to delete repository: %w\nOutput: %s", err, output)
@@ -1497,17 +1481,17 @@ func (st *SystemTest) buildBranchState(wd, token string) error {
 		return nil
 	}
 
-	mainBranchName, err := gitcmds.GetMainBranch(wd)
+	mainBranchName, err := gitcmds.GetMainBranch(gitcmds.NewRepoContext(wd))
 	if err != nil {
 		return fmt.Errorf("failed to get main branch name: %w", err)
 	}
 
-	parentRepo, err := gitcmds.GetParentRepoName(wd)
+	parentRepo, err := gitcmds.GetParentRepoName(gitcmds.NewRepoContext(wd))
 	if err != nil {
 		return fmt.Errorf("failed to get parent repository name: %w", err)
 	}
 
-	_, forkAccount, err := gitcmds.GetRepoAndOrgName(wd)
+	_, forkAccount, err := gitcmds.GetRepoAndOrgName(gitcmds.NewRepoContext(wd))
 	if err != nil {
 		return fmt.Errorf("failed to get fork account name: %w", err)
 	}
@@ -1577,7 +1561,7 @@ func setBranchState(
 	}
 
 	// Check out on the main branch
-	if err := gitcmds.CheckoutOnBranch(wd, mainBranchName); err != nil {
+	if err := gitcmds.CheckoutOnBranch(gitcmds.NewRepoContext(wd), mainBranchName); err != nil {
 		return err
 	}
 
@@ -1596,7 +1580,7 @@ func setBranchState(
 		return err
 	}
 	// Push the branch to the remote
-	err = utils.Retry(func() error {
+	err = utils.Retry(context.Background(), func() error {
 		_, stderr, err = new(goUtilsExec.PipedExec).
 			Command(git, "push", "-u", origin, branchName).
 			WorkingDir(wd).
@@ -1632,7 +1616,7 @@ func setBranchState(
 		cmd.Dir = wd
 
 		// create a pull request
-		err = utils.Retry(func() error {
+		err = utils.Retry(context.Background(), func() error {
 			return cmd.Run()
 		})
 		if err != nil {
@@ -1644,7 +1628,7 @@ func setBranchState(
 		if prMerged {
 			// merge the pull request
 			// here is a code to merge the pull request
-			err = utils.Retry(func() error {
+			err = utils.Retry(context.Background(), func() error {
 				_, stderr, err = new(goUtilsExec.PipedExec).
 					Command("gh", "pr", "merge", "--merge", forkAccount+":"+defaultPrBranchName).
 					WorkingDir(wd).
@@ -1662,7 +1646,7 @@ func setBranchState(
 
 	if !branchExists {
 		// checking out on the main branch before deleting the branch
-		if err := gitcmds.CheckoutOnBranch(wd, mainBranchName); err != nil {
+		if err := gitcmds.CheckoutOnBranch(gitcmds.NewRepoContext(wd), mainBranchName); err != nil {
 			return err
 		}
 		// Delete the local branch
@@ -1678,7 +1662,7 @@ func setBranchState(
 	}
 
 	if !hasRtBranch {
-		err := utils.Retry(func() error {
+		err := utils.Retry(context.Background(), func() error {
 			_, stderr, err = new(goUtilsExec.PipedExec).
 				Command(git, "push", origin, "--delete", branchName).
 				WorkingDir(wd).
@@ -59,8 +59,8 @@ type CommandConfig struct {
 	Command string
 	// Args are arguments to be passed to the command
 	Args []string
-	// Stdin is a string that will be written to stdin of the command
-	Stdin string
+	// AssumeYes answers yes to all confirmations of the command, qs does not read answers from stdin of tests
+	AssumeYes bool
 }
 
 // GithubConfig holds GitHub account and token information
@@ -82,7 +82,7 @@ func (cfg *TestConfig) NoUpstream() bool {
 
 // ExpectationCustomBranchIsCurrentBranch represents checker for ExpectationCurrentBranch
 func ExpectationCustomBranchIsCurrentBranch(ctx context.Context) error {
-	currentBranch, err := gitcmds.GetCurrentBranchName(ctx.Value(utils.CtxKeyCloneRepoPath).(string))
+	currentBranch, err := gitcmds.GetCurrentBranchName(gitcmds.NewRepoContext(ctx.Value(utils.CtxKeyCloneRepoPath).(string)))
 	if err != nil {
 		return err
 	}
@@ -168,10 +168,10 @@ func ExpectationBranchLinkedToIssue(ctx context.Context) error {
 		return err
 	}
 	// Build full repo URL
-	repoURL := fmt.Sprintf("https://github.com/%s/%s", repoOwner, repoName)
+	repoURL := fmt.Sprintf("%s/%s/%s", githubWebURL(), repoOwner, repoName)
 	// Run gh issue develop --list command with retry logic
 	var output []byte
-	err = utils.Retry(func() error {
+	err = utils.Retry(ctx, func() error {
 		cmd := exec.Command("gh", "issue", "develop", "--list", "--repo", repoURL, issueNum)
 		var cmdErr error
 		output, cmdErr = cmd.Output()
@@ -202,15 +202,12 @@ func ExpectationLargeFileHooksInstalled(ctx context.Context) error {
 		return fmt.Errorf("pre-commit hook is not installed at %s", hookPath)
 	}
 
-	substring := gitcmds.LargeFileHookFilename
-	stdout, _, err := new(goUtilsExec.PipedExec).
-		Command("grep", "-l", substring, hookPath).
-		RunToStrings()
+	content, err := os.ReadFile(hookPath)
 	if err != nil {
 		return fmt.Errorf("failed to check if large file hook is installed: %w", err)
 	}
 
-	if len(stdout) == 0 {
+	if !strings.Contains(string(content), gitcmds.LargeFileHookFilename) {
 		return fmt.Errorf("large file hook is not installed")
 	}
 
@@ -365,7 +362,7 @@ func ExpectationPRCreated(ctx context.Context) error {
 	}
 
 	// Get notes from the branch
-	notes, _, err := gitcmds.GetNotes(cloneRepoPath, expectedPRBranch)
+	notes, _, err := gitcmds.GetNotes(gitcmds.NewRepoContext(cloneRepoPath), expectedPRBranch)
 	if err != nil {
 		return err
 	}
@@ -440,7 +437,7 @@ func ExpectationPRCreated(ctx context.Context) error {
 		return fmt.Errorf("dev branch %s still exists on origin after PR creation", devBranchName)
 	}
 
-	parentRepo, err := gitcmds.GetParentRepoName(cloneRepoPath)
+	parentRepo, err := gitcmds.GetParentRepoName(gitcmds.NewRepoContext(cloneRepoPath))
 	if err != nil {
 		return fmt.Errorf("failed to get parent repo name: %w", err)
 	}
@@ -471,16 +468,16 @@ func ExpectationPRCreated(ctx context.Context) error {
 	}
 
 	remoteURL := remote.Config().URLs[0]
-	var owner, repoName string
-
-	owner, repoName, _, err = gitcmds.ParseGitRemoteURL(remoteURL)
+	upstreamURL, err := gitcmds.ParseGitRemoteURL(remoteURL)
 	if err != nil {
 		return fmt.Errorf("failed to parse upstream URL: %w", err)
 	}
-	// Use gh CLI to check if PR exists with retry logic
+	owner, repoName := upstreamURL.Account, upstreamURL.Repo
+	// Check if PR exists via the hosting API with retry logic
 
-	prInfo, _, _, err := gitcmds.DoesPrExist(
-		cloneRepoPath,
+	rc := gitcmds.NewRepoContext(cloneRepoPath)
+	prInfo, err := gitcmds.DoesPrExist(
+		rc,
 		fmt.Sprintf("%s/%s", owner, repoName),
 		expectedPRBranch,
 		gitcmds.PRStateOpen,
@@ -493,7 +490,7 @@ func ExpectationPRCreated(ctx context.Context) error {
 	}
 
 	// extract expected PR title from GitHub issue or JIRA ticket
-	expectedPRTitle, err := gitcmds.GetIssueDescription(notes)
+	expectedPRTitle, err := gitcmds.GetIssueDescription(rc, notes)
 	if err != nil {
 		return err
 	}
@@ -521,7 +518,7 @@ func ExpectationRemoteBranchWithCommitMessage(ctx context.Context) error {
 
 	// Check if branch exists on the remote with retry logic
 	var stdout, stderr string
-	err := utils.Retry(func() error {
+	err := utils.Retry(ctx, func() error {
 		var lsErr error
 		stdout, stderr, lsErr = new(goUtilsExec.PipedExec).
 			Command(git, "ls-remote", "--heads", origin, remoteBranchName).
@@ -573,15 +570,16 @@ func ExpectationNotesDownloaded(ctx context.Context) error {
 		return fmt.Errorf("remote branch name not found in context")
 	}
 
-	remoteURL, err := gitcmds.GetRemoteUrlByName(cloneRepoPath, origin)
+	remoteURL, err := gitcmds.GetRemoteUrlByName(gitcmds.NewRepoContext(cloneRepoPath), origin)
 	if err != nil {
 		return fmt.Errorf("failed to get remote URL: %w", err)
 	}
 
-	_, repo, token, err := gitcmds.ParseGitRemoteURL(remoteURL)
+	parsedURL, err := gitcmds.ParseGitRemoteURL(remoteURL)
 	if err != nil {
 		return err
 	}
+	repo, token := parsedURL.Repo, parsedURL.Token
 
 	// Step 2: Create temp path for the clone
 	tempPath, err := os.MkdirTemp("", "qs-test-clone-*")
@@ -609,12 +607,12 @@ func ExpectationNotesDownloaded(ctx context.Context) error {
 	}
 
 	// Step 5: Run `qs d`
-	if err := gitcmds.Download(tempClonePath); err != nil {
+	if err := gitcmds.Download(gitcmds.NewRepoContext(tempClonePath)); err != nil {
 		return err
 	}
 
 	// Step 6: Check if notes are downloaded
-	notes, _, err := gitcmds.GetNotes(tempClonePath, remoteBranchName)
+	notes, _, err := gitcmds.GetNotes(gitcmds.NewRepoContext(tempClonePath), remoteBranchName)
 	if err != nil {
 		return err
 	}
@@ -768,7 +766,7 @@ func ExpectationFilesWithSpacesHandled(ctx context.Context) error {
 
 	// Now run qs command (empty command = status) to ensure it doesn't fail
 	// This will call gitcmds.Status which internally calls getListOfChangedFiles
-	if err := gitcmds.Status(cloneRepoPath); err != nil {
+	if err := gitcmds.Status(gitcmds.NewRepoContext(cloneRepoPath)); err != nil {
 		return fmt.Errorf("qs status failed with file with spaces: %w", err)
 	}
 
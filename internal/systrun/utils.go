@@ -6,12 +6,17 @@
 package systrun
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
 
 	goGitPkg "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/untillpro/qs/gitcmds"
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/hosting/github"
+	"github.com/untillpro/qs/internal/runner"
 )
 
 // createRemote creates a remote in the cloned repository
@@ -41,5 +46,43 @@ func CreateRemote(wd, remote, account, token, repoName string, isUpstream bool)
 
 // buildRemoteURL constructs the remote URL for cloning
 func BuildRemoteURL(account, token, repoName string, isUpstream bool) string {
-	return "https://" + account + ":" + token + "@github.com/" + account + slash + repoName + ".git"
+	return "https://" + account + ":" + token + "@" + githubHost() + slash + account + slash + repoName + ".git"
+}
+
+// verifyGitHubRepoExists checks if a GitHub repository exists and is accessible.
+// Uses the default token resolution if token is empty.
+func verifyGitHubRepoExists(owner, repo, token string) error {
+	tokenFunc := github.DefaultToken(runner.New(), githubHost())
+	if token != "" {
+		tokenFunc = hosting.StaticToken(token)
+	}
+	if err := github.NewForWebURL(githubWebURL(), tokenFunc).VerifyRepoExists(context.Background(), owner+"/"+repo); err != nil {
+		return fmt.Errorf("repository %s/%s not accessible: %w", owner, repo, err)
+	}
+
+	return nil
+}
+
+// githubHost returns the host of the GitHub instance system tests run against
+func githubHost() string {
+	if host := os.Getenv(EnvGithubHost); len(host) > 0 {
+		return host
+	}
+
+	return github.DefaultHost
+}
+
+// githubWebURL returns the root URL of the GitHub instance system tests run against
+func githubWebURL() string {
+	return "https://" + githubHost()
+}
+
+// githubTokenEnv returns the environment variable assignment passing the token to gh
+// for the GitHub instance system tests run against
+func githubTokenEnv(token string) string {
+	if githubHost() != github.DefaultHost {
+		return "GH_ENTERPRISE_TOKEN=" + token
+	}
+
+	return "GITHUB_TOKEN=" + token
 }
@@ -0,0 +1,13 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package tracker
+
+const (
+	githubIssuesPathPart = "/issues/"
+	gitlabIssuesPathPart = "/-/issues/"
+	// minRepoURLParts is the number of parts of "https://host/owner/repo" split by slash
+	minRepoURLParts = 5
+)
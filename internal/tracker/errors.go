@@ -0,0 +1,16 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package tracker
+
+import "errors"
+
+var (
+	ErrNotSupported  = errors.New("not supported by the issue tracker")
+	ErrNotFound      = errors.New("not found in the issue tracker")
+	ErrTokenNotFound = errors.New("issue tracker API token not found")
+	// ErrUntrustedSite is returned instead of sending credentials to a site which is not configured or not served over https
+	ErrUntrustedSite = errors.New("issue tracker site is not trusted")
+)
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+// Find returns the first tracker matching the issue URL or nil if there is no such tracker
+func (r *Registry) Find(issueURL string) IssueTracker {
+	for _, t := range r.trackers {
+		if t.Match(issueURL) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// lastSegment returns the last slash-separated segment of the URL
+func lastSegment(issueURL string) string {
+	segments := strings.Split(strings.TrimSuffix(issueURL, "/"), "/")
+
+	return segments[len(segments)-1]
+}
+
+func (githubTracker) Name() string {
+	return "GitHub"
+}
+
+func (githubTracker) Match(issueURL string) bool {
+	return strings.Contains(issueURL, githubIssuesPathPart)
+}
+
+func (githubTracker) ExtractID(issueURL string) string {
+	return lastSegment(issueURL)
+}
+
+func (t githubTracker) FetchTitle(ctx context.Context, env Env, issueURL string) (string, error) {
+	repo, err := githubIssueRepo(issueURL)
+	if err != nil {
+		return "", err
+	}
+
+	title, err := env.Hosting.GetIssueTitle(ctx, repo, t.ExtractID(issueURL))
+	if err != nil {
+		return "", fmt.Errorf("failed to get issue title: %w", err)
+	}
+	logger.Verbose(title)
+
+	return title, nil
+}
+
+func (t githubTracker) Link(ctx context.Context, env Env, issueURL string, branch Branch) error {
+	repo, err := githubIssueRepo(issueURL)
+	if err != nil {
+		return err
+	}
+
+	return env.Hosting.LinkBranchToIssue(ctx, repo, t.ExtractID(issueURL), branch.RepoFullName, branch.Name)
+}
+
+func (githubTracker) Transition(context.Context, Env, string, string) error {
+	return ErrNotSupported
+}
+
+// githubIssueRepo returns the full name of the repo of the GitHub issue URL
+func githubIssueRepo(issueURL string) (string, error) {
+	repoURL := strings.Split(issueURL, githubIssuesPathPart)[0]
+	urlParts := strings.Split(repoURL, "/")
+	if len(urlParts) < minRepoURLParts {
+		return "", fmt.Errorf("invalid GitHub URL format: %s", repoURL)
+	}
+
+	return urlParts[3] + "/" + urlParts[4], nil //nolint:revive
+}
+
+func (gitlabTracker) Name() string {
+	return "GitLab"
+}
+
+func (gitlabTracker) Match(issueURL string) bool {
+	return strings.Contains(issueURL, gitlabIssuesPathPart)
+}
+
+func (gitlabTracker) ExtractID(issueURL string) string {
+	return lastSegment(issueURL)
+}
+
+// FetchTitle fetches the issue title from GitLab.
+// Project full name may contain subgroups, e.g. https://gitlab.com/group/sub/project/-/issues/7
+func (t gitlabTracker) FetchTitle(ctx context.Context, env Env, issueURL string) (string, error) {
+	projectURL := strings.Split(issueURL, gitlabIssuesPathPart)[0]
+	urlParts := strings.SplitN(projectURL, "/", 4) //nolint:revive
+	if len(urlParts) < 4 || !strings.Contains(urlParts[3], "/") {
+		return "", fmt.Errorf("invalid GitLab URL format: %s", projectURL)
+	}
+
+	title, err := env.Hosting.GetIssueTitle(ctx, urlParts[3], t.ExtractID(issueURL))
+	if err != nil {
+		return "", fmt.Errorf("failed to get issue title: %w", err)
+	}
+	logger.Verbose(title)
+
+	return title, nil
+}
+
+// Link is not supported: GitLab has no API to link branches, the branch name starting with the issue ID is enough
+func (gitlabTracker) Link(context.Context, Env, string, Branch) error {
+	return ErrNotSupported
+}
+
+func (gitlabTracker) Transition(context.Context, Env, string, string) error {
+	return ErrNotSupported
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package tracker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// linkRecorder is a hosting provider stub recording linked branches
+type linkRecorder struct {
+	hosting.HostingProvider
+	linked []string
+}
+
+func (l *linkRecorder) LinkBranchToIssue(_ context.Context, issueRepoFullName, issueNumber, branchRepoFullName, branchName string) error {
+	l.linked = append(l.linked, issueRepoFullName+"#"+issueNumber+" "+branchRepoFullName+":"+branchName)
+
+	return nil
+}
+
+func TestRegistry_Find(t *testing.T) {
+	require := require.New(t)
+	registry := NewRegistry(NewGitLab(), NewGitHub())
+
+	require.Equal("GitLab", registry.Find("https://gitlab.com/group/qs/-/issues/7").Name())
+	require.Equal("GitHub", registry.Find("https://github.com/untillpro/qs/issues/42").Name())
+	require.Nil(registry.Find("https://dev.untill.com/projects/#!763090"))
+}
+
+func TestGitHub_Link(t *testing.T) {
+	require := require.New(t)
+	hp := &linkRecorder{}
+	env := Env{Hosting: hp}
+	branch := Branch{RepoFullName: "fork-account/qs", Name: "42-fix-bug-dev"}
+
+	require.NoError(NewGitHub().Link(context.Background(), env, "https://github.com/untillpro/qs/issues/42", branch))
+	require.Equal([]string{"untillpro/qs#42 fork-account/qs:42-fix-bug-dev"}, hp.linked)
+
+	require.Error(NewGitHub().Link(context.Background(), env, "https://github.com/issues/42", branch))
+	require.ErrorIs(NewGitLab().Link(context.Background(), env, "https://gitlab.com/group/qs/-/issues/7", branch), ErrNotSupported)
+}
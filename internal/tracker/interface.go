@@ -0,0 +1,27 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package tracker
+
+import "context"
+
+// IssueTracker is an issue tracker (GitHub issues, Jira etc.) qs dev creates branches for.
+// Issues are referred to by their URLs.
+type IssueTracker interface {
+	// Name returns the human-readable name of the tracker, e.g. "Jira"
+	Name() string
+	// Match returns true if the URL is an issue URL of the tracker
+	Match(issueURL string) bool
+	// ExtractID returns the issue ID used as the branch name prefix, e.g. "42" or "AIR-270"
+	ExtractID(issueURL string) string
+	// FetchTitle returns the title of the issue
+	FetchTitle(ctx context.Context, env Env, issueURL string) (string, error)
+	// Link links the existing remote branch to the issue.
+	// Returns ErrNotSupported if the tracker can not link branches.
+	Link(ctx context.Context, env Env, issueURL string, branch Branch) error
+	// Transition moves the issue to the status with the given name, e.g. "In Progress".
+	// Returns ErrNotSupported if the tracker has no workflow statuses.
+	Transition(ctx context.Context, env Env, issueURL, status string) error
+}
@@ -0,0 +1,21 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package linear
+
+import "time"
+
+const (
+	// DefaultAPIURL is the GraphQL API endpoint of Linear
+	DefaultAPIURL = "https://api.linear.app/graphql"
+
+	envLinearAPIKey = "LINEAR_API_KEY"
+
+	httpTimeout = 30 * time.Second
+
+	queryIssueTitle          = `query($id: String!) { issue(id: $id) { title } }`
+	queryIssueTeamStates     = `query($id: String!) { issue(id: $id) { id team { states { nodes { id name } } } } }`
+	mutationUpdateIssueState = `mutation($id: String!, $stateId: String!) { issueUpdate(id: $id, input: { stateId: $stateId }) { success } }`
+)
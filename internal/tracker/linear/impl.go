@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/untillpro/qs/internal/tracker"
+	"github.com/untillpro/qs/utils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+func (c *Client) Name() string {
+	return "Linear"
+}
+
+func (c *Client) Match(issueURL string) bool {
+	return issueURLRegexp.MatchString(issueURL)
+}
+
+func (c *Client) ExtractID(issueURL string) string {
+	if matches := issueURLRegexp.FindStringSubmatch(issueURL); matches != nil {
+		return matches[1]
+	}
+
+	return ""
+}
+
+func (c *Client) FetchTitle(ctx context.Context, _ tracker.Env, issueURL string) (string, error) {
+	id := c.ExtractID(issueURL)
+
+	var data struct {
+		Issue struct {
+			Title string `json:"title"`
+		} `json:"issue"`
+	}
+	if err := c.graphQL(ctx, queryIssueTitle, map[string]any{"id": id}, &data); err != nil {
+		return "", fmt.Errorf("failed to get Linear issue %s: %w", id, err)
+	}
+	logger.Verbose(data.Issue.Title)
+
+	return data.Issue.Title, nil
+}
+
+// Link is not supported: Linear links branches by the issue ID in the branch name
+func (c *Client) Link(context.Context, tracker.Env, string, tracker.Branch) error {
+	return tracker.ErrNotSupported
+}
+
+// Transition moves the issue to the workflow state of its team with the given name
+func (c *Client) Transition(ctx context.Context, _ tracker.Env, issueURL, status string) error {
+	id := c.ExtractID(issueURL)
+
+	var data struct {
+		Issue struct {
+			ID   string `json:"id"`
+			Team struct {
+				States struct {
+					Nodes []workflowState `json:"nodes"`
+				} `json:"states"`
+			} `json:"team"`
+		} `json:"issue"`
+	}
+	if err := c.graphQL(ctx, queryIssueTeamStates, map[string]any{"id": id}, &data); err != nil {
+		return fmt.Errorf("failed to get workflow states of Linear issue %s: %w", id, err)
+	}
+
+	for _, state := range data.Issue.Team.States.Nodes {
+		if !strings.EqualFold(state.Name, status) {
+			continue
+		}
+		vars := map[string]any{"id": data.Issue.ID, "stateId": state.ID}
+		if err := c.graphQL(ctx, mutationUpdateIssueState, vars, nil); err != nil {
+			return fmt.Errorf("failed to move Linear issue %s to %q: %w", id, status, err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("no workflow state %q for Linear issue %s", status, id)
+}
+
+// graphQL executes the query and decodes the response data into respData if it is not nil
+func (c *Client) graphQL(ctx context.Context, query string, vars map[string]any, respData any) error {
+	token, err := c.token()
+	if err != nil {
+		return err
+	}
+
+	bb, err := json.Marshal(graphQLRequest{Query: query, Variables: vars})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL, bytes.NewReader(bb))
+	if err != nil {
+		return err
+	}
+	// personal API keys are passed as is, without the Bearer scheme
+	req.Header.Set("Authorization", token)
+	req.Header.Set("Content-Type", "application/json")
+
+	logger.Verbose(http.MethodPost, c.apiURL)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var gqlResp graphQLResponse
+	if err := json.Unmarshal(respBytes, &gqlResp); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return utils.HTTPStatusError(resp.StatusCode, errors.New(resp.Status))
+		}
+
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(gqlResp.Errors) > 0 {
+		messages := make([]string, 0, len(gqlResp.Errors))
+		notFound := false
+		for _, e := range gqlResp.Errors {
+			messages = append(messages, e.Message)
+			notFound = notFound || strings.Contains(strings.ToLower(e.Message), "not found")
+		}
+		if notFound {
+			return fmt.Errorf("%s: %w", strings.Join(messages, "; "), tracker.ErrNotFound)
+		}
+
+		return errors.New(strings.Join(messages, "; "))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return utils.HTTPStatusError(resp.StatusCode, errors.New(resp.Status))
+	}
+
+	if respData == nil {
+		return nil
+	}
+	if err := json.Unmarshal(gqlResp.Data, respData); err != nil {
+		return fmt.Errorf("failed to parse response data: %w", err)
+	}
+
+	return nil
+}
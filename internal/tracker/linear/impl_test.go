@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/tracker"
+)
+
+const testIssueURL = "https://linear.app/untill/issue/ENG-12/fix-bug"
+
+// newTestClient returns the client of the test server answering the queries by their text
+func newTestClient(t *testing.T, answers map[string]string) (*Client, *[]graphQLRequest) {
+	var requests []graphQLRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "lin_api_test", r.Header.Get("Authorization"))
+
+		var req graphQLRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+		_, _ = w.Write([]byte(answers[req.Query]))
+	}))
+	t.Cleanup(srv.Close)
+
+	return New(srv.URL, hosting.StaticToken("lin_api_test")), &requests
+}
+
+func TestMatch(t *testing.T) {
+	client := New(DefaultAPIURL, DefaultToken())
+
+	require.True(t, client.Match(testIssueURL))
+	require.Equal(t, "ENG-12", client.ExtractID(testIssueURL))
+	require.Equal(t, "ENG-12", client.ExtractID("https://linear.app/untill/issue/ENG-12"))
+	require.False(t, client.Match("https://example.youtrack.cloud/issue/ENG-12"))
+	require.False(t, client.Match("https://linear.app/untill/project/qs-1234"))
+}
+
+func TestFetchTitle(t *testing.T) {
+	require := require.New(t)
+	client, requests := newTestClient(t, map[string]string{
+		queryIssueTitle: `{"data":{"issue":{"title":"Fix bug"}}}`,
+	})
+
+	title, err := client.FetchTitle(context.Background(), tracker.Env{}, testIssueURL)
+	require.NoError(err)
+	require.Equal("Fix bug", title)
+	require.Equal(map[string]any{"id": "ENG-12"}, (*requests)[0].Variables)
+}
+
+func TestFetchTitle_NotFound(t *testing.T) {
+	client, _ := newTestClient(t, map[string]string{
+		queryIssueTitle: `{"data":null,"errors":[{"message":"Entity not found: Issue"}]}`,
+	})
+
+	_, err := client.FetchTitle(context.Background(), tracker.Env{}, testIssueURL)
+	require.ErrorIs(t, err, tracker.ErrNotFound)
+}
+
+func TestTransition(t *testing.T) {
+	require := require.New(t)
+	client, requests := newTestClient(t, map[string]string{
+		queryIssueTeamStates: `{"data":{"issue":{"id":"uuid-12","team":{"states":{"nodes":[
+			{"id":"state-todo","name":"Todo"},{"id":"state-progress","name":"In Progress"}
+		]}}}}}`,
+		mutationUpdateIssueState: `{"data":{"issueUpdate":{"success":true}}}`,
+	})
+
+	require.NoError(client.Transition(context.Background(), tracker.Env{}, testIssueURL, "in progress"))
+	require.Len(*requests, 2)
+	require.Equal(map[string]any{"id": "uuid-12", "stateId": "state-progress"}, (*requests)[1].Variables)
+
+	require.Error(client.Transition(context.Background(), tracker.Env{}, testIssueURL, "Done"))
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package linear
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/tracker"
+)
+
+// New returns the tracker of Linear issues, e.g. https://linear.app/untill/issue/ENG-12/fix-bug,
+// using the GraphQL API located at apiURL
+func New(apiURL string, token hosting.TokenFunc) *Client {
+	return &Client{
+		apiURL:     apiURL,
+		token:      token,
+		httpClient: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// DefaultToken returns a TokenFunc which takes the personal API key from LINEAR_API_KEY
+func DefaultToken() hosting.TokenFunc {
+	return func() (string, error) {
+		if token := strings.TrimSpace(os.Getenv(envLinearAPIKey)); len(token) > 0 {
+			return token, nil
+		}
+
+		return "", fmt.Errorf("%w: set %s environment variable", tracker.ErrTokenNotFound, envLinearAPIKey)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package linear
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// Client is the tracker of Linear issues
+type Client struct {
+	apiURL     string
+	token      hosting.TokenFunc
+	httpClient *http.Client
+}
+
+// issueURLRegexp matches Linear issue URLs like "https://linear.app/<workspace>/issue/<ID>[/<slug>]".
+// Group 1 is the issue ID.
+var issueURLRegexp = regexp.MustCompile(`https://linear\.app/[^\s/]+/issue/([A-Za-z][A-Za-z0-9]*-[0-9]+)(?:[/?#]|$)`)
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type workflowState struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
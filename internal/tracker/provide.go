@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package tracker
+
+// NewRegistry returns the registry of the given trackers.
+// Trackers are matched in the given order, so more specific ones must go first.
+func NewRegistry(trackers ...IssueTracker) *Registry {
+	return &Registry{trackers: trackers}
+}
+
+// NewGitHub returns the tracker of GitHub issues, e.g. https://github.com/untillpro/qs/issues/42
+func NewGitHub() IssueTracker {
+	return githubTracker{}
+}
+
+// NewGitLab returns the tracker of GitLab issues, e.g. https://gitlab.com/group/sub/qs/-/issues/7
+func NewGitLab() IssueTracker {
+	return gitlabTracker{}
+}
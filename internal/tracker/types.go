@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package tracker
+
+import "github.com/untillpro/qs/internal/hosting"
+
+// Env holds the dependencies of the current repository trackers may use
+type Env struct {
+	// Hosting is the hosting provider of the issue URL host
+	Hosting hosting.HostingProvider
+}
+
+// Branch describes a remote branch
+type Branch struct {
+	// RepoFullName is the full name of the repo the branch belongs to, e.g. "fork-account/qs"
+	RepoFullName string
+	Name         string
+}
+
+// Registry is an ordered list of trackers, the first matching one is used
+type Registry struct {
+	trackers []IssueTracker
+}
+
+// githubTracker handles GitHub-style issue URLs: GitHub, GitHub Enterprise Server and Gitea/Forgejo
+type githubTracker struct{}
+
+// gitlabTracker handles issues of GitLab
+type gitlabTracker struct{}
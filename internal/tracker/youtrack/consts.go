@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package youtrack
+
+import "time"
+
+const (
+	// apiPath is the REST API root relative to the YouTrack site
+	apiPath = "/api"
+
+	envYouTrackToken = "YOUTRACK_TOKEN"
+	// envYouTrackURL is the YouTrack site used until SetSiteURL is called
+	envYouTrackURL = "YOUTRACK_URL"
+
+	httpTimeout = 30 * time.Second
+
+	// stateCommand is the command setting the State field, braces allow multi-word values
+	stateCommand = "State {%s}"
+)
@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package youtrack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/untillpro/qs/internal/tracker"
+	"github.com/untillpro/qs/utils"
+	"github.com/voedger/voedger/pkg/goutils/logger"
+)
+
+func (c *Client) Name() string {
+	return "YouTrack"
+}
+
+// Match returns true for issue URLs of the configured site only, the token is not sent to other sites
+func (c *Client) Match(issueURL string) bool {
+	_, _, err := c.parseIssueURL(issueURL)
+
+	return err == nil
+}
+
+func (c *Client) ExtractID(issueURL string) string {
+	_, id, _ := c.parseIssueURL(issueURL)
+
+	return id
+}
+
+func (c *Client) FetchTitle(ctx context.Context, _ tracker.Env, issueURL string) (string, error) {
+	site, id, err := c.parseIssueURL(issueURL)
+	if err != nil {
+		return "", err
+	}
+
+	var i issue
+	if err := c.do(ctx, http.MethodGet, site+apiPath+"/issues/"+url.PathEscape(id)+"?fields=summary", nil, &i); err != nil {
+		return "", fmt.Errorf("failed to get YouTrack issue %s: %w", id, err)
+	}
+	logger.Verbose(i.Summary)
+
+	return i.Summary, nil
+}
+
+// Link is not supported: YouTrack VCS integration relates branches to issues by the issue ID in the branch name
+func (c *Client) Link(context.Context, tracker.Env, string, tracker.Branch) error {
+	return tracker.ErrNotSupported
+}
+
+// Transition sets the State field of the issue by applying a command
+func (c *Client) Transition(ctx context.Context, _ tracker.Env, issueURL, status string) error {
+	site, id, err := c.parseIssueURL(issueURL)
+	if err != nil {
+		return err
+	}
+
+	cmd := command{
+		Query:  fmt.Sprintf(stateCommand, status),
+		Issues: []issueRef{{IDReadable: id}},
+	}
+	if err := c.do(ctx, http.MethodPost, site+apiPath+"/commands", cmd, nil); err != nil {
+		return fmt.Errorf("failed to set state of YouTrack issue %s to %q: %w", id, status, err)
+	}
+
+	return nil
+}
+
+// parseIssueURL returns the site and the issue ID of the issue URL of the configured site
+func (c *Client) parseIssueURL(issueURL string) (site, id string, err error) {
+	matches := issueURLRegexp.FindStringSubmatch(issueURL)
+	if matches == nil {
+		return "", "", fmt.Errorf("invalid YouTrack issue URL: %s", issueURL)
+	}
+	if configured := c.site(); len(configured) == 0 || !strings.EqualFold(matches[1], configured) {
+		return "", "", fmt.Errorf("%s is not an issue of the configured YouTrack site, set youtrack.url", issueURL)
+	}
+
+	return matches[1], matches[2], nil
+}
+
+// do sends a request to the REST API URL and decodes the JSON response into respBody if it is not nil
+func (c *Client) do(ctx context.Context, method, reqURL string, reqBody any, respBody any) error {
+	token, err := c.token()
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if reqBody != nil {
+		bb, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(bb)
+	}
+
+	if !strings.HasPrefix(reqURL, "https://") {
+		return fmt.Errorf("%w: %s is not served over https", tracker.ErrUntrustedSite, reqURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	logger.Verbose(method, reqURL)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var apiErr apiError
+		_ = json.Unmarshal(respBytes, &apiErr)
+		if resp.StatusCode == http.StatusNotFound {
+			return utils.HTTPStatusError(resp.StatusCode, fmt.Errorf("%s: %w", apiErr.Description, tracker.ErrNotFound))
+		}
+
+		return utils.HTTPStatusError(resp.StatusCode, errors.New(resp.Status+": "+apiErr.Description))
+	}
+
+	if respBody == nil || len(respBytes) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBytes, respBody); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return nil
+}
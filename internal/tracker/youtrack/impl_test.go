@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package youtrack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/tracker"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		url    string
+		wantID string
+	}{
+		{url: "https://example.youtrack.cloud/issue/QS-12", wantID: "QS-12"},
+		{url: "https://example.youtrack.cloud/issue/QS-12/fix-bug", wantID: "QS-12"},
+		{url: "https://example.youtrack.cloud/issues/QS"},
+		{url: "https://github.com/untillpro/qs/issues/42"},
+		{url: "https://other.youtrack.cloud/issue/QS-12", wantID: ""},
+		{url: "http://example.youtrack.cloud/issue/QS-12", wantID: ""},
+	}
+
+	client := New(StaticSite("https://example.youtrack.cloud/"), hosting.StaticToken("perm:token"))
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			require.Equal(t, tt.wantID != "", client.Match(tt.url))
+			require.Equal(t, tt.wantID, client.ExtractID(tt.url))
+		})
+	}
+
+	t.Run("context path", func(t *testing.T) {
+		client := New(StaticSite("https://example.myjetbrains.com/youtrack"), hosting.StaticToken("perm:token"))
+		require.Equal(t, "QS_2-7", client.ExtractID("https://example.myjetbrains.com/youtrack/issue/QS_2-7#focus=Comments"))
+	})
+
+	t.Run("site is not configured", func(t *testing.T) {
+		require.False(t, New(StaticSite(""), hosting.StaticToken("perm:token")).Match("https://example.youtrack.cloud/issue/QS-12"))
+	})
+}
+
+// newTestClient returns the client of the https YouTrack site served by the handler under the path
+func newTestClient(t *testing.T, path string, handler http.HandlerFunc) (*Client, string) {
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+	client := New(StaticSite(srv.URL+path), hosting.StaticToken("perm:token"))
+	client.httpClient = srv.Client()
+
+	return client, srv.URL + path
+}
+
+func TestFetchTitle(t *testing.T) {
+	require := require.New(t)
+
+	client, site := newTestClient(t, "/youtrack", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal("Bearer perm:token", r.Header.Get("Authorization"))
+		require.Equal("/youtrack/api/issues/QS-12", r.URL.Path)
+		require.Equal("summary", r.URL.Query().Get("fields"))
+		_, _ = w.Write([]byte(`{"summary":"Fix bug","$type":"Issue"}`))
+	})
+
+	title, err := client.FetchTitle(context.Background(), tracker.Env{}, site+"/issue/QS-12/fix-bug")
+	require.NoError(err)
+	require.Equal("Fix bug", title)
+}
+
+func TestFetchTitle_NotFound(t *testing.T) {
+	client, site := newTestClient(t, "", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"Not Found","error_description":"Entity with id QS-99 not found"}`))
+	})
+
+	_, err := client.FetchTitle(context.Background(), tracker.Env{}, site+"/issue/QS-99")
+	require.ErrorIs(t, err, tracker.ErrNotFound)
+	require.ErrorContains(t, err, "Entity with id QS-99 not found")
+}
+
+func TestTransition(t *testing.T) {
+	require := require.New(t)
+
+	client, site := newTestClient(t, "", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(http.MethodPost, r.Method)
+		require.Equal("/api/commands", r.URL.Path)
+
+		var cmd command
+		require.NoError(json.NewDecoder(r.Body).Decode(&cmd))
+		require.Equal(command{Query: "State {In Progress}", Issues: []issueRef{{IDReadable: "QS-12"}}}, cmd)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	require.NoError(client.Transition(context.Background(), tracker.Env{}, site+"/issue/QS-12", "In Progress"))
+}
+
+func TestDefaultToken(t *testing.T) {
+	t.Setenv(envYouTrackToken, "")
+	_, err := DefaultToken()()
+	require.ErrorIs(t, err, tracker.ErrTokenNotFound)
+
+	t.Setenv(envYouTrackToken, "perm:token")
+	token, err := DefaultToken()()
+	require.NoError(t, err)
+	require.Equal(t, "perm:token", token)
+}
+
+func TestFetchTitle_NotHTTPS(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+	}))
+	defer srv.Close()
+
+	_, err := New(StaticSite(srv.URL), hosting.StaticToken("perm:token")).FetchTitle(context.Background(), tracker.Env{}, srv.URL+"/issue/QS-12")
+	require.ErrorIs(t, err, tracker.ErrUntrustedSite)
+	require.Zero(t, requests)
+}
+
+func TestDefaultSite(t *testing.T) {
+	site, configured := siteURL, siteURLConfigured
+	t.Cleanup(func() {
+		siteURL, siteURLConfigured = site, configured
+	})
+	siteURLConfigured = false
+	t.Setenv(envYouTrackURL, "https://env.youtrack.cloud/")
+	require.Equal(t, "https://env.youtrack.cloud", DefaultSite()())
+
+	// the config resolves -c youtrack.url above YOUTRACK_URL
+	SetSiteURL("https://flag.youtrack.cloud")
+	require.Equal(t, "https://flag.youtrack.cloud", DefaultSite()())
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package youtrack
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/tracker"
+)
+
+// New returns the tracker of YouTrack issues of the site, e.g. https://example.youtrack.cloud/issue/QS-12/fix-bug
+func New(site SiteFunc, token hosting.TokenFunc) *Client {
+	return &Client{
+		site:       site,
+		token:      token,
+		httpClient: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// StaticSite returns a SiteFunc which always returns the given site
+func StaticSite(siteURL string) SiteFunc {
+	return func() string {
+		return strings.TrimSuffix(siteURL, "/")
+	}
+}
+
+// DefaultSite returns a SiteFunc which returns the site set by SetSiteURL or taken from YOUTRACK_URL
+func DefaultSite() SiteFunc {
+	return func() string {
+		if !siteURLConfigured {
+			return strings.TrimSuffix(strings.TrimSpace(os.Getenv(envYouTrackURL)), "/")
+		}
+
+		return siteURL
+	}
+}
+
+// SetSiteURL sets the YouTrack site resolved by the layered config.
+// YOUTRACK_URL is not read afterwards since the config has already applied it below the flags.
+func SetSiteURL(site string) {
+	siteURL = strings.TrimSuffix(site, "/")
+	siteURLConfigured = true
+}
+
+// DefaultToken returns a TokenFunc which takes the permanent token from YOUTRACK_TOKEN
+func DefaultToken() hosting.TokenFunc {
+	return func() (string, error) {
+		if token := strings.TrimSpace(os.Getenv(envYouTrackToken)); len(token) > 0 {
+			return token, nil
+		}
+
+		return "", fmt.Errorf("%w: set %s environment variable", tracker.ErrTokenNotFound, envYouTrackToken)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package youtrack
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/untillpro/qs/internal/hosting"
+)
+
+// Client is the tracker of YouTrack issues of the configured site
+type Client struct {
+	site       SiteFunc
+	token      hosting.TokenFunc
+	httpClient *http.Client
+}
+
+var (
+	// siteURL is the YouTrack site resolved by the layered config, see SetSiteURL
+	siteURL = ""
+	// siteURLConfigured is true if the site is resolved by the layered config, YOUTRACK_URL is not read then
+	siteURLConfigured = false
+)
+
+// SiteFunc returns the YouTrack site, e.g. "https://example.youtrack.cloud", empty if it is not configured
+type SiteFunc func() string
+
+// issueURLRegexp matches YouTrack issue URLs like "https://<site>/issue/<ID>[/<slug>]",
+// where site may contain a context path, e.g. "https://example.myjetbrains.com/youtrack".
+// Group 1 is the site, group 2 is the issue ID.
+var issueURLRegexp = regexp.MustCompile(`(https?://[^\s/]+(?:/[^\s]*?)?)/issue/([A-Za-z][A-Za-z0-9_]*-[0-9]+)(?:[/?#]|$)`)
+
+type issue struct {
+	Summary string `json:"summary"`
+}
+
+type issueRef struct {
+	IDReadable string `json:"idReadable"`
+}
+
+type command struct {
+	Query  string     `json:"query"`
+	Issues []issueRef `json:"issues"`
+}
+
+type apiError struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
@@ -2,16 +2,88 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
 
+	"github.com/untillpro/qs/gitcmds"
 	"github.com/untillpro/qs/internal/cmdproc"
+	"github.com/untillpro/qs/internal/config"
+	"github.com/untillpro/qs/internal/hosting"
+	"github.com/untillpro/qs/internal/journal"
+	"github.com/untillpro/qs/internal/output"
+	"github.com/untillpro/qs/internal/prompt"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
+// Exit codes of qs, scripts may rely on them
+const (
+	exitOK = 0
+	// exitFailure is any error not listed below
+	exitFailure = 1
+	// exitUsage: unknown flag, wrong number of args, unknown output format or config key
+	exitUsage = 2
+	// exitPrecondition: the repository is not in the state the command needs, e.g. there are uncommitted changes
+	exitPrecondition = 3
+	// exitDiverged: the branch can not be fast-forwarded or rebased without conflicts
+	exitDiverged = 4
+	// exitInterrupted: a previous command is interrupted, run qs recover
+	exitInterrupted = 5
+	// exitCommitTooLarge: the pre-commit check rejected too large files
+	exitCommitTooLarge = 6
+	// exitPRExists: the pull request already exists
+	exitPRExists = 7
+	// exitInputRequired: a question must be answered but input is not available, see --yes
+	exitInputRequired = 8
+	// exitAuth: the hosting token is missing or rejected
+	exitAuth = 9
+	// exitCancelled: the command is cancelled by the user, e.g. by Ctrl+C
+	exitCancelled = 130
+)
+
+// exitCodes maps errors to exit codes, the first match wins
+var exitCodes = []struct {
+	errs []error
+	code int
+}{
+	{[]error{context.Canceled}, exitCancelled},
+	{[]error{cmdproc.ErrUsage, output.ErrUnknownFormat, config.ErrUnknownKey}, exitUsage},
+	{[]error{journal.ErrInterrupted}, exitInterrupted},
+	{[]error{gitcmds.ErrDiverged, gitcmds.ErrRebaseConflict}, exitDiverged},
+	{[]error{gitcmds.ErrCommitTooLarge}, exitCommitTooLarge},
+	{[]error{gitcmds.ErrPRExists}, exitPRExists},
+	{[]error{prompt.ErrAnswerRequired, prompt.ErrNoTerminal}, exitInputRequired},
+	{[]error{hosting.ErrAuthFailed, hosting.ErrTokenNotFound}, exitAuth},
+	{[]error{
+		gitcmds.ErrNotGitRepo,
+		config.ErrNotInRepo,
+		gitcmds.ErrUncommittedChanges,
+		gitcmds.ErrNotOnDevBranch,
+		gitcmds.ErrNoCommits,
+		gitcmds.ErrBranchExists,
+		gitcmds.ErrAlreadyForked,
+	}, exitPrecondition},
+}
+
 func main() {
 	if _, err := cmdproc.ExecRootCmd(context.Background(), os.Args); err != nil {
 		logger.Verbose(err)
 
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
+
+// exitCode returns the exit code of qs for the error of the command
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	for _, c := range exitCodes {
+		for _, target := range c.errs {
+			if errors.Is(err, target) {
+				return c.code
+			}
+		}
+	}
+
+	return exitFailure
+}
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -8,6 +11,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/untillpro/qs/gitcmds"
+	"github.com/untillpro/qs/internal/cmdproc"
+	"github.com/untillpro/qs/internal/journal"
+	"github.com/untillpro/qs/internal/prompt"
 )
 
 func TestClipBoard(t *testing.T) {
@@ -28,3 +34,24 @@ func TestIssueRepoFromURL(t *testing.T) {
 	repo := gitcmds.GetGithubIssueRepoFromURL("https://github.com/untillpro/qs/issues/24")
 	assert.Equal(t, "untillpro/qs", repo)
 }
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{nil, exitOK},
+		{errors.New("boom"), exitFailure},
+		{fmt.Errorf("%w: unknown flag: --foo", cmdproc.ErrUsage), exitUsage},
+		{fmt.Errorf("failed to create PR branch: %w", gitcmds.ErrUncommittedChanges), exitPrecondition},
+		{gitcmds.NewGitError([]string{"merge"}, "fatal: Not possible to fast-forward, aborting.", errors.New("exit status 128")), exitDiverged},
+		{fmt.Errorf("%w: qs pr started at 2026-01-02 03:04:05", journal.ErrInterrupted), exitInterrupted},
+		{gitcmds.ErrCommitTooLarge, exitCommitTooLarge},
+		{gitcmds.ErrPRExists, exitPRExists},
+		{prompt.ErrAnswerRequired, exitInputRequired},
+		{context.Canceled, exitCancelled},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.code, exitCode(test.err), "%v", test.err)
+	}
+}
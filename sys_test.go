@@ -81,8 +81,8 @@ func TestDev_CustomName(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Stdin:   "y",
+			Command:   "dev",
+			AssumeYes: true,
 		},
 		ClipboardContent: systrun.ClipboardContentCustom,
 		UpstreamState:    systrun.RemoteStateOK,
@@ -107,9 +107,9 @@ func TestDev_ExistingBranch(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{branchName},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{branchName},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		ForkState:     systrun.RemoteStateOK,
@@ -134,9 +134,9 @@ func TestDev_ExistingBranch_NoUpstream(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{branchName},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{branchName},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		BranchState: &systrun.BranchState{
@@ -160,8 +160,8 @@ func TestDev_GitHubIssue_NoUpstream(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: ghConfig,
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Stdin:   "y",
+			Command:   "dev",
+			AssumeYes: true,
 		},
 		UpstreamState:     systrun.RemoteStateOK,
 		ForkState:         systrun.RemoteStateNull,
@@ -237,8 +237,8 @@ func TestDev_NonExistingIssue_NoUpstream(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: ghConfig,
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Stdin:   "y",
+			Command:   "dev",
+			AssumeYes: true,
 		},
 		ClipboardContent: systrun.ClipboardContentUnavailableGithubIssue,
 		UpstreamState:    systrun.RemoteStateOK,
@@ -257,8 +257,8 @@ func TestDev_JiraTicketURL_NoUpstream(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Stdin:   "y",
+			Command:   "dev",
+			AssumeYes: true,
 		},
 		UpstreamState:    systrun.RemoteStateOK,
 		ClipboardContent: systrun.ClipboardContentJiraTicket,
@@ -299,8 +299,8 @@ func TestDev_JiraTicketURL_InvalidJiraAPIToken(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Stdin:   "y",
+			Command:   "dev",
+			AssumeYes: true,
 		},
 		UpstreamState:    systrun.RemoteStateOK,
 		ClipboardContent: systrun.ClipboardContentJiraTicket,
@@ -486,8 +486,8 @@ func TestUpload(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "u",
-			Stdin:   "y",
+			Command:   "u",
+			AssumeYes: true,
 		},
 		UpstreamState:     systrun.RemoteStateOK,
 		ForkState:         systrun.RemoteStateOK,
@@ -510,8 +510,8 @@ func TestUpload_NothingToCommit(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "u",
-			Stdin:   "y",
+			Command:   "u",
+			AssumeYes: true,
 		},
 		UpstreamState:     systrun.RemoteStateOK,
 		ForkState:         systrun.RemoteStateOK,
@@ -536,9 +536,9 @@ func TestDevD_DevBranch_NoRT_NoPR(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		BranchState: &systrun.BranchState{
@@ -563,9 +563,9 @@ func TestDevD_DevBranch_RT_NoPR(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		BranchState: &systrun.BranchState{
@@ -591,9 +591,9 @@ func TestDevD_DevBranch_RT_PROpen(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		ForkState:     systrun.RemoteStateOK,
@@ -621,9 +621,9 @@ func TestDevD_DevBranch_RT_PRMerged(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		ForkState:     systrun.RemoteStateOK,
@@ -651,9 +651,9 @@ func TestDevD_DevBranch_NoRT_PRMerged(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		ForkState:     systrun.RemoteStateOK,
@@ -680,9 +680,9 @@ func TestDevD_PrBranch_NoRT_PRMerged(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		ForkState:     systrun.RemoteStateOK,
@@ -709,9 +709,9 @@ func TestDevD_PrBranch_RT_PRMerged(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		ForkState:     systrun.RemoteStateOK,
@@ -739,9 +739,9 @@ func TestDevD_PrBranch_NoRT_PROpen(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		ForkState:     systrun.RemoteStateOK,
@@ -768,9 +768,9 @@ func TestDevD_DevBranch_PrBranch_PROpen(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		ForkState:     systrun.RemoteStateOK,
@@ -798,9 +798,9 @@ func TestDevD_DevBranch_PrBranch_PRMerged(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState: systrun.RemoteStateOK,
 		ForkState:     systrun.RemoteStateOK,
@@ -828,9 +828,9 @@ func TestDevD_NoBranches(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Args:    []string{"-d"},
-			Stdin:   "y",
+			Command:   "dev",
+			Args:      []string{"-d"},
+			AssumeYes: true,
 		},
 		UpstreamState:     systrun.RemoteStateOK,
 		ForkState:         systrun.RemoteStateOK,
@@ -884,8 +884,8 @@ func TestLargeFileHook(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Stdin:   "y",
+			Command:   "dev",
+			AssumeYes: true,
 		},
 		UpstreamState:     systrun.RemoteStateOK,
 		ForkState:         systrun.RemoteStateNull,
@@ -936,8 +936,8 @@ func TestDev_CustomName_NoUpstream(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Stdin:   "y",
+			Command:   "dev",
+			AssumeYes: true,
 		},
 		ClipboardContent: systrun.ClipboardContentCustom,
 		UpstreamState:    systrun.RemoteStateOK,
@@ -962,8 +962,8 @@ func TestDev_MainBranchConflict(t *testing.T) {
 		TestID:   strings.ToLower(t.Name()),
 		GHConfig: getGithubConfig(t),
 		CommandConfig: &systrun.CommandConfig{
-			Command: "dev",
-			Stdin:   "y",
+			Command:   "dev",
+			AssumeYes: true,
 		},
 		ClipboardContent:  systrun.ClipboardContentGithubIssue,
 		UpstreamState:     systrun.RemoteStateOK,
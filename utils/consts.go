@@ -5,17 +5,10 @@
 
 package utils
 
-import "time"
-
 const (
 	defaultGhTimeoutMs = 1500
 	ghTimeoutMsEnv     = "GH_TIMEOUT_MS"
 
-	// Retry configuration constants
-	defaultMaxRetries    = 3
-	defaultRetryDelay    = 2 * time.Second
-	defaultMaxRetryDelay = 30 * time.Second
-
 	// Retry configuration environment variables
 	maxRetriesEnv      = "QS_MAX_RETRIES"
 	retryDelayMsEnv    = "QS_RETRY_DELAY_MS"
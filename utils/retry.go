@@ -6,7 +6,10 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 	"time"
@@ -14,6 +17,24 @@ import (
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
+// Retry settings, the environment variables override them until SetRetryDefaults is called
+var (
+	defaultMaxRetries    = 3
+	defaultRetryDelay    = 2 * time.Second
+	defaultMaxRetryDelay = 30 * time.Second
+	// retryConfigured is true if the settings are resolved by the layered config, the environment is not read then
+	retryConfigured = false
+)
+
+// SetRetryDefaults sets the retry settings resolved by the layered config: flags, environment, config files and defaults.
+// The retry environment variables are not read afterwards since the config has already applied them below the flags.
+func SetRetryDefaults(maxRetries int, delay, maxDelay time.Duration) {
+	defaultMaxRetries = maxRetries
+	defaultRetryDelay = delay
+	defaultMaxRetryDelay = maxDelay
+	retryConfigured = true
+}
+
 // RetryConfig holds configuration for retry operations
 type RetryConfig struct {
 	MaxRetries   int
@@ -22,9 +43,22 @@ type RetryConfig struct {
 	Backoff      func(attempt int, delay time.Duration) time.Duration
 }
 
-// getMaxRetries returns the maximum number of retries from environment or default
+// nonRetryableError is an error Retry does not retry, see NonRetryable
+type nonRetryableError struct {
+	err error
+}
+
+func (e *nonRetryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *nonRetryableError) Unwrap() error {
+	return e.err
+}
+
+// getMaxRetries returns the maximum number of retries from environment or the configured one
 func getMaxRetries() int {
-	if envVal := os.Getenv(maxRetriesEnv); envVal != "" {
+	if envVal := os.Getenv(maxRetriesEnv); envVal != "" && !retryConfigured {
 		if val, err := strconv.Atoi(envVal); err == nil && val >= 0 {
 			return val
 		}
@@ -34,9 +68,9 @@ func getMaxRetries() int {
 	return defaultMaxRetries
 }
 
-// getRetryDelay returns the initial retry delay from environment or default
+// getRetryDelay returns the initial retry delay from environment or the configured one
 func getRetryDelay() time.Duration {
-	if envVal := os.Getenv(retryDelayMsEnv); envVal != "" {
+	if envVal := os.Getenv(retryDelayMsEnv); envVal != "" && !retryConfigured {
 		if val, err := strconv.Atoi(envVal); err == nil && val > 0 {
 			return time.Duration(val) * time.Millisecond
 		}
@@ -46,9 +80,9 @@ func getRetryDelay() time.Duration {
 	return defaultRetryDelay
 }
 
-// getMaxRetryDelay returns the maximum retry delay from environment or default
+// getMaxRetryDelay returns the maximum retry delay from environment or the configured one
 func getMaxRetryDelay() time.Duration {
-	if envVal := os.Getenv(maxRetryDelayMsEnv); envVal != "" {
+	if envVal := os.Getenv(maxRetryDelayMsEnv); envVal != "" && !retryConfigured {
 		if val, err := strconv.Atoi(envVal); err == nil && val > 0 {
 			return time.Duration(val) * time.Millisecond
 		}
@@ -68,6 +102,30 @@ func DefaultRetryConfig() *RetryConfig {
 	}
 }
 
+// NonRetryable marks the error as permanent, Retry returns it at once without retrying
+func NonRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &nonRetryableError{err: err}
+}
+
+// HTTPStatusError marks the error of the HTTP response as non-retryable if repeating the request can not help:
+// client errors (4xx) except 408 Request Timeout and 429 Too Many Requests.
+// 404 Not Found is retried since forks and just pushed branches appear on the hosting with a delay.
+func HTTPStatusError(statusCode int, err error) error {
+	if statusCode < http.StatusBadRequest || statusCode >= http.StatusInternalServerError {
+		return err
+	}
+	switch statusCode {
+	case http.StatusNotFound, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return err
+	}
+
+	return NonRetryable(err)
+}
+
 // ExponentialBackoff implements exponential backoff with jitter
 func ExponentialBackoff(attempt int, delay time.Duration) time.Duration {
 	newDelay := delay * time.Duration(1<<attempt)
@@ -90,15 +148,22 @@ func LinearBackoff(attempt int, delay time.Duration) time.Duration {
 	return newDelay
 }
 
-// RetryWithConfig executes a function with retry logic using the provided configuration
-func RetryWithConfig(fn func() error, config *RetryConfig) error {
+// RetryWithConfig executes a function with retry logic using the provided configuration.
+// Retrying stops when ctx is cancelled, the error wraps ctx.Err() then
+func RetryWithConfig(ctx context.Context, fn func() error, config *RetryConfig) error {
 	var lastErr error
 
 	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
 		if attempt > 0 {
 			delay := config.Backoff(attempt-1, config.InitialDelay)
 			logger.Verbose(fmt.Sprintf("Retry attempt %d/%d, waiting %v before retry", attempt, config.MaxRetries, delay))
-			time.Sleep(delay)
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("retry is interrupted: %w, last error: %w", ctx.Err(), lastErr)
+			case <-timer.C:
+			}
 		}
 
 		lastErr = fn()
@@ -109,6 +174,17 @@ func RetryWithConfig(fn func() error, config *RetryConfig) error {
 
 			return nil
 		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// the operation is interrupted, it is not retried
+			if errors.Is(lastErr, ctxErr) {
+				return lastErr
+			}
+			return fmt.Errorf("%w: %w", lastErr, ctxErr)
+		}
+		var nonRetryable *nonRetryableError
+		if errors.As(lastErr, &nonRetryable) {
+			return lastErr
+		}
 
 		if attempt < config.MaxRetries {
 			logger.Verbose(fmt.Sprintf("Attempt %d failed: %v", attempt+1, lastErr))
@@ -119,8 +195,8 @@ func RetryWithConfig(fn func() error, config *RetryConfig) error {
 }
 
 // Retry executes a function with default retry logic
-func Retry(fn func() error) error {
-	return RetryWithConfig(fn, DefaultRetryConfig())
+func Retry(ctx context.Context, fn func() error) error {
+	return RetryWithConfig(ctx, fn, DefaultRetryConfig())
 }
 
 // RetryConfigWithMaxAttempts creates a retry config with custom max attempts but environment-based delays
@@ -134,8 +210,8 @@ func RetryConfigWithMaxAttempts(maxAttempts int) *RetryConfig {
 }
 
 // RetryWithMaxAttempts executes a function with specified maximum attempts
-func RetryWithMaxAttempts(fn func() error, maxAttempts int) error {
+func RetryWithMaxAttempts(ctx context.Context, fn func() error, maxAttempts int) error {
 	config := RetryConfigWithMaxAttempts(maxAttempts)
 
-	return RetryWithConfig(fn, config)
+	return RetryWithConfig(ctx, fn, config)
 }
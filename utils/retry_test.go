@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2026-present unTill Software Development Group B.V.
+ * @author Denis Gribanov
+ */
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/untillpro/qs/internal/config"
+)
+
+func TestRetryWithConfig_Cancel(t *testing.T) {
+	config := &RetryConfig{MaxRetries: 3, InitialDelay: time.Minute, Backoff: LinearBackoff}
+	errFailed := errors.New("failed")
+
+	t.Run("backoff is aborted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		err := RetryWithConfig(ctx, func() error {
+			attempts++
+			return errFailed
+		}, config)
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, errFailed)
+		require.Equal(t, 1, attempts)
+	})
+
+	t.Run("interrupted operation is not retried", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts := 0
+
+		err := RetryWithConfig(ctx, func() error {
+			attempts++
+			cancel()
+			return errFailed
+		}, config)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 1, attempts)
+	})
+}
+
+func TestRetryWithConfig_NonRetryable(t *testing.T) {
+	config := &RetryConfig{MaxRetries: 3, InitialDelay: time.Minute, Backoff: LinearBackoff}
+	errInvalid := errors.New("validation failed")
+	attempts := 0
+
+	err := RetryWithConfig(context.Background(), func() error {
+		attempts++
+		return fmt.Errorf("failed to create PR: %w", HTTPStatusError(http.StatusUnprocessableEntity, errInvalid))
+	}, config)
+	require.ErrorIs(t, err, errInvalid)
+	require.Equal(t, 1, attempts)
+}
+
+func TestHTTPStatusError(t *testing.T) {
+	errFailed := errors.New("failed")
+	for _, statusCode := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusConflict, http.StatusUnprocessableEntity} {
+		var nonRetryable *nonRetryableError
+		require.ErrorAs(t, HTTPStatusError(statusCode, errFailed), &nonRetryable, statusCode)
+	}
+	for _, statusCode := range []int{http.StatusNotFound, http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway} {
+		require.Same(t, errFailed, HTTPStatusError(statusCode, errFailed), statusCode)
+	}
+}
+
+func TestSetRetryDefaults_FlagBeatsEnv(t *testing.T) {
+	maxRetries, delay, maxDelay, configured := defaultMaxRetries, defaultRetryDelay, defaultMaxRetryDelay, retryConfigured
+	t.Cleanup(func() {
+		defaultMaxRetries, defaultRetryDelay, defaultMaxRetryDelay, retryConfigured = maxRetries, delay, maxDelay, configured
+	})
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(maxRetriesEnv, "5")
+	t.Setenv(retryDelayMsEnv, "3000")
+	require.Equal(t, 5, getMaxRetries(), "environment overrides the built-in defaults")
+
+	cfg, err := config.Load(t.TempDir(), []string{"retry.max_retries=1"})
+	require.NoError(t, err)
+	SetRetryDefaults(cfg.Retry.MaxRetries, time.Duration(cfg.Retry.DelayMs)*time.Millisecond, time.Duration(cfg.Retry.MaxDelayMs)*time.Millisecond)
+	require.Equal(t, 1, getMaxRetries(), "flag overrides environment")
+	require.Equal(t, 3*time.Second, getRetryDelay(), "environment overrides defaults")
+	require.Equal(t, 30*time.Second, getMaxRetryDelay())
+}
@@ -44,6 +44,11 @@ func ReadVersion() (*Version, error) {
 	return nil, err
 }
 
+// Filename returns the file the version is read from
+func (v *Version) Filename() string {
+	return v.filename
+}
+
 func (v *Version) Save() error {
 	if v.filename == "version" {
 		return os.WriteFile(v.filename, []byte(v.String()), writeFilePermission)
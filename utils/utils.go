@@ -14,24 +14,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/untillpro/goutils/exec"
 	"github.com/voedger/voedger/pkg/goutils/logger"
 )
 
-// GetUserEmail - github user email
-func GetUserEmail() (string, error) {
-	var stdout string
-	err := Retry(func() error {
-		var apiErr error
-		stdout, _, apiErr = new(exec.PipedExec).
-			Command("gh", "api", "user", "--jq", ".email").
-			RunToStrings()
-		return apiErr
-	})
-
-	return strings.TrimSpace(stdout), err
-}
-
 // DelayIfTest is a helper function to delay execution for a specified time.
 // It reads the timeout from the environment variable GH_TIMEOUT_MS, defaulting to 1500 ms if not set.
 func DelayIfTest() {